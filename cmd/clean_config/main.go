@@ -1,16 +1,27 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 
+	"github.com/phravins/devcli/internal/config"
 	"github.com/spf13/viper"
 )
 
 func main() {
-	home, _ := os.UserHomeDir()
-	configPath := filepath.Join(home, ".devcli.yaml")
+	path := flag.String("config", "", "Config file to clean instead of the default (or set $"+config.EnvPath+")")
+	flag.Parse()
+	if err := config.SetPath(*path); err != nil {
+		fmt.Printf("Error locating config: %v\n", err)
+		os.Exit(1)
+	}
+
+	configPath, err := config.Path()
+	if err != nil {
+		fmt.Printf("Error locating config: %v\n", err)
+		os.Exit(1)
+	}
 
 	viper.SetConfigFile(configPath)
 	viper.ReadInConfig()
@@ -19,6 +30,6 @@ func main() {
 	if err := viper.WriteConfig(); err != nil {
 		fmt.Printf("Error writing config: %v\n", err)
 	} else {
-		fmt.Println("Successfully cleared ai_base_url in .devcli.yaml")
+		fmt.Printf("Successfully cleared ai_base_url in %s\n", configPath)
 	}
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the DevCLI config file",
+}
+
+var configResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Restore the default values of a group of settings",
+	Long: `Restores the documented defaults of one section of config.yaml, after showing what changes and asking to confirm.
+
+The "ui" section covers editor_theme, editor.*, ui.confirm_quit and the File Manager
+options (filemanager.max_index, grep_exclude, persist_search_history). AI keys,
+profiles, runner options and saved state such as search history are kept.
+
+  devcli config reset --section ui
+  devcli config reset --section ui --yes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		section, _ := cmd.Flags().GetString("section")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		if _, err := config.LoadConfig(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		changes, err := config.PlanReset(section)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(changes) == 0 {
+			fmt.Printf("The %s settings already use their defaults.\n", section)
+			return
+		}
+
+		printChanges(changes)
+		if !assumeYes {
+			if !stdinIsTerminal() {
+				fmt.Println("Error: not a terminal, pass --yes to reset without asking")
+				os.Exit(1)
+			}
+			fmt.Print("Reset these settings? [y/N]: ")
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Println("Nothing changed.")
+				return
+			}
+		}
+
+		if _, err := config.Reset(section); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Reset %d setting(s) to their defaults.\n", len(changes))
+	},
+}
+
+func init() {
+	configResetCmd.Flags().String("section", "", "Settings to reset: "+strings.Join(config.SectionNames(), ", ")+" (required)")
+	configResetCmd.Flags().BoolP("yes", "y", false, "Reset without asking")
+	configResetCmd.MarkFlagRequired("section")
+	configCmd.AddCommand(configResetCmd)
+}
+
+// printChanges lists each setting as key: old -> default
+func printChanges(changes []config.Change) {
+	width := 0
+	for _, c := range changes {
+		width = max(width, len(c.Key))
+	}
+	for _, c := range changes {
+		fmt.Printf("  %-*s  %s -> %s\n", width, c.Key, c.Old, c.New)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/devserver"
+	"github.com/phravins/devcli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev [path]",
+	Short: "Detect and run a project's development server",
+	Long: `Detects the framework in path (default: the current directory) and runs its dev server in the foreground, streaming the logs. Press Ctrl+C to stop. If a server fails, devcli dev exits with its status.
+
+Use --detect-only to print what would run, --json for machine-readable output, and --cmd to run your own command instead of the detected one. --port and --env set environment variables for every server, over the shell and .env (e.g. --port 4000 --env API_URL=http://localhost:9000).`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		detectOnly, _ := cmd.Flags().GetBool("detect-only")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		override, _ := cmd.Flags().GetString("cmd")
+		port, _ := cmd.Flags().GetString("port")
+		envPairs, _ := cmd.Flags().GetStringArray("env")
+		config.LoadConfig() // "shell" and "devserver.load_env"
+
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		info, dir, err := detectProject(path, override)
+		if err == nil {
+			err = setServerEnv(&info, port, envPairs)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if asJSON {
+			data, _ := json.MarshalIndent(info, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+		printDetection(info, dir)
+		if detectOnly {
+			return
+		}
+		if len(info.Servers) == 0 {
+			fmt.Println("Error: the Makefile has no dev, serve, start or run target; use --cmd \"make <target>\" to run one")
+			os.Exit(1)
+		}
+
+		runner := devserver.NewRunner()
+		runner.LoadEnvFile = config.GetString("devserver.load_env") != "false"
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		fmt.Println()
+		if err := runner.Run(ctx, info, os.Stdout); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				os.Exit(exitErr.ExitCode())
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	devCmd.Flags().Bool("detect-only", false, "Print the detected project and exit")
+	devCmd.Flags().Bool("json", false, "Print the detection result as JSON and exit (implies --detect-only)")
+	devCmd.Flags().String("cmd", "", `Command to run instead of the detected one (e.g. "npm run dev -- --port 4000")`)
+	devCmd.Flags().String("port", "", "Set PORT for the servers (most Node frameworks honour it)")
+	devCmd.Flags().StringArray("env", nil, "Set an environment variable, KEY=VALUE (repeatable)")
+}
+
+// setServerEnv gives every server the --port and --env variables
+func setServerEnv(info *devserver.ProjectInfo, port string, pairs []string) error {
+	var env []string
+	for _, kv := range pairs {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			return fmt.Errorf("--env %q is not KEY=VALUE", kv)
+		}
+		env = append(env, kv)
+	}
+	if port != "" {
+		env = append(env, "PORT="+port)
+	}
+	for i := range info.Servers {
+		info.Servers[i].Env = append(info.Servers[i].Env, env...)
+	}
+	return nil
+}
+
+// detectProject resolves path and detects its servers. A --cmd override
+// replaces them with one server running that command through the shell.
+func detectProject(path, override string) (devserver.ProjectInfo, string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return devserver.ProjectInfo{}, "", err
+	}
+	if st, err := os.Stat(dir); err != nil {
+		return devserver.ProjectInfo{}, "", err
+	} else if !st.IsDir() {
+		return devserver.ProjectInfo{}, "", fmt.Errorf("%s is not a directory", dir)
+	}
+
+	info := devserver.Detect(dir)
+	if override == "" {
+		if info.Type == devserver.TypeUnknown {
+			return info, dir, fmt.Errorf("%s is not a recognised project (no package.json, go.mod, manage.py, ...); use --cmd to run a command anyway", dir)
+		}
+		return info, dir, nil
+	}
+
+	server := devserver.ServerConfig{Name: "Server", Type: info.Type, Dir: dir}
+	if len(info.Servers) == 1 {
+		server.Name = info.Servers[0].Name
+	}
+	shell := utils.GetShellCommand(config.Shell(), override)
+	server.Cmd, server.Args = shell.Args[0], shell.Args[1:]
+	info.Servers = []devserver.ServerConfig{server}
+	return info, dir, nil
+}
+
+func printDetection(info devserver.ProjectInfo, dir string) {
+	fmt.Printf("Project: %s\n", dir)
+	detected := string(info.Type)
+	if marker := info.Type.Info().Marker; marker != "" {
+		detected += " (found " + marker + ")"
+	}
+	fmt.Printf("Type:    %s\n", detected)
+	if len(info.MakeTargets) > 0 {
+		fmt.Printf("Targets: %s\n", strings.Join(info.MakeTargets, " "))
+	}
+	for _, srv := range info.Servers {
+		fmt.Printf("Run:     %s", srv.CommandLine())
+		if len(srv.Env) > 0 {
+			fmt.Printf("   (%s)", devserver.FormatEnvPairs(srv.Env))
+		}
+		if len(info.Servers) > 1 || srv.Dir != dir {
+			rel, _ := filepath.Rel(dir, srv.Dir)
+			fmt.Printf("   [%s in %s]", srv.Name, rel)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install DevCLI globally to your system",
+	Long: `Copies the DevCLI binary into a bin folder and makes sure that folder is on your PATH.
+
+Run interactively to pick the folder and how PATH is updated, or pass --dir and --path-strategy (plus --yes to skip all questions):
+  --dir            local (~/.local/bin), system (/usr/local/bin, via sudo), devcli (~/.devcli/bin) or any folder
+  --path-strategy  rc (append to your shell rc), registry (Windows user PATH), symlink (link into a folder already on PATH) or none
+
+Nothing is changed on PATH when the folder is already on it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dirFlag, _ := cmd.Flags().GetString("dir")
+		strategyFlag, _ := cmd.Flags().GetString("path-strategy")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		if _, ok := pathStrategyLabels[strategyFlag]; strategyFlag != "" && !ok {
+			fmt.Printf("Error: unknown --path-strategy %q (use rc, registry, symlink or none)\n", strategyFlag)
+			os.Exit(1)
+		}
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Printf("Error finding home directory: %v\n", err)
+			os.Exit(1)
+		}
+		interactive := !assumeYes && stdinIsTerminal()
+		in := bufio.NewReader(os.Stdin)
+
+		dirs := installDirs(home)
+		var dir installDir
+		switch {
+		case dirFlag != "":
+			dir = resolveInstallDir(dirFlag, dirs)
+		case interactive:
+			dir = dirs[pickOption(in, "Install DevCLI to:", installDirLabels(dirs), 0)]
+		default:
+			dir = dirs[0]
+		}
+
+		strategies := pathStrategies()
+		onPath := isOnPath(dir.path)
+		var strategy string
+		switch {
+		case onPath:
+			strategy = "none"
+		case strategyFlag != "":
+			strategy = strategyFlag
+		case interactive:
+			labels := make([]string, len(strategies))
+			for i, s := range strategies {
+				labels[i] = pathStrategyLabels[s]
+			}
+			strategy = strategies[pickOption(in, dir.path+" is not on your PATH. Update PATH by:", labels, 0)]
+		default:
+			strategy = strategies[0]
+		}
+
+		var changes []string
+		dest, err := installBinary(dir)
+		if err != nil {
+			fmt.Printf("Error installing binary: %v\n", err)
+			os.Exit(1)
+		}
+		changes = append(changes, "Copied the devcli binary to "+dest)
+
+		if onPath {
+			changes = append(changes, "PATH unchanged: "+dir.path+" is already on it")
+		} else if change, err := applyPathStrategy(strategy, home, dir.path, dest); err != nil {
+			changes = append(changes, fmt.Sprintf("PATH not updated (%v); add %s to PATH manually", err, dir.path))
+		} else {
+			changes = append(changes, change)
+		}
+
+		fmt.Println("\nSummary of changes:")
+		for _, c := range changes {
+			fmt.Println("  - " + c)
+		}
+		if !onPath && (strategy == "rc" || strategy == "registry") {
+			fmt.Println("\nRestart your terminal to use 'devcli' from anywhere.")
+		}
+	},
+}
+
+func init() {
+	installCmd.Flags().String("dir", "", "Install folder: local, system, devcli or a path")
+	installCmd.Flags().String("path-strategy", "", "How to put the folder on PATH: rc, registry, symlink or none")
+	installCmd.Flags().BoolP("yes", "y", false, "Do not ask; use the flags or the defaults")
+}
+
+// installDir is a folder the binary can be installed to
+type installDir struct {
+	name string // local, system, devcli or custom
+	path string
+	sudo bool // Written through sudo when not writable
+}
+
+// installDirs lists the offered folders, default first
+func installDirs(home string) []installDir {
+	devcliDir := installDir{"devcli", filepath.Join(home, ".devcli", "bin"), false}
+	if runtime.GOOS == "windows" {
+		return []installDir{devcliDir}
+	}
+	return []installDir{
+		devcliDir,
+		{"local", filepath.Join(home, ".local", "bin"), false},
+		{"system", "/usr/local/bin", true},
+	}
+}
+
+func installDirLabels(dirs []installDir) []string {
+	labels := make([]string, len(dirs))
+	for i, d := range dirs {
+		labels[i] = d.path
+		if d.sudo {
+			labels[i] += " (uses sudo if needed)"
+		}
+		if isOnPath(d.path) {
+			labels[i] += " [on PATH]"
+		}
+	}
+	return labels
+}
+
+// resolveInstallDir maps a --dir value to one of dirs, or a custom folder
+func resolveInstallDir(value string, dirs []installDir) installDir {
+	for _, d := range dirs {
+		if d.name == value {
+			return d
+		}
+	}
+	path, err := filepath.Abs(value)
+	if err != nil {
+		path = value
+	}
+	return installDir{"custom", path, false}
+}
+
+var pathStrategyLabels = map[string]string{
+	"rc":       "Appending an export line to your shell rc file",
+	"registry": "Adding the folder to your user PATH in the registry",
+	"symlink":  "Linking devcli into a folder that is already on PATH",
+	"none":     "Leaving PATH alone (I manage it myself)",
+}
+
+// pathStrategies lists the strategies for this OS, default first
+func pathStrategies() []string {
+	if runtime.GOOS == "windows" {
+		return []string{"registry", "none"}
+	}
+	return []string{"rc", "symlink", "none"}
+}
+
+// pickOption prints a numbered menu and returns the chosen index; Enter or
+// unreadable input picks def
+func pickOption(in *bufio.Reader, question string, options []string, def int) int {
+	fmt.Println(question)
+	for i, o := range options {
+		marker := " "
+		if i == def {
+			marker = "*"
+		}
+		fmt.Printf(" %s %d) %s\n", marker, i+1, o)
+	}
+	for {
+		fmt.Printf("Choice [%d]: ", def+1)
+		line, err := in.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" || (err != nil && err != io.EOF) {
+			return def
+		}
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= len(options) {
+			return n - 1
+		}
+		if err == io.EOF {
+			return def
+		}
+		fmt.Printf("Enter a number from 1 to %d.\n", len(options))
+	}
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// isOnPath reports whether dir is one of the PATH entries
+func isOnPath(dir string) bool {
+	want := canonicalDir(dir)
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry != "" && canonicalDir(entry) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func canonicalDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	if runtime.GOOS == "windows" {
+		return strings.ToLower(dir)
+	}
+	return dir
+}
+
+// installBinary copies the running executable into dir. The copy is written
+// beside the target and renamed over it, so replacing a running devcli works.
+func installBinary(dir installDir) (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding executable: %w", err)
+	}
+	name := "devcli"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	dest := filepath.Join(dir.path, name)
+	if canonicalDir(exePath) == canonicalDir(dest) {
+		return dest, nil // Already running the installed copy
+	}
+
+	err = copyExecutable(exePath, dest)
+	if err != nil && dir.sudo && os.IsPermission(err) {
+		fmt.Printf("%s needs administrator rights; running sudo...\n", dir.path)
+		return dest, sudoInstall(exePath, dest)
+	}
+	return dest, err
+}
+
+func copyExecutable(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	tmp := dest + ".new"
+	if err := os.WriteFile(tmp, data, 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Chmod(dest, 0755)
+}
+
+func sudoInstall(src, dest string) error {
+	for _, args := range [][]string{
+		{"mkdir", "-p", filepath.Dir(dest)},
+		{"install", "-m", "0755", src, dest},
+	} {
+		c := exec.Command("sudo", args...)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("sudo %s: %w", strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
+
+// applyPathStrategy makes binDir (or the binary at dest) reachable from
+// PATH and describes what it changed
+func applyPathStrategy(strategy, home, binDir, dest string) (string, error) {
+	switch strategy {
+	case "rc":
+		if runtime.GOOS == "windows" {
+			return "", fmt.Errorf("shell rc files are not used on Windows; use --path-strategy registry")
+		}
+		return addToShellRC(home, binDir)
+	case "registry":
+		if runtime.GOOS != "windows" {
+			return "", fmt.Errorf("the registry strategy is only available on Windows")
+		}
+		return addToUserPath(binDir)
+	case "symlink":
+		return linkIntoPath(home, dest)
+	}
+	return "PATH unchanged (--path-strategy none): add " + binDir + " yourself", nil
+}
+
+// addToShellRC appends a PATH export for binDir to the rc file of $SHELL
+func addToShellRC(home, binDir string) (string, error) {
+	shell := os.Getenv("SHELL")
+	var rcFile, exportLine string
+	switch {
+	case strings.Contains(shell, "zsh"):
+		rcFile = filepath.Join(home, ".zshrc")
+	case strings.Contains(shell, "bash"):
+		rcFile = filepath.Join(home, ".bashrc")
+	case strings.Contains(shell, "fish"):
+		configDir, _ := os.UserConfigDir() // usually ~/.config
+		rcFile = filepath.Join(configDir, "fish", "config.fish")
+	default:
+		return "", fmt.Errorf("could not detect the shell configuration file (.bashrc/.zshrc/config.fish)")
+	}
+	if strings.Contains(shell, "fish") {
+		exportLine = fmt.Sprintf("\n# DevCLI\nset -gx PATH $PATH %s\n", binDir)
+	} else {
+		exportLine = fmt.Sprintf("\n# DevCLI\nexport PATH=\"$PATH:%s\"\n", binDir)
+	}
+
+	if content, err := os.ReadFile(rcFile); err == nil && strings.Contains(string(content), binDir) {
+		return fmt.Sprintf("%s already adds %s to PATH; left unchanged", rcFile, binDir), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(rcFile), 0755); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(rcFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(exportLine); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Appended a PATH export for %s to %s (run 'source %s' to apply it now)", binDir, rcFile, rcFile), nil
+}
+
+// addToUserPath adds binDir to the Windows user PATH in the registry
+func addToUserPath(binDir string) (string, error) {
+	script := fmt.Sprintf(`
+		$binPath = "%s"
+		$currentPath = [System.Environment]::GetEnvironmentVariable("Path", "User")
+		if ($currentPath -notlike "*$binPath*") {
+			[System.Environment]::SetEnvironmentVariable("Path", $currentPath + ";" + $binPath, "User")
+			Write-Output "ADDED"
+		} else {
+			Write-Output "EXISTS"
+		}
+	`, binDir)
+	out, err := exec.Command("powershell", "-Command", script).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("automated PATH update failed: %v", err)
+	}
+	if strings.TrimSpace(string(out)) == "ADDED" {
+		return "Added " + binDir + " to your user PATH (registry)", nil
+	}
+	return "Your user PATH already lists " + binDir + "; left unchanged", nil
+}
+
+// linkIntoPath links dest from the first writable user folder on PATH
+func linkIntoPath(home, dest string) (string, error) {
+	for _, dir := range []string{filepath.Join(home, ".local", "bin"), filepath.Join(home, "bin"), "/usr/local/bin"} {
+		if !isOnPath(dir) {
+			continue
+		}
+		link := filepath.Join(dir, filepath.Base(dest))
+		if canonicalDir(link) == canonicalDir(dest) {
+			return link + " already points to " + dest, nil
+		}
+		if info, err := os.Lstat(link); err == nil {
+			if info.Mode()&os.ModeSymlink == 0 {
+				return "", fmt.Errorf("%s exists and is not a link", link)
+			}
+			os.Remove(link)
+		}
+		if err := os.Symlink(dest, link); err != nil {
+			if os.IsPermission(err) {
+				continue
+			}
+			return "", err
+		}
+		return "Linked " + link + " -> " + dest, nil
+	}
+	return "", fmt.Errorf("no writable folder on PATH to link from (tried ~/.local/bin, ~/bin, /usr/local/bin)")
+}
@@ -0,0 +1,236 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// ModelLister is implemented by providers that can list the models
+// available to the configured key
+type ModelLister interface {
+	ListModels() ([]string, error)
+}
+
+// RateLimitError is returned when the models endpoint answers 429
+type RateLimitError struct {
+	Provider   string
+	RetryAfter time.Duration // 0 when the server did not say
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: rate limited, try again in %s", e.Provider, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: rate limited, try again later", e.Provider)
+}
+
+// Model lists are small and change rarely; listing is quick to give up so
+// Settings never waits long on an unreachable endpoint
+const (
+	listTimeout    = 10 * time.Second
+	listCacheTTL   = 5 * time.Minute
+	rateLimitPause = time.Minute // Used when a 429 has no Retry-After
+)
+
+type listResult struct {
+	models []string
+	err    error
+	until  time.Time
+}
+
+var (
+	listMu    sync.Mutex
+	listCache = map[string]listResult{}
+)
+
+// ListModels returns the models the configured backend offers, sorted. A
+// list is reused for a few minutes, and after a 429 the endpoint is not
+// asked again until the server's Retry-After has passed.
+func ListModels(cfg *config.Config) ([]string, error) {
+	p, err := GetProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := p.(ModelLister)
+	if !ok {
+		return nil, fmt.Errorf("%s does not offer a model list", p.Name())
+	}
+
+	key := strings.Join([]string{strings.ToLower(cfg.AIBackend), cfg.AIBaseURL, cfg.AIAPIKey, cfg.GeminiAPIKey}, "\x00")
+	listMu.Lock()
+	cached, hit := listCache[key]
+	listMu.Unlock()
+	if hit && time.Now().Before(cached.until) {
+		return cached.models, cached.err
+	}
+
+	models, err := lister.ListModels()
+	sort.Strings(models)
+	result := listResult{models: models, err: err}
+	var limited *RateLimitError
+	switch {
+	case err == nil:
+		result.until = time.Now().Add(listCacheTTL)
+	case errors.As(err, &limited):
+		pause := limited.RetryAfter
+		if pause <= 0 {
+			pause = rateLimitPause
+		}
+		result.until = time.Now().Add(pause)
+	}
+	// Other errors (unreachable, bad key) are not cached so a fix is seen at once
+	if !result.until.IsZero() {
+		listMu.Lock()
+		listCache[key] = result
+		listMu.Unlock()
+	}
+	return models, err
+}
+
+// HasModel reports whether name is in models. A name without a tag matches
+// its ":latest" entry (Ollama) and a "models/" prefix is ignored (Gemini).
+func HasModel(models []string, name string) bool {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "models/")
+	for _, m := range models {
+		m = strings.TrimPrefix(m, "models/")
+		if strings.EqualFold(m, name) || strings.EqualFold(m, name+":latest") {
+			return true
+		}
+	}
+	return false
+}
+
+// getModels sends a GET to url and decodes the JSON answer into out. The
+// errors name the provider, as the Send errors do.
+func getModels(provider, url string, header map[string]string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: listTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL is left out: Gemini carries the key in it
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: models endpoint unreachable: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return &RateLimitError{Provider: provider, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s: invalid API key or access denied", provider)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: models endpoint error (%d): %s", provider, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: failed to decode model list: %w", provider, err)
+	}
+	return nil
+}
+
+// retryAfter reads a Retry-After header given in seconds or as a date
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// OpenAI-compatible APIs (and Anthropic) answer {"data": [{"id": ...}]}
+type modelData struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+func (d modelData) ids() []string {
+	var ids []string
+	for _, m := range d.Data {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func (p *OpenAIProvider) ListModels() ([]string, error) {
+	header := map[string]string{}
+	if !p.IsLMStudio {
+		header["Authorization"] = "Bearer " + p.APIKey
+	}
+	var out modelData
+	if err := getModels(p.Name(), p.BaseURL+"/models", header, &out); err != nil {
+		return nil, err
+	}
+	return out.ids(), nil
+}
+
+func (p *AnthropicProvider) ListModels() ([]string, error) {
+	header := map[string]string{
+		"x-api-key":         p.APIKey,
+		"anthropic-version": "2023-06-01",
+	}
+	var out modelData
+	if err := getModels("Claude", p.BaseURL+"/models?limit=1000", header, &out); err != nil {
+		return nil, err
+	}
+	return out.ids(), nil
+}
+
+func (p *OllamaProvider) ListModels() ([]string, error) {
+	var out struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := getModels("Ollama", p.BaseURL+"/api/tags", nil, &out); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, m := range out.Models {
+		names = append(names, m.Name)
+	}
+	return names, nil
+}
+
+func (p *GeminiProvider) ListModels() ([]string, error) {
+	var out struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := getModels("Gemini", p.BaseURL+"?pageSize=1000&key="+p.APIKey, nil, &out); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, m := range out.Models {
+		names = append(names, strings.TrimPrefix(m.Name, "models/"))
+	}
+	return names, nil
+}
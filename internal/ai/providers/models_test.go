@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+func TestListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Write([]byte(`{"models":[{"name":"mistral:latest"},{"name":"llama3:8b"}]}`))
+		case "/v1/models":
+			if r.Header.Get("Authorization") != "Bearer sk-test" {
+				t.Errorf("Expected bearer key, got %q", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"data":[{"id":"gpt-4o"},{"id":"gpt-3.5-turbo"}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	models, err := ListModels(&config.Config{AIBackend: "ollama", AIBaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+	if len(models) != 2 || models[0] != "llama3:8b" {
+		t.Errorf("Expected sorted Ollama models, got %v", models)
+	}
+	if !HasModel(models, "mistral") || HasModel(models, "llama3") {
+		t.Errorf("HasModel matched wrongly against %v", models)
+	}
+
+	models, err = ListModels(&config.Config{AIBackend: "openai", AIBaseURL: server.URL + "/v1", AIAPIKey: "sk-test"})
+	if err != nil || !HasModel(models, "gpt-4o") {
+		t.Errorf("Expected gpt-4o, got %v, %v", models, err)
+	}
+
+	if _, err := ListModels(&config.Config{AIBackend: "huggingface"}); err == nil {
+		t.Error("Expected an error for a backend without a model list")
+	}
+}
+
+func TestListModels_RateLimited(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AIBackend: "groq", AIBaseURL: server.URL, AIAPIKey: "gsk-test"}
+	for i := 0; i < 2; i++ {
+		_, err := ListModels(cfg)
+		var limited *RateLimitError
+		if !errors.As(err, &limited) || limited.RetryAfter != 30*time.Second {
+			t.Fatalf("Expected a 30s rate limit, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the endpoint to be asked once while rate limited, got %d calls", calls)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // Manager handles all boilerplate operations
@@ -49,6 +51,50 @@ func (m *Manager) GenerateSnippet(name, language string, destDir string) (string
 	return fullPath, os.WriteFile(fullPath, []byte(content), 0644)
 }
 
+// SnippetKeys returns the keys of Snippets, sorted
+func SnippetKeys() []string {
+	keys := make([]string, 0, len(Snippets))
+	for key := range Snippets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FindSnippet looks up a built-in snippet by key or display name, ignoring
+// case, and returns its key
+func FindSnippet(name string) (string, Snippet, bool) {
+	name = strings.TrimSpace(name)
+	for _, key := range SnippetKeys() {
+		s := Snippets[key]
+		if strings.EqualFold(key, name) || strings.EqualFold(s.Name, name) {
+			return key, s, true
+		}
+	}
+	return "", Snippet{}, false
+}
+
+// Languages returns the languages the snippet is written in, sorted
+func (s Snippet) Languages() []string {
+	langs := make([]string, 0, len(s.Content))
+	for lang := range s.Content {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Code returns the snippet in language, matched ignoring case, along with
+// the language's name as listed in Content
+func (s Snippet) Code(language string) (string, string, bool) {
+	for _, lang := range s.Languages() {
+		if strings.EqualFold(lang, strings.TrimSpace(language)) {
+			return s.Content[lang], lang, true
+		}
+	}
+	return "", "", false
+}
+
 func getExt(lang string) string {
 	switch lang {
 	case "Go":
@@ -13,9 +13,10 @@ type TemplateManager struct {
 	TemplatesDir string
 }
 
-func NewTemplateManager(baseDir string) *TemplateManager {
+// NewTemplateManager stores templates under <configDir>/custom_templates
+func NewTemplateManager(configDir string) *TemplateManager {
 	return &TemplateManager{
-		TemplatesDir: filepath.Join(baseDir, ".devcli", "custom_templates"),
+		TemplatesDir: filepath.Join(configDir, "custom_templates"),
 	}
 }
 
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -19,27 +22,84 @@ type Config struct {
 	HFAccessToken string            `mapstructure:"hf_access_token"`
 	GeminiAPIKey  string            `mapstructure:"gemini_api_key"`
 	Compilers     map[string]string `mapstructure:"compilers"` // Persisted detected paths
+
+	// Named AI backends (see Profile); AIProfile is applied on load
+	AIProfile string             `mapstructure:"ai_profile"`
+	Profiles  map[string]Profile `mapstructure:"profiles"`
+
+	base          Profile // ai_* keys, restored by UseProfile("")
+	activeProfile string
 }
 
-func LoadConfig() (*Config, error) {
-	home, err := os.UserHomeDir()
+// EnvPath names the environment variable that selects the config file when
+// no --config flag is given
+const EnvPath = "DEVCLI_CONFIG"
+
+// pathOverride is set from --config and wins over DEVCLI_CONFIG
+var pathOverride string
+
+// recoveryWarning holds a message about a config file that had to be reset.
+// It is consumed once by TakeWarning so the UI only shows it a single time.
+var recoveryWarning string
+
+// SetPath makes Path return path (for --config). Settings are read from and
+// written to it on the next LoadConfig; "" restores the default.
+func SetPath(path string) error {
+	if path == "" {
+		pathOverride = ""
+		return nil
+	}
+	abs, err := filepath.Abs(path)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	pathOverride = abs
+	return nil
+}
 
-	viper.AddConfigPath(home)
-	viper.SetConfigName(".devcli")
-	viper.SetConfigType("yaml")
+// Path returns the config file: the --config path, else $DEVCLI_CONFIG,
+// else config.yaml inside Dir.
+func Path() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	if env := os.Getenv(EnvPath); env != "" {
+		return filepath.Abs(env)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
 
+func setDefaults() {
 	viper.SetDefault("ai_backend", "")
 	viper.SetDefault("editor_theme", "default")
 	viper.SetDefault("user_name", "Developer")
+}
+
+func LoadConfig() (*Config, error) {
+	configPath, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType("yaml")
+	setDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			// Config file not found; ignore error if desired
-			// or create a default one
-		} else {
+		var parseErr viper.ConfigParseError
+		switch {
+		case errors.As(err, &parseErr):
+			// Malformed file (bad YAML, truncated write): keep a copy and start fresh
+			if err := recoverCorrupt(configPath, err); err != nil {
+				return nil, err
+			}
+		case os.IsNotExist(err), errors.As(err, new(viper.ConfigFileNotFoundError)):
+			// Config file not found; defaults apply
+		default:
 			return nil, err
 		}
 	}
@@ -48,23 +108,73 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, err
 	}
+	config.applyDefaultProfile()
 
 	return &config, nil
 }
 
+// recoverCorrupt moves a broken config aside to <path>.bak, resets the
+// in-memory settings and writes a default config in its place.
+func recoverCorrupt(configPath string, cause error) error {
+	backupPath := configPath + ".bak"
+	if err := os.Rename(configPath, backupPath); err != nil {
+		return fmt.Errorf("config is corrupt (%v) and could not be backed up: %w", cause, err)
+	}
+
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType("yaml")
+	setDefaults()
+
+	if err := Write(); err != nil {
+		return fmt.Errorf("failed to write default config: %w", err)
+	}
+
+	recoveryWarning = fmt.Sprintf("Config file was corrupt and has been reset to defaults. The old file was saved to %s", backupPath)
+	return nil
+}
+
+// TakeWarning returns the pending config recovery warning, if any, and clears it.
+func TakeWarning() string {
+	w := recoveryWarning
+	recoveryWarning = ""
+	return w
+}
+
 func SaveConfig(key string, value interface{}) error {
 	viper.Set(key, value)
 	return Write()
 }
 
+// Write saves the current settings atomically: the config is written to a
+// temp file in the same directory and renamed over the original, so an
+// interrupted save never leaves a half-written file behind.
 func Write() error {
-	home, err := os.UserHomeDir()
+	configPath, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(configPath), ".devcli-*.yaml")
 	if err != nil {
 		return err
 	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+
+	if err := viper.WriteConfigAs(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
 
-	configPath := filepath.Join(home, ".devcli.yaml")
-	return viper.WriteConfigAs(configPath)
+	if err := os.Rename(tmpPath, configPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
 }
 
 func Set(key string, value interface{}) {
@@ -74,3 +184,20 @@ func Set(key string, value interface{}) {
 func GetString(key string) string {
 	return viper.GetString(key)
 }
+
+func GetStringSlice(key string) []string {
+	return viper.GetStringSlice(key)
+}
+
+func GetStringMapStringSlice(key string) map[string][]string {
+	return viper.GetStringMapStringSlice(key)
+}
+
+// ShellKey names the shell used for one-off commands and terminals (pwsh,
+// bash, zsh, fish, ...); empty means the platform default
+const ShellKey = "shell"
+
+// Shell returns the configured shell, or "" for the platform default
+func Shell() string {
+	return strings.TrimSpace(viper.GetString(ShellKey))
+}
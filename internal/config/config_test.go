@@ -0,0 +1,311 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// setupHome points the config at a fresh temp home directory
+func setupHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("AppData", filepath.Join(home, "AppData")) // Windows
+	t.Setenv(EnvPath, "")
+	pathOverride = ""
+	t.Cleanup(func() { pathOverride = "" })
+	viper.Reset()
+	recoveryWarning = ""
+	migrateOnce = sync.Once{}
+	migrateErr = nil
+	return home
+}
+
+// configPath resolves Path or fails the test
+func configPath(t *testing.T) string {
+	t.Helper()
+	p, err := Path()
+	if err != nil {
+		t.Fatalf("Path failed: %v", err)
+	}
+	return p
+}
+
+func TestLoadConfig_RecoversCorruptFile(t *testing.T) {
+	setupHome(t)
+	configPath := configPath(t)
+	corrupt := "ai_backend: [ollama\nai_model: {{{\n"
+	if err := os.WriteFile(configPath, []byte(corrupt), 0644); err != nil {
+		t.Fatalf("Failed to write corrupt config: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed on corrupt file: %v", err)
+	}
+	if cfg.UserName != "Developer" {
+		t.Errorf("Expected default user name 'Developer', got '%s'", cfg.UserName)
+	}
+
+	backup, err := os.ReadFile(configPath + ".bak")
+	if err != nil {
+		t.Fatalf("Expected backup of corrupt config: %v", err)
+	}
+	if string(backup) != corrupt {
+		t.Errorf("Backup content mismatch, got %q", string(backup))
+	}
+
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("Expected fresh default config to be written: %v", err)
+	}
+
+	warning := TakeWarning()
+	if !strings.Contains(warning, ".bak") {
+		t.Errorf("Expected warning mentioning backup, got %q", warning)
+	}
+	if TakeWarning() != "" {
+		t.Error("Warning should only be returned once")
+	}
+}
+
+func TestLoadConfig_MissingFile(t *testing.T) {
+	setupHome(t)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed without config file: %v", err)
+	}
+	if cfg.EditorTheme != "default" {
+		t.Errorf("Expected default editor theme, got '%s'", cfg.EditorTheme)
+	}
+	if TakeWarning() != "" {
+		t.Error("No warning expected for a missing config file")
+	}
+}
+
+func TestWrite_Atomic(t *testing.T) {
+	setupHome(t)
+	dir := filepath.Dir(configPath(t))
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := SaveConfig("ai_backend", "ollama"); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read config dir: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != "config.yaml" {
+			t.Errorf("Unexpected leftover file after write: %s", e.Name())
+		}
+	}
+
+	viper.Reset()
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if cfg.AIBackend != "ollama" {
+		t.Errorf("Expected ai_backend 'ollama', got '%s'", cfg.AIBackend)
+	}
+}
+
+func TestSetPath_UsedForReadAndWrite(t *testing.T) {
+	home := setupHome(t)
+	defaultPath := configPath(t)
+	custom := filepath.Join(home, "profiles", "work.yaml")
+	if err := SetPath(custom); err != nil {
+		t.Fatal(err)
+	}
+	if got := configPath(t); got != custom {
+		t.Fatalf("Path() = %q, want %q", got, custom)
+	}
+
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := SaveConfig("user_name", "Work Profile"); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	data, err := os.ReadFile(custom)
+	if err != nil {
+		t.Fatalf("custom config not written: %v", err)
+	}
+	if !strings.Contains(string(data), "Work Profile") {
+		t.Errorf("custom config missing saved value:\n%s", data)
+	}
+	if _, err := os.Stat(defaultPath); !os.IsNotExist(err) {
+		t.Errorf("default config %s was touched (err = %v)", defaultPath, err)
+	}
+
+	viper.Reset()
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if cfg.UserName != "Work Profile" {
+		t.Errorf("UserName = %q after reload, want %q", cfg.UserName, "Work Profile")
+	}
+}
+
+func TestPath_EnvOverride(t *testing.T) {
+	home := setupHome(t)
+	fromEnv := filepath.Join(home, "env.yaml")
+	t.Setenv(EnvPath, fromEnv)
+	if got := configPath(t); got != fromEnv {
+		t.Errorf("Path() = %q, want %q from %s", got, fromEnv, EnvPath)
+	}
+
+	// The flag wins over the environment
+	flag := filepath.Join(home, "flag.yaml")
+	if err := SetPath(flag); err != nil {
+		t.Fatal(err)
+	}
+	if got := configPath(t); got != flag {
+		t.Errorf("Path() = %q, want %q from SetPath", got, flag)
+	}
+}
+
+func TestLoadConfig_AppliesDefaultProfile(t *testing.T) {
+	setupHome(t)
+	yaml := `ai_backend: openai
+ai_model: gpt-4o
+ai_profile: fast
+profiles:
+  fast:
+    backend: ollama
+    model: llama3
+  smart:
+    backend: claude
+    api_key: sk-test
+`
+	if err := os.WriteFile(configPath(t), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.ActiveProfile() != "fast" || cfg.AIBackend != "ollama" || cfg.AIModel != "llama3" {
+		t.Errorf("default profile not applied: active=%q backend=%q model=%q", cfg.ActiveProfile(), cfg.AIBackend, cfg.AIModel)
+	}
+	if next := cfg.NextProfile(); next != "smart" {
+		t.Errorf("NextProfile = %q, want smart", next)
+	}
+
+	if err := cfg.UseProfile("smart"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AIBackend != "claude" || cfg.AIAPIKey != "sk-test" || cfg.AIModel != "" {
+		t.Errorf("smart profile: backend=%q key=%q model=%q", cfg.AIBackend, cfg.AIAPIKey, cfg.AIModel)
+	}
+	if next := cfg.NextProfile(); next != "" {
+		t.Errorf("NextProfile after the last = %q, want the ai_* keys", next)
+	}
+
+	if err := cfg.UseProfile(""); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AIBackend != "openai" || cfg.AIModel != "gpt-4o" {
+		t.Errorf("ai_* keys not restored: backend=%q model=%q", cfg.AIBackend, cfg.AIModel)
+	}
+	if err := cfg.UseProfile("missing"); err == nil {
+		t.Error("UseProfile accepted an unknown profile")
+	}
+}
+
+func TestDir_MigratesLegacyFiles(t *testing.T) {
+	home := setupHome(t)
+	legacyDir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(filepath.Join(legacyDir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(home, ".devcli.yaml"), []byte("user_name: Ada\n"), 0644)
+	os.WriteFile(filepath.Join(legacyDir, "history.json"), []byte("[]"), 0644)
+
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir failed: %v", err)
+	}
+	if filepath.Base(dir) != "devcli" {
+		t.Errorf("Unexpected config dir %s", dir)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "history.json")); err != nil {
+		t.Errorf("history.json was not migrated: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".devcli.yaml")); !os.IsNotExist(err) {
+		t.Error("Legacy config file should have been moved")
+	}
+	if _, err := os.Stat(filepath.Join(legacyDir, "bin")); err != nil {
+		t.Error("bin/ must stay in the legacy folder")
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.UserName != "Ada" {
+		t.Errorf("Expected migrated user name 'Ada', got '%s'", cfg.UserName)
+	}
+}
+
+func TestReset_UISection(t *testing.T) {
+	setupHome(t)
+	yaml := "ai_backend: claude\nai_api_key: sk-test\neditor_theme: dracula\neditor:\n  output_ratio: 70\n  autosave_interval: \"\"\nui:\n  confirm_quit: \"false\"\nfilemanager:\n  grep_exclude: [dist]\n  search_history: [todo]\n"
+	if err := os.WriteFile(configPath(t), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if _, err := PlanReset("nope"); err == nil {
+		t.Error("Expected an error for an unknown section")
+	}
+	changes, err := Reset("ui")
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	want := []Change{
+		{"editor_theme", "dracula", "default"},
+		{"editor.output_ratio", "70", "50"},
+		{"ui.confirm_quit", "false", "true"},
+		{"filemanager.grep_exclude", "dist", ".git, node_modules, .venv, venv, __pycache__"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Got changes %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	viper.Reset()
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if cfg.EditorTheme != "default" || GetString("ui.confirm_quit") != "true" {
+		t.Errorf("Defaults not saved: theme %q, confirm_quit %q", cfg.EditorTheme, GetString("ui.confirm_quit"))
+	}
+	if cfg.AIBackend != "claude" || cfg.AIAPIKey != "sk-test" || len(GetStringSlice("filemanager.search_history")) != 1 {
+		t.Error("Reset touched AI keys or saved state")
+	}
+	if changes, _ := PlanReset("ui"); len(changes) != 0 {
+		t.Errorf("Expected nothing left to reset, got %+v", changes)
+	}
+}
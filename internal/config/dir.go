@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// legacyKeep lists entries of the old ~/.devcli folder that are not
+// migrated. bin/ holds the installed binary and is referenced from PATH.
+var legacyKeep = map[string]bool{
+	"bin": true,
+}
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+// Dir returns the per-platform directory for DevCLI config and state:
+// $XDG_CONFIG_HOME/devcli (default ~/.config/devcli) on Linux,
+// %AppData%\devcli on Windows and ~/Library/Application Support/devcli
+// on macOS. On first use, files from the legacy ~/.devcli folder and
+// ~/.devcli.yaml are moved into it.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	migrateOnce.Do(func() {
+		migrateErr = migrateLegacy(dir)
+	})
+	if migrateErr != nil {
+		return "", migrateErr
+	}
+	return dir, nil
+}
+
+// migrateLegacy moves ~/.devcli.yaml and the contents of ~/.devcli into dir.
+// Anything that already exists in dir is left untouched.
+func migrateLegacy(dir string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil // No home, nothing to migrate
+	}
+
+	legacyConfig := filepath.Join(home, ".devcli.yaml")
+	if err := moveIfAbsent(legacyConfig, filepath.Join(dir, "config.yaml")); err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", legacyConfig, err)
+	}
+
+	legacyDir := filepath.Join(home, ".devcli")
+	if legacyDir == dir {
+		return nil
+	}
+	entries, err := os.ReadDir(legacyDir)
+	if err != nil {
+		return nil // No legacy folder
+	}
+	for _, e := range entries {
+		if legacyKeep[e.Name()] {
+			continue
+		}
+		src := filepath.Join(legacyDir, e.Name())
+		if err := moveIfAbsent(src, filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", src, err)
+		}
+	}
+	// Only succeeds if nothing (e.g. bin/) was left behind
+	os.Remove(legacyDir)
+	return nil
+}
+
+// moveIfAbsent moves src to dst unless src is missing or dst already exists.
+// Falls back to copy+delete when a rename is not possible (different volumes).
+func moveIfAbsent(src, dst string) error {
+	if _, err := os.Lstat(src); err != nil {
+		return nil
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		return nil
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
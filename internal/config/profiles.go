@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profile is a named AI backend under "profiles" in the config, e.g.
+//
+//	profiles:
+//	  fast:  {backend: ollama, model: llama3}
+//	  smart: {backend: claude, model: claude-3-5-sonnet-latest, api_key: sk-...}
+//
+// Empty fields take the backend's defaults, as the ai_* keys do.
+type Profile struct {
+	Backend string `mapstructure:"backend"`
+	Model   string `mapstructure:"model"`
+	APIKey  string `mapstructure:"api_key"`
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// ProfileNames returns the configured profile names, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveProfile is the profile the AI fields come from; empty means the
+// ai_* keys
+func (c *Config) ActiveProfile() string {
+	return c.activeProfile
+}
+
+// UseProfile points the AI fields at the named profile. "" restores the
+// ai_* keys. Providers must be re-created to pick up the change.
+func (c *Config) UseProfile(name string) error {
+	if name == "" {
+		c.setAI(c.base)
+		c.activeProfile = ""
+		return nil
+	}
+	p, ok := c.Profiles[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown AI profile %q", name)
+	}
+	c.setAI(p)
+	c.activeProfile = strings.ToLower(name)
+	return nil
+}
+
+// NextProfile returns the profile after the active one, cycling through
+// the ai_* keys ("") after the last
+func (c *Config) NextProfile() string {
+	cycle := append([]string{""}, c.ProfileNames()...)
+	for i, name := range cycle {
+		if name == c.activeProfile {
+			return cycle[(i+1)%len(cycle)]
+		}
+	}
+	return ""
+}
+
+func (c *Config) setAI(p Profile) {
+	c.AIBackend, c.AIModel, c.AIAPIKey, c.AIBaseURL = p.Backend, p.Model, p.APIKey, p.BaseURL
+}
+
+// applyDefaultProfile remembers the ai_* keys and switches to ai_profile,
+// if set. An unknown name keeps the ai_* keys.
+func (c *Config) applyDefaultProfile() {
+	c.base = Profile{Backend: c.AIBackend, Model: c.AIModel, APIKey: c.AIAPIKey, BaseURL: c.AIBaseURL}
+	if c.AIProfile != "" {
+		c.UseProfile(c.AIProfile)
+	}
+}
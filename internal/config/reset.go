@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Setting is a config key with its documented default
+type Setting struct {
+	Key     string
+	Default interface{}
+}
+
+// Sections group the settings "devcli config reset --section" and the
+// Settings screen can restore. AI keys, profiles, compilers and saved state
+// (search history, favorites, last paths) are never part of one.
+var Sections = map[string][]Setting{
+	// Editor and UI preferences. The defaults match the fallbacks in
+	// internal/tui (editor_layout.go, editor_limits.go, quit_guard.go, ...).
+	"ui": {
+		{"editor_theme", "default"},
+		{"editor.output_ratio", 50},
+		{"editor.output_maximized", false},
+		{"editor.autosave_interval", ""},
+		{"editor.run_timeout", ""},
+		{"editor.indent_style", ""},
+		{"editor.indent_size", ""},
+		{"editor.max_open_bytes", 4 << 20},
+		{"editor.open_extensions", []string{}},
+		{"editor.external_extensions", []string{}},
+		{"editor.trim_trailing_whitespace", false},
+		{"editor.final_newline", false},
+		{"ui.confirm_quit", true},
+		{"filemanager.max_index", 1_000_000},
+		{"filemanager.index_max_age", "24h"},
+		{"filemanager.grep_exclude", []string{".git", "node_modules", ".venv", "venv", "__pycache__"}},
+		{"filemanager.scan_exclude", []string{".git", "node_modules", ".venv", "venv", "__pycache__", "target", "dist", "build"}},
+		{"filemanager.scan_gitignore", true},
+		{"filemanager.show_hidden", true},
+		{"filemanager.persist_search_history", false},
+		{"devserver.confirm_actions", false},
+		{"devserver.load_env", true},
+	},
+}
+
+// SectionNames lists the sections, sorted
+func SectionNames() []string {
+	names := make([]string, 0, len(Sections))
+	for name := range Sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Change is a setting that differs from its default
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// PlanReset lists the settings of section that a reset would change. Unset
+// and empty keys already fall back to their default and are skipped.
+func PlanReset(section string) ([]Change, error) {
+	settings, ok := Sections[section]
+	if !ok {
+		return nil, fmt.Errorf("unknown section %q (valid: %s)", section, strings.Join(SectionNames(), ", "))
+	}
+	var changes []Change
+	for _, s := range settings {
+		old, def := formatValue(viper.Get(s.Key)), formatValue(s.Default)
+		if old != "" && old != def {
+			changes = append(changes, Change{Key: s.Key, Old: old, New: def})
+		}
+	}
+	return changes, nil
+}
+
+// Reset restores the defaults of section and saves the config, returning
+// what changed
+func Reset(section string) ([]Change, error) {
+	changes, err := PlanReset(section)
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+	for _, s := range Sections[section] {
+		viper.Set(s.Key, s.Default)
+	}
+	return changes, Write()
+}
+
+// formatValue renders a config value for comparison and display
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = fmt.Sprint(p)
+		}
+		return strings.Join(parts, ", ")
+	}
+	if v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
@@ -0,0 +1,111 @@
+package devserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseCommandLine splits a command typed by the user into the program and
+// its arguments. Whitespace separates arguments; single or double quotes
+// keep spaces in one, and \" is a quote inside double quotes. Other
+// backslashes are literal so Windows paths survive.
+func ParseCommandLine(line string) (cmd string, args []string, err error) {
+	words, err := splitWords(line)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(words) == 0 {
+		return "", nil, errors.New("empty command")
+	}
+	return words[0], words[1:], nil
+}
+
+// ParseEnvPairs reads KEY=VALUE pairs typed on one line, split and quoted
+// like ParseCommandLine ("PORT=4000 API_URL='http://localhost:9000'")
+func ParseEnvPairs(line string) ([]string, error) {
+	words, err := splitWords(line)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range words {
+		key, _, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%q is not KEY=VALUE", kv)
+		}
+	}
+	return words, nil
+}
+
+// FormatEnvPairs is the inverse of ParseEnvPairs
+func FormatEnvPairs(env []string) string {
+	quoted := make([]string, len(env))
+	for i, kv := range env {
+		quoted[i] = quoteArg(kv)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func splitWords(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) && runes[i+1] == '"' {
+				word.WriteRune('"')
+				i++
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// SetCommandLine replaces the server's command with line, as parsed by
+// ParseCommandLine
+func (s *ServerConfig) SetCommandLine(line string) error {
+	cmd, args, err := ParseCommandLine(line)
+	if err != nil {
+		return err
+	}
+	s.Cmd, s.Args = cmd, args
+	return nil
+}
+
+// quoteArg quotes an argument that would not survive ParseCommandLine as is
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'") {
+		return arg
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'"
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
@@ -0,0 +1,72 @@
+package devserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantCmd  string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{"pnpm dev", "pnpm", []string{"dev"}, false},
+		{"hugo", "hugo", []string{}, false},
+		{"  npm   run dev -- --port 4000 ", "npm", []string{"run", "dev", "--", "--port", "4000"}, false},
+		{`node "my server.js" 'a b'`, "node", []string{"my server.js", "a b"}, false},
+		{`echo "say \"hi\""`, "echo", []string{`say "hi"`}, false},
+		{`C:\tools\hugo.exe server`, `C:\tools\hugo.exe`, []string{"server"}, false},
+		{`run ""`, "run", []string{""}, false},
+		{`npm run "dev`, "", nil, true},
+		{"   ", "", nil, true},
+	}
+	for _, tt := range tests {
+		cmd, args, err := ParseCommandLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCommandLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if cmd != tt.wantCmd || !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Errorf("ParseCommandLine(%q) = %q %q, want %q %q", tt.line, cmd, args, tt.wantCmd, tt.wantArgs)
+		}
+	}
+}
+
+func TestCommandLineRoundTrip(t *testing.T) {
+	srv := ServerConfig{Cmd: "node", Args: []string{"my server.js", "--name", `it's "quoted"`, ""}}
+	var again ServerConfig
+	if err := again.SetCommandLine(srv.CommandLine()); err != nil {
+		t.Fatal(err)
+	}
+	if again.Cmd != srv.Cmd || !reflect.DeepEqual(again.Args, srv.Args) {
+		t.Errorf("round trip of %q gave %q %q", srv.CommandLine(), again.Cmd, again.Args)
+	}
+}
+
+func TestParseEnvPairs(t *testing.T) {
+	got, err := ParseEnvPairs(`PORT=4000  API_URL='http://localhost:9000' GREETING="hi there" EMPTY=`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"PORT=4000", "API_URL=http://localhost:9000", "GREETING=hi there", "EMPTY="}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseEnvPairs = %q, want %q", got, want)
+	}
+	if again, _ := ParseEnvPairs(FormatEnvPairs(got)); !reflect.DeepEqual(again, want) {
+		t.Errorf("round trip of %q gave %q", FormatEnvPairs(got), again)
+	}
+	if srv := (ServerConfig{Env: got}); srv.Port() != "4000" {
+		t.Errorf("Port() = %q, want 4000", srv.Port())
+	}
+
+	for _, bad := range []string{"PORT", "=1", `A="open`} {
+		if _, err := ParseEnvPairs(bad); err == nil {
+			t.Errorf("ParseEnvPairs(%q) succeeded, want an error", bad)
+		}
+	}
+}
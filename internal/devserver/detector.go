@@ -2,11 +2,19 @@ package devserver
 
 import (
 	"bufio"
+	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/phravins/devcli/internal/taskrunner"
 )
 
+// makeDevTargets are the Makefile targets that conventionally start a
+// project, in order of preference
+var makeDevTargets = []string{"dev", "serve", "start", "run"}
+
 type ProjectType string
 
 const (
@@ -15,6 +23,8 @@ const (
 	TypeNextJS    ProjectType = "Next.js"
 	TypeNestJS    ProjectType = "Nest.js"
 	TypeAngular   ProjectType = "Angular"
+	TypeNuxt      ProjectType = "Nuxt"
+	TypeSvelteKit ProjectType = "SvelteKit"
 	TypeVue       ProjectType = "Vue.js"
 	TypePython    ProjectType = "Python"
 	TypeGo        ProjectType = "Go"
@@ -25,23 +35,134 @@ const (
 	TypeVite      ProjectType = "Vite"
 	TypeWebpack   ProjectType = "Webpack"
 	TypeSpring    ProjectType = "Spring Boot"
+	TypeHugo      ProjectType = "Hugo"
+	TypeJekyll    ProjectType = "Jekyll"
+	TypeEleventy  ProjectType = "Eleventy"
+	TypeMake      ProjectType = "Makefile"
 	TypeFullstack ProjectType = "Fullstack"
 	TypeUnknown   ProjectType = "Unknown"
 )
 
+// TypeInfo describes a supported project type: its language and the
+// files Detect looks for to recognise it
+type TypeInfo struct {
+	Type     ProjectType `json:"type"`
+	Language string      `json:"language"`
+	Marker   string      `json:"marker"`
+}
+
+// typeInfos lists every detectable type in detection priority order
+var typeInfos = []TypeInfo{
+	{TypeDjango, "Python", "manage.py"},
+	{TypeFastAPI, "Python", "main.py + fastapi import"},
+	{TypeSpring, "Java", "pom.xml"},
+	{TypeNextJS, "JavaScript", "next.config.js or a \"next\" dependency"},
+	{TypeNestJS, "TypeScript", "nest-cli.json"},
+	{TypeAngular, "TypeScript", "angular.json"},
+	{TypeNuxt, "JavaScript", "nuxt.config.js or a \"nuxt\" dependency"},
+	{TypeSvelteKit, "JavaScript", "svelte.config.js or a \"@sveltejs/kit\" dependency"},
+	{TypeHugo, "Go templates", "hugo.toml, or config.toml + content/ or layouts/"},
+	{TypeJekyll, "Ruby", "_config.yml + Gemfile"},
+	{TypeEleventy, "JavaScript", ".eleventy.js or eleventy.config.js"},
+	{TypeVue, "JavaScript", "package.json (Vue), or vue.config.js for Vue CLI"},
+	{TypeVite, "JavaScript", "vite.config.js"},
+	{TypeWebpack, "JavaScript", "webpack.config.js"},
+	{TypeReact, "JavaScript", "package.json (React)"},
+	{TypeExpress, "JavaScript", "package.json (Express)"},
+	{TypeNode, "JavaScript", "package.json"},
+	{TypeFlask, "Python", "app.py + flask import"},
+	{TypePython, "Python", "Python project files"},
+	{TypeGo, "Go", "go.mod"},
+	{TypeMake, "Make", "Makefile"},
+	{TypeFullstack, "Mixed", "backend/ + frontend/ folders"},
+}
+
+// Types returns metadata for every project type Detect can report
+func Types() []TypeInfo {
+	return append([]TypeInfo(nil), typeInfos...)
+}
+
+// Info returns the metadata for t; unknown types get an empty marker
+func (t ProjectType) Info() TypeInfo {
+	for _, info := range typeInfos {
+		if info.Type == t {
+			return info
+		}
+	}
+	return TypeInfo{Type: t}
+}
+
 type ServerConfig struct {
-	Name string // "Backend", "Frontend", or "Server"
-	Type ProjectType
-	Cmd  string
-	Args []string
-	Dir  string // Working directory for this server
+	Name string      `json:"name"` // "Backend", "Frontend", or "Server"
+	Type ProjectType `json:"type"`
+	Cmd  string      `json:"cmd"`
+	Args []string    `json:"args"`
+	Dir  string      `json:"dir"` // Working directory for this server
+
+	// Env holds KEY=VALUE pairs set by the user (e.g. PORT=4000). They
+	// win over both the shell environment and the server's .env.
+	Env []string `json:"env,omitempty"`
+}
+
+// Port is the PORT the server is given in Env, or "" when it picks its own
+func (s ServerConfig) Port() string {
+	port := ""
+	for _, kv := range s.Env {
+		if v, ok := strings.CutPrefix(kv, "PORT="); ok {
+			port = v // The last assignment wins
+		}
+	}
+	return port
+}
+
+// CommandLine is the server's command as it would be typed in a shell.
+// Arguments with spaces or quotes are quoted, so ParseCommandLine reads
+// it back unchanged.
+func (s ServerConfig) CommandLine() string {
+	words := []string{s.Cmd}
+	for _, arg := range s.Args {
+		words = append(words, quoteArg(arg))
+	}
+	return strings.TrimSpace(strings.Join(words, " "))
 }
 
 type ProjectInfo struct {
-	Type    ProjectType
-	Servers []ServerConfig
+	Type    ProjectType    `json:"type"`
+	Servers []ServerConfig `json:"servers"`
+
+	// MakeTargets lists the Makefile's targets for a TypeMake project.
+	// Servers is empty when none of them is a conventional dev target.
+	MakeTargets []string `json:"make_targets,omitempty"`
+}
+
+// Commands returns the command line of each server, in start order
+func (p ProjectInfo) Commands() []string {
+	cmds := make([]string, 0, len(p.Servers))
+	for _, srv := range p.Servers {
+		cmds = append(cmds, srv.CommandLine())
+	}
+	return cmds
+}
+
+// MissingTools returns the server commands that are not on PATH, once each
+func (p ProjectInfo) MissingTools() []string {
+	var missing []string
+	seen := map[string]bool{}
+	for _, srv := range p.Servers {
+		if seen[srv.Cmd] {
+			continue
+		}
+		seen[srv.Cmd] = true
+		if _, err := exec.LookPath(srv.Cmd); err != nil {
+			missing = append(missing, srv.Cmd)
+		}
+	}
+	return missing
 }
 
+// Detect inspects path (the working directory when empty) and reports the
+// project type and the servers to start. It only reads marker files, so the
+// same tree always gives the same result.
 func Detect(path string) ProjectInfo {
 	if path == "" {
 		path, _ = os.Getwd()
@@ -91,8 +212,8 @@ func Detect(path string) ProjectInfo {
 		detectedType = TypeSpring
 	}
 
-	// Check for Next.js (next.config.js or pages/ directory)
-	if exists(filepath.Join(path, "next.config.js")) || exists(filepath.Join(path, "next.config.mjs")) || exists(filepath.Join(path, "pages")) || exists(filepath.Join(path, "app")) {
+	// Check for Next.js (next.config.js, or a next dependency)
+	if isNext(path) {
 		servers = append(servers, ServerConfig{
 			Name: "Next.js Dev Server",
 			Type: TypeNextJS,
@@ -127,13 +248,78 @@ func Detect(path string) ProjectInfo {
 		detectedType = TypeAngular
 	}
 
-	// Check for Vue.js (vue.config.js or vite.config with vue)
+	// Check for Nuxt (nuxt.config.js, or a nuxt dependency); Nuxt apps
+	// depend on vue too, so this comes before the Vue check
+	if isNuxt(path) {
+		servers = append(servers, ServerConfig{
+			Name: "Nuxt Dev Server",
+			Type: TypeNuxt,
+			Cmd:  "npm",
+			Args: []string{"run", "dev"},
+			Dir:  path,
+		})
+		detectedType = TypeNuxt
+	}
+
+	// Check for SvelteKit (svelte.config.js, or a @sveltejs/kit dependency)
+	if isSvelteKit(path) {
+		servers = append(servers, ServerConfig{
+			Name: "SvelteKit Dev Server",
+			Type: TypeSvelteKit,
+			Cmd:  "npm",
+			Args: []string{"run", "dev"},
+			Dir:  path,
+		})
+		detectedType = TypeSvelteKit
+	}
+
+	// Static-site generators: their sites often carry package.json or go.mod
+	// too, so they are checked before the generic Node/Go fallbacks
+	if isHugo(path) {
+		servers = append(servers, ServerConfig{
+			Name: "Hugo Server",
+			Type: TypeHugo,
+			Cmd:  "hugo",
+			Args: []string{"server"},
+			Dir:  path,
+		})
+		detectedType = TypeHugo
+	}
+
+	if exists(filepath.Join(path, "_config.yml")) && exists(filepath.Join(path, "Gemfile")) {
+		servers = append(servers, ServerConfig{
+			Name: "Jekyll Server",
+			Type: TypeJekyll,
+			Cmd:  "bundle",
+			Args: []string{"exec", "jekyll", "serve"},
+			Dir:  path,
+		})
+		detectedType = TypeJekyll
+	}
+
+	if isEleventy(path) {
+		servers = append(servers, ServerConfig{
+			Name: "Eleventy Server",
+			Type: TypeEleventy,
+			Cmd:  "npx",
+			Args: []string{"@11ty/eleventy", "--serve"},
+			Dir:  path,
+		})
+		detectedType = TypeEleventy
+	}
+
+	// Check for Vue.js (vue.config.js or vite.config with vue). Vue CLI
+	// projects name their dev script "serve", Vite ones "dev".
 	if isVue(path) && len(servers) == 0 {
+		script := "dev"
+		if isVueCLI(path) {
+			script = "serve"
+		}
 		servers = append(servers, ServerConfig{
 			Name: "Vue Dev Server",
 			Type: TypeVue,
 			Cmd:  "npm",
-			Args: []string{"run", "dev"},
+			Args: []string{"run", script},
 			Dir:  path,
 		})
 		detectedType = TypeVue
@@ -244,6 +430,18 @@ func Detect(path string) ProjectInfo {
 		detectedType = TypeGo
 	}
 
+	// Check for a Makefile (C/C++ and other non-framework projects)
+	var makeTargets []string
+	if makefile := findMakefile(path); makefile != "" && len(servers) == 0 {
+		makeTargets = taskrunner.MakefileTargets(makefile)
+		if len(makeTargets) > 0 {
+			if target := DefaultMakeTarget(makeTargets); target != "" {
+				servers = append(servers, MakeServer(path, target))
+			}
+			detectedType = TypeMake
+		}
+	}
+
 	// Check for fullstack projects (multiple folder patterns)
 	fullstackPatterns := []struct {
 		backend  string
@@ -276,6 +474,7 @@ func Detect(path string) ProjectInfo {
 					servers = append(servers, srv)
 				}
 				detectedType = TypeFullstack
+				makeTargets = nil
 				break
 			}
 		}
@@ -287,8 +486,43 @@ func Detect(path string) ProjectInfo {
 	}
 
 	return ProjectInfo{
-		Type:    detectedType,
-		Servers: servers,
+		Type:        detectedType,
+		Servers:     servers,
+		MakeTargets: makeTargets,
+	}
+}
+
+// findMakefile returns the Makefile make would read in dir, or ""
+func findMakefile(dir string) string {
+	for _, name := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		if path := filepath.Join(dir, name); exists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// DefaultMakeTarget returns the first conventional dev target among
+// targets, or "" when there is none
+func DefaultMakeTarget(targets []string) string {
+	for _, want := range makeDevTargets {
+		for _, target := range targets {
+			if target == want {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// MakeServer is the server that runs "make target" in dir
+func MakeServer(dir, target string) ServerConfig {
+	return ServerConfig{
+		Name: "make " + target,
+		Type: TypeMake,
+		Cmd:  "make",
+		Args: []string{target},
+		Dir:  dir,
 	}
 }
 
@@ -310,6 +544,80 @@ func isFastAPI(path string) bool {
 	return false
 }
 
+func isNext(path string) bool {
+	return existsAny(path, "next.config.js", "next.config.mjs", "next.config.ts") || hasDependency(path, "next")
+}
+
+func isNuxt(path string) bool {
+	return existsAny(path, "nuxt.config.js", "nuxt.config.mjs", "nuxt.config.ts") || hasDependency(path, "nuxt", "nuxt3")
+}
+
+// isSvelteKit also matches plain Svelte on Vite, which has a
+// svelte.config.js too; both start with "npm run dev"
+func isSvelteKit(path string) bool {
+	return existsAny(path, "svelte.config.js", "svelte.config.mjs", "svelte.config.ts") || hasDependency(path, "@sveltejs/kit")
+}
+
+func isVueCLI(path string) bool {
+	return exists(filepath.Join(path, "vue.config.js")) || hasDependency(path, "@vue/cli-service")
+}
+
+func existsAny(dir string, names ...string) bool {
+	for _, name := range names {
+		if exists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDependency reports whether package.json in dir lists any of names in
+// dependencies or devDependencies. Matching the parsed keys, rather than the
+// text, keeps version tags such as "typescript": "next" from counting.
+func hasDependency(dir string, names ...string) bool {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(content, &pkg) != nil {
+		return false
+	}
+	for _, name := range names {
+		if _, ok := pkg.Dependencies[name]; ok {
+			return true
+		}
+		if _, ok := pkg.DevDependencies[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isHugo accepts hugo.toml/yaml/json on its own; the older config.toml name
+// is too generic, so it also needs a Hugo content or layouts folder
+func isHugo(path string) bool {
+	for _, name := range []string{"hugo.toml", "hugo.yaml", "hugo.json"} {
+		if exists(filepath.Join(path, name)) {
+			return true
+		}
+	}
+	return exists(filepath.Join(path, "config.toml")) &&
+		(exists(filepath.Join(path, "content")) || exists(filepath.Join(path, "layouts")))
+}
+
+func isEleventy(path string) bool {
+	for _, name := range []string{".eleventy.js", "eleventy.config.js", "eleventy.config.mjs", "eleventy.config.cjs"} {
+		if exists(filepath.Join(path, name)) {
+			return true
+		}
+	}
+	return false
+}
+
 func isReact(path string) bool {
 	pkgPath := filepath.Join(path, "package.json")
 	if exists(pkgPath) {
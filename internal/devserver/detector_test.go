@@ -0,0 +1,331 @@
+package devserver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTree creates files (relative path -> content) under a temp dir
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantType ProjectType
+		wantCmds []string
+	}{
+		{
+			name:     "Django",
+			files:    map[string]string{"manage.py": "import django\n"},
+			wantType: TypeDjango,
+			wantCmds: []string{"python manage.py runserver"},
+		},
+		{
+			name:     "FastAPI",
+			files:    map[string]string{"main.py": "from fastapi import FastAPI\napp = FastAPI()\n"},
+			wantType: TypeFastAPI,
+			wantCmds: []string{"uvicorn main:app --reload"},
+		},
+		{
+			name:     "FastAPI app.py",
+			files:    map[string]string{"app.py": "import fastapi\n"},
+			wantType: TypeFastAPI,
+			wantCmds: []string{"uvicorn app:app --reload"},
+		},
+		{
+			name:     "Spring Boot",
+			files:    map[string]string{"pom.xml": "<project/>"},
+			wantType: TypeSpring,
+			wantCmds: []string{"mvn spring-boot:run"},
+		},
+		{
+			name:     "Next.js config",
+			files:    map[string]string{"next.config.js": "module.exports = {}", "package.json": `{"dependencies": {"next": "14", "react": "18"}}`},
+			wantType: TypeNextJS,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "Next.js pages dir",
+			files:    map[string]string{"pages/index.js": "", "package.json": `{"dependencies": {"next": "14"}}`},
+			wantType: TypeNextJS,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "Nest.js",
+			files:    map[string]string{"nest-cli.json": "{}"},
+			wantType: TypeNestJS,
+			wantCmds: []string{"npm run start:dev"},
+		},
+		{
+			name:     "Angular",
+			files:    map[string]string{"angular.json": "{}"},
+			wantType: TypeAngular,
+			wantCmds: []string{"npm start"},
+		},
+		{
+			name:     "Next.js app in src",
+			files:    map[string]string{"src/app/page.tsx": "", "package.json": `{"dependencies": {"next": "15", "react": "19"}}`},
+			wantType: TypeNextJS,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "next version tag is not Next.js",
+			files:    map[string]string{"package.json": `{"devDependencies": {"typescript": "next"}}`},
+			wantType: TypeNode,
+			wantCmds: []string{"npm start"},
+		},
+		{
+			name:     "Nuxt",
+			files:    map[string]string{"nuxt.config.ts": "export default defineNuxtConfig({})", "package.json": `{"dependencies": {"nuxt": "3", "vue": "3"}}`},
+			wantType: TypeNuxt,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "SvelteKit",
+			files:    map[string]string{"svelte.config.js": "", "vite.config.js": "", "package.json": `{"devDependencies": {"@sveltejs/kit": "2"}}`},
+			wantType: TypeSvelteKit,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "Hugo",
+			files:    map[string]string{"hugo.toml": "title = 'site'\n"},
+			wantType: TypeHugo,
+			wantCmds: []string{"hugo server"},
+		},
+		{
+			name:     "Hugo config.toml with content",
+			files:    map[string]string{"config.toml": "", "content/_index.md": ""},
+			wantType: TypeHugo,
+			wantCmds: []string{"hugo server"},
+		},
+		{
+			name:     "Jekyll",
+			files:    map[string]string{"_config.yml": "title: blog\n", "Gemfile": "gem 'jekyll'\n"},
+			wantType: TypeJekyll,
+			wantCmds: []string{"bundle exec jekyll serve"},
+		},
+		{
+			name:     "Eleventy",
+			files:    map[string]string{".eleventy.js": "", "package.json": `{"devDependencies": {"@11ty/eleventy": "2"}}`},
+			wantType: TypeEleventy,
+			wantCmds: []string{"npx @11ty/eleventy --serve"},
+		},
+		{
+			name:     "Vue",
+			files:    map[string]string{"package.json": `{"dependencies": {"vue": "3"}}`},
+			wantType: TypeVue,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "Vue CLI",
+			files:    map[string]string{"vue.config.js": "", "package.json": `{"dependencies": {"vue": "2"}, "devDependencies": {"@vue/cli-service": "5"}}`},
+			wantType: TypeVue,
+			wantCmds: []string{"npm run serve"},
+		},
+		{
+			name:     "Vite",
+			files:    map[string]string{"vite.config.js": "export default {}"},
+			wantType: TypeVite,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "Webpack",
+			files:    map[string]string{"webpack.config.js": "module.exports = {}"},
+			wantType: TypeWebpack,
+			wantCmds: []string{"npm run dev"},
+		},
+		{
+			name:     "React",
+			files:    map[string]string{"package.json": `{"dependencies": {"react": "18"}}`},
+			wantType: TypeReact,
+			wantCmds: []string{"npm start"},
+		},
+		{
+			name:     "Express",
+			files:    map[string]string{"package.json": `{"dependencies": {"express": "4"}}`},
+			wantType: TypeExpress,
+			wantCmds: []string{"npm start"},
+		},
+		{
+			name:     "Node",
+			files:    map[string]string{"package.json": `{"name": "plain"}`},
+			wantType: TypeNode,
+			wantCmds: []string{"npm start"},
+		},
+		{
+			name:     "Flask",
+			files:    map[string]string{"app.py": "from flask import Flask\n"},
+			wantType: TypeFlask,
+			wantCmds: []string{"flask run --debug"},
+		},
+		{
+			name:     "Python",
+			files:    map[string]string{"main.py": "print('hi')\n"},
+			wantType: TypePython,
+			wantCmds: []string{"python main.py"},
+		},
+		{
+			name:     "Python requirements only",
+			files:    map[string]string{"requirements.txt": "requests\n"},
+			wantType: TypePython,
+			wantCmds: []string{"python"},
+		},
+		{
+			name:     "Go",
+			files:    map[string]string{"go.mod": "module example.com/x\n"},
+			wantType: TypeGo,
+			wantCmds: []string{"go run ."},
+		},
+		{
+			name: "Fullstack folders",
+			files: map[string]string{
+				"backend/go.mod":        "module example.com/api\n",
+				"frontend/package.json": `{"dependencies": {"react": "18"}}`,
+			},
+			wantType: TypeFullstack,
+			wantCmds: []string{"go run .", "npm start"},
+		},
+		{
+			name:     "Fullstack from several servers",
+			files:    map[string]string{"manage.py": "", "angular.json": "{}"},
+			wantType: TypeFullstack,
+			wantCmds: []string{"python manage.py runserver", "npm start"},
+		},
+		{
+			name:     "Django app dir is not Next.js",
+			files:    map[string]string{"manage.py": "", "app/models.py": ""},
+			wantType: TypeDjango,
+			wantCmds: []string{"python manage.py runserver"},
+		},
+		{
+			name:     "Makefile dev target",
+			files:    map[string]string{"Makefile": "build:\n\tcc -o app main.c\nrun: build\n\t./app\ndev: build\n\t./app --watch\n"},
+			wantType: TypeMake,
+			wantCmds: []string{"make dev"},
+		},
+		{
+			name:     "Makefile without a dev target",
+			files:    map[string]string{"Makefile": "all:\n\tcc main.c\nclean:\n\trm -f a.out\n"},
+			wantType: TypeMake,
+			wantCmds: []string{},
+		},
+		{
+			name:     "Go project with a Makefile stays Go",
+			files:    map[string]string{"go.mod": "module x\n", "Makefile": "run:\n\tgo run .\n"},
+			wantType: TypeGo,
+			wantCmds: []string{"go run ."},
+		},
+		{
+			name:     "Bare config.toml is not Hugo",
+			files:    map[string]string{"config.toml": ""},
+			wantType: TypeUnknown,
+			wantCmds: []string{},
+		},
+		{
+			name:     "Unknown",
+			files:    map[string]string{"README.md": "# nothing here"},
+			wantType: TypeUnknown,
+			wantCmds: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeTree(t, tt.files)
+			info := Detect(root)
+			if info.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", info.Type, tt.wantType)
+			}
+			if got := info.Commands(); !reflect.DeepEqual(got, tt.wantCmds) {
+				t.Errorf("Commands() = %q, want %q", got, tt.wantCmds)
+			}
+
+			// Detection only depends on the tree, so repeating it must agree
+			if again := Detect(root); !reflect.DeepEqual(again, info) {
+				t.Errorf("second Detect = %+v, want %+v", again, info)
+			}
+		})
+	}
+}
+
+func TestDetect_FullstackServerNames(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"server/requirements.txt": "flask\n",
+		"client/vite.config.ts":   "",
+	})
+	info := Detect(root)
+	if info.Type != TypeFullstack || len(info.Servers) != 2 {
+		t.Fatalf("got %+v, want two fullstack servers", info)
+	}
+	if info.Servers[0].Name != "Backend" || info.Servers[1].Name != "Frontend" {
+		t.Errorf("names = %q, %q; want Backend, Frontend", info.Servers[0].Name, info.Servers[1].Name)
+	}
+	if info.Servers[0].Dir != filepath.Join(root, "server") {
+		t.Errorf("backend dir = %q", info.Servers[0].Dir)
+	}
+}
+
+func TestDetect_MakeTargets(t *testing.T) {
+	root := writeTree(t, map[string]string{"Makefile": ".PHONY: all clean\nall:\n\tcc main.c\nclean:\n\trm -f a.out\n"})
+	info := Detect(root)
+	if want := []string{"all", "clean"}; !reflect.DeepEqual(info.MakeTargets, want) {
+		t.Errorf("MakeTargets = %q, want %q", info.MakeTargets, want)
+	}
+	if len(info.Servers) != 0 {
+		t.Errorf("Servers = %+v, want none without a dev target", info.Servers)
+	}
+	if got := MakeServer(root, "all").CommandLine(); got != "make all" {
+		t.Errorf("MakeServer().CommandLine() = %q, want make all", got)
+	}
+}
+
+func TestProjectInfo_JSON(t *testing.T) {
+	info := ProjectInfo{
+		Type:    TypeGo,
+		Servers: []ServerConfig{{Name: "Go Server", Type: TypeGo, Cmd: "go", Args: []string{"run", "."}, Dir: "/src"}},
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Go","servers":[{"name":"Go Server","type":"Go","cmd":"go","args":["run","."],"dir":"/src"}]}`
+	if string(data) != want {
+		t.Errorf("json = %s\nwant   %s", data, want)
+	}
+}
+
+func TestTypes_CoverEveryDetectableType(t *testing.T) {
+	seen := map[ProjectType]bool{}
+	for _, info := range Types() {
+		if info.Marker == "" || info.Language == "" {
+			t.Errorf("%s: missing metadata %+v", info.Type, info)
+		}
+		if seen[info.Type] {
+			t.Errorf("%s listed twice", info.Type)
+		}
+		seen[info.Type] = true
+	}
+	if len(seen) != 22 {
+		t.Errorf("Types() lists %d types, want 22", len(seen))
+	}
+	if info := TypeUnknown.Info(); info.Marker != "" {
+		t.Errorf("TypeUnknown.Info() = %+v, want empty metadata", info)
+	}
+}
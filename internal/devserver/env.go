@@ -0,0 +1,139 @@
+package devserver
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvFileName is the dotenv file loaded from a server's working directory
+const EnvFileName = ".env"
+
+// EnvFile returns the .env path for dir, or "" if there is none
+func EnvFile(dir string) string {
+	path := filepath.Join(dir, EnvFileName)
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return path
+	}
+	return ""
+}
+
+// ParseEnvFile reads a dotenv file into KEY=VALUE pairs, in file order.
+// It understands blank lines, # comments, an optional "export " prefix,
+// single-quoted (literal) and double-quoted (\n, \t, \" escapes) values,
+// and trailing " # comments" after unquoted values.
+func ParseEnvFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vars []string
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", filepath.Base(path), n)
+		}
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s: %w", filepath.Base(path), n, key, err)
+		}
+		vars = append(vars, key+"="+value)
+	}
+	return vars, scanner.Err()
+}
+
+func parseEnvValue(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	switch quote := v[0]; quote {
+	case '\'', '"':
+		end := closingQuote(v, quote)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated %c quote", quote)
+		}
+		if rest := strings.TrimSpace(v[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected text after closing quote")
+		}
+		inner := v[1:end]
+		if quote == '"' {
+			inner = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(inner)
+		}
+		return inner, nil
+	}
+
+	// Unquoted: a " #" starts a comment
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v), nil
+}
+
+// closingQuote finds the quote ending a value that starts with one. Double
+// quotes may be escaped with a backslash.
+func closingQuote(v string, quote byte) int {
+	for i := 1; i < len(v); i++ {
+		switch {
+		case quote == '"' && v[i] == '\\':
+			i++
+		case v[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeEnv returns base plus the vars whose keys base does not set.
+// Variables already in the environment win, as with most dotenv loaders;
+// within the file a later assignment replaces an earlier one.
+func mergeEnv(base, vars []string) []string {
+	inBase := make(map[string]bool, len(base))
+	for _, kv := range base {
+		k, _, _ := strings.Cut(kv, "=")
+		inBase[k] = true
+	}
+	merged := append([]string{}, base...)
+	added := map[string]int{} // Key -> index in merged
+	for _, kv := range vars {
+		k, _, _ := strings.Cut(kv, "=")
+		if inBase[k] {
+			continue
+		}
+		if i, ok := added[k]; ok {
+			merged[i] = kv
+			continue
+		}
+		added[k] = len(merged)
+		merged = append(merged, kv)
+	}
+	return merged
+}
+
+// overrideEnv returns base with vars set, replacing any earlier value of
+// the same key (unlike mergeEnv, which never touches base)
+func overrideEnv(base, vars []string) []string {
+	set := make(map[string]bool, len(vars))
+	for _, kv := range vars {
+		k, _, _ := strings.Cut(kv, "=")
+		set[k] = true
+	}
+	merged := make([]string, 0, len(base)+len(vars))
+	for _, kv := range base {
+		if k, _, _ := strings.Cut(kv, "="); !set[k] {
+			merged = append(merged, kv)
+		}
+	}
+	return mergeEnv(merged, vars)
+}
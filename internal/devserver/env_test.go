@@ -0,0 +1,84 @@
+package devserver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	root := writeTree(t, map[string]string{".env": strings.Join([]string{
+		"# database",
+		"",
+		"DB_HOST=localhost",
+		"export PORT=8080",
+		"NAME = spaced ",
+		"GREETING=\"hello world\"",
+		"ESCAPED=\"line1\\nline2 \\\"quoted\\\"\"",
+		"LITERAL='no $expansion \\n here'",
+		"INLINE=value # trailing comment",
+		"HASH=abc#def",
+		"QUOTED_COMMENT=\"a # b\" # comment",
+		"EMPTY=",
+	}, "\n")})
+
+	got, err := ParseEnvFile(EnvFile(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"DB_HOST=localhost",
+		"PORT=8080",
+		"NAME=spaced",
+		"GREETING=hello world",
+		"ESCAPED=line1\nline2 \"quoted\"",
+		`LITERAL=no $expansion \n here`,
+		"INLINE=value",
+		"HASH=abc#def",
+		"QUOTED_COMMENT=a # b",
+		"EMPTY=",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseEnvFile =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestParseEnvFile_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing equals":    "JUST_A_WORD\n",
+		"unterminated":      "A=\"open\n",
+		"text after quotes": "A='x' y\n",
+		"space in key":      "MY KEY=1\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := writeTree(t, map[string]string{".env": content})
+			if _, err := ParseEnvFile(EnvFile(root)); err == nil || !strings.Contains(err.Error(), ".env:1") {
+				t.Errorf("err = %v, want a .env:1 error", err)
+			}
+		})
+	}
+}
+
+func TestEnvFile_Missing(t *testing.T) {
+	if path := EnvFile(t.TempDir()); path != "" {
+		t.Errorf("EnvFile = %q, want empty", path)
+	}
+}
+
+func TestMergeEnv(t *testing.T) {
+	base := []string{"PATH=/bin", "PORT=3000"}
+	vars := []string{"PORT=8080", "DEBUG=1", "DEBUG=2"}
+	want := []string{"PATH=/bin", "PORT=3000", "DEBUG=2"}
+	if got := mergeEnv(base, vars); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeEnv = %q, want %q", got, want)
+	}
+}
+
+func TestOverrideEnv(t *testing.T) {
+	base := []string{"PATH=/bin", "PORT=3000", "API_URL=http://localhost:8000"}
+	vars := []string{"PORT=4000", "DEBUG=1", "PORT=4001"}
+	want := []string{"PATH=/bin", "API_URL=http://localhost:8000", "PORT=4001", "DEBUG=1"}
+	if got := overrideEnv(base, vars); !reflect.DeepEqual(got, want) {
+		t.Errorf("overrideEnv = %q, want %q", got, want)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"regexp"
 	"sync"
+
+	"github.com/phravins/devcli/internal/procs"
 )
 
 // ANSI escape code regex
@@ -19,28 +22,34 @@ type LogLine struct {
 	ServerName string
 	Line       string
 	IsError    bool
+	URL        string // Local URL announced on this line, if any
 }
 
 type Runner struct {
+	// LoadEnvFile merges each server's .env into its environment (default on)
+	LoadEnvFile bool
+
 	ctx       context.Context
 	cancel    context.CancelFunc
 	processes []*exec.Cmd
 	logChan   chan LogLine
 	wg        sync.WaitGroup
+	stopOnce  sync.Once
 }
 
 func NewRunner() *Runner {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Runner{
-		ctx:       ctx,
-		cancel:    cancel,
-		processes: make([]*exec.Cmd, 0),
-		logChan:   make(chan LogLine, 100),
+		LoadEnvFile: true,
+		ctx:         ctx,
+		cancel:      cancel,
+		processes:   make([]*exec.Cmd, 0),
+		logChan:     make(chan LogLine, 100),
 	}
 }
 
 func (r *Runner) Start(info ProjectInfo) error {
-	if info.Type == TypeUnknown || len(info.Servers) == 0 {
+	if len(info.Servers) == 0 {
 		return fmt.Errorf("unable to detect project type or no servers configured")
 	}
 
@@ -59,6 +68,21 @@ func (r *Runner) startServer(config ServerConfig) error {
 	if config.Dir != "" {
 		cmd.Dir = config.Dir
 	}
+	if r.LoadEnvFile {
+		if path := EnvFile(config.Dir); path != "" {
+			vars, err := ParseEnvFile(path)
+			if err != nil {
+				return err
+			}
+			cmd.Env = mergeEnv(os.Environ(), vars)
+		}
+	}
+	if len(config.Env) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = overrideEnv(cmd.Env, config.Env)
+	}
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -70,7 +94,7 @@ func (r *Runner) startServer(config ServerConfig) error {
 		return err
 	}
 
-	if err := cmd.Start(); err != nil {
+	if err := procs.Start(cmd, config.Name); err != nil {
 		return err
 	}
 
@@ -92,13 +116,15 @@ func (r *Runner) streamLogs(serverName string, reader io.Reader, isError bool) {
 
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
+		line := re.ReplaceAllString(scanner.Text(), "")
 		select {
 		case <-r.ctx.Done():
 			return
 		case r.logChan <- LogLine{
 			ServerName: serverName,
-			Line:       re.ReplaceAllString(scanner.Text(), ""),
+			Line:       line,
 			IsError:    isError,
+			URL:        LocalURL(line),
 		}:
 		}
 	}
@@ -108,17 +134,24 @@ func (r *Runner) GetLogChannel() <-chan LogLine {
 	return r.logChan
 }
 
+// Stop kills the servers and returns once their output has ended. It is
+// safe to call more than once, e.g. by a restart and a quit at the same time.
 func (r *Runner) Stop() {
-	r.cancel()
-
-	for _, cmd := range r.processes {
-		if cmd.Process != nil {
-			cmd.Process.Kill()
+	r.stopOnce.Do(func() {
+		// Kill the whole tree first: children that inherited the output pipes
+		// would otherwise keep the log streams (and their ports) open
+		for _, cmd := range r.processes {
+			if cmd.ProcessState != nil {
+				procs.Unregister(cmd) // Already reaped: its PID may be reused
+				continue
+			}
+			procs.Kill(cmd)
 		}
-	}
+		r.cancel()
 
-	r.wg.Wait()
-	close(r.logChan)
+		r.wg.Wait()
+		close(r.logChan)
+	})
 }
 
 func (r *Runner) IsRunning() bool {
@@ -130,20 +163,64 @@ func (r *Runner) IsRunning() bool {
 	return false
 }
 
-// Legacy function for backward compatibility
-func Run(info ProjectInfo) error {
-	if info.Type == TypeUnknown || len(info.Servers) == 0 {
-		return fmt.Errorf("unable to detect project type")
-	}
-
-	runner := NewRunner()
-	defer runner.Stop()
+// Run starts info's servers with a default Runner and copies their logs to
+// out until ctx is cancelled or every server has exited
+func Run(ctx context.Context, info ProjectInfo, out io.Writer) error {
+	return NewRunner().Run(ctx, info, out)
+}
 
-	if err := runner.Start(info); err != nil {
+// Run is the headless counterpart of the dashboard: log lines are written
+// to out, prefixed with the server name when there are several servers.
+// Once every server has exited, it returns the first failure, which wraps
+// the server's *exec.ExitError.
+func (r *Runner) Run(ctx context.Context, info ProjectInfo, out io.Writer) error {
+	if err := r.Start(info); err != nil {
 		return err
 	}
+	defer r.Stop()
+
+	// Each server's streams close when it exits
+	exited := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(exited)
+	}()
+
+	prefix := len(info.Servers) > 1
+	write := func(l LogLine) {
+		if prefix {
+			fmt.Fprintf(out, "[%s] %s\n", l.ServerName, l.Line)
+		} else {
+			fmt.Fprintln(out, l.Line)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case l := <-r.logChan:
+			write(l)
+		case <-exited:
+			// No more senders: flush what is buffered
+			for {
+				select {
+				case l := <-r.logChan:
+					write(l)
+				default:
+					return r.wait(info)
+				}
+			}
+		}
+	}
+}
 
-	// Wait for context cancellation
-	<-runner.ctx.Done()
-	return nil
+// wait reaps the exited servers, returning the first one that failed
+func (r *Runner) wait(info ProjectInfo) error {
+	var firstErr error
+	for i, cmd := range r.processes {
+		if err := cmd.Wait(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s exited: %w", info.Servers[i].Name, err)
+		}
+	}
+	return firstErr
 }
@@ -0,0 +1,36 @@
+package devserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+func TestRun_ReportsFailedServer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	// procs keeps its registry in the config dir
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	run := func(script string) error {
+		info := ProjectInfo{Servers: []ServerConfig{{Name: "web", Cmd: "sh", Args: []string{"-c", script}}}}
+		r := NewRunner()
+		r.LoadEnvFile = false
+		return r.Run(context.Background(), info, io.Discard)
+	}
+
+	if err := run("echo ok"); err != nil {
+		t.Errorf("clean exit returned %v", err)
+	}
+
+	err := run("echo boom >&2; exit 3")
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("Run() = %v, want exit status 3", err)
+	}
+}
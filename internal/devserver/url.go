@@ -0,0 +1,25 @@
+package devserver
+
+import (
+	"regexp"
+	"strings"
+)
+
+// localURLPattern matches the address dev servers announce on startup, e.g.
+// "Web Server is available at http://localhost:1313/" (Hugo), "Server
+// address: http://127.0.0.1:4000/" (Jekyll) or "Local: http://localhost:5173/"
+var localURLPattern = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1?\])(?::\d+)?(?:/[^\s"'<>]*)?`)
+
+// LocalURL returns the first local http(s) URL in a log line, or "". Wildcard
+// bind addresses are rewritten to localhost so the URL can be opened.
+func LocalURL(line string) string {
+	url := localURLPattern.FindString(line)
+	if url == "" {
+		return ""
+	}
+	url = strings.TrimRight(url, ".,;:)")
+	for _, wildcard := range []string{"0.0.0.0", "[::]"} {
+		url = strings.Replace(url, "://"+wildcard, "://localhost", 1)
+	}
+	return url
+}
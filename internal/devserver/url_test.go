@@ -0,0 +1,25 @@
+package devserver
+
+import "testing"
+
+func TestLocalURL(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"Web Server is available at http://localhost:1313/ (bind address 127.0.0.1)", "http://localhost:1313/"},
+		{"    Server address: http://127.0.0.1:4000/", "http://127.0.0.1:4000/"},
+		{"[11ty] Server at http://localhost:8080/", "http://localhost:8080/"},
+		{"  ➜  Local:   http://localhost:5173/", "http://localhost:5173/"},
+		{"Uvicorn running on http://0.0.0.0:8000 (Press CTRL+C to quit)", "http://localhost:8000"},
+		{"Listening on http://[::]:3000.", "http://localhost:3000"},
+		{"Listening on http://[::1]:3000", "http://[::1]:3000"},
+		{"Docs at https://gohugo.io/ for help", ""},
+		{"Compiled successfully", ""},
+	}
+	for _, tt := range tests {
+		if got := LocalURL(tt.line); got != tt.want {
+			t.Errorf("LocalURL(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
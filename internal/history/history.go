@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/phravins/devcli/internal/config"
 )
 
 type Entry struct {
@@ -14,9 +16,12 @@ type Entry struct {
 }
 
 func getHistoryPath() string {
-	home, _ := os.UserHomeDir()
-	dir := filepath.Join(home, ".devcli")
-	os.MkdirAll(dir, 0755)
+	dir, err := config.Dir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".devcli")
+		os.MkdirAll(dir, 0755)
+	}
 	return filepath.Join(dir, "history.json")
 }
 
@@ -0,0 +1,316 @@
+// Package keymap holds the configurable key bindings of the TUI. Each
+// scope (editor, filemanager) has actions with a default key that
+// "keys.<scope>.<action>" in the config can move to another key. The
+// dashboards have no scope and keep fixed keys.
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Binding is a configurable action and its default key
+type Binding struct {
+	Action  string // Config name, e.g. "run" for keys.editor.run
+	Default string // Key name as Bubble Tea reports it, e.g. "ctrl+r"
+	Desc    string
+}
+
+// Defaults lists the configurable actions of each scope, in help order
+var Defaults = map[string][]Binding{
+	"editor": {
+		{"run", "ctrl+r", "Run code"},
+		{"stop", "ctrl+k", "Stop the running program"},
+		{"save", "ctrl+s", "Save file"},
+		{"new", "ctrl+n", "New file"},
+		{"undo", "ctrl+z", "Undo"},
+		{"redo", "ctrl+y", "Redo"},
+		{"delete_line", "ctrl+d", "Delete line"},
+		{"duplicate_line", "alt+d", "Duplicate line"},
+		{"goto_line", "ctrl+g", "Go to line"},
+		{"command", "ctrl+p", "Run a shell command"},
+		{"help", "ctrl+h", "Toggle help"},
+		{"focus_output", "ctrl+o", "Focus the output pane"},
+		{"focus_editor", "ctrl+e", "Focus the editor"},
+		{"clear_output", "ctrl+l", "Clear output"},
+		{"open_tab", "ctrl+t", "Open a file in a new tab"},
+		{"close_tab", "ctrl+w", "Close tab"},
+		{"column_select", "ctrl+b", "Column selection"},
+		{"format", "alt+f", "Format buffer"},
+		{"run_selection", "alt+r", "Run line or selection"},
+		{"run_external", "alt+e", "Run in a terminal window"},
+		{"copy_run_command", "alt+c", "Copy the run command"},
+		{"repl", "alt+p", "REPL"},
+		{"stdin", "alt+s", "Edit the program input (stdin)"},
+		{"explain", "alt+x", "Explain the last error"},
+		{"insert_file", "alt+i", "Insert a file"},
+		{"rename", "alt+n", "Rename file"},
+		{"line_endings", "alt+l", "Toggle LF/CRLF"},
+		{"import_path", "alt+g", "Copy the Go import path"},
+		{"hex_view", "alt+h", "Hex view"},
+	},
+	"filemanager": {
+		{"move", "alt+m", "Move/rename the selected file"},
+		{"copy", "alt+c", "Copy the selected file"},
+		{"delete", "alt+d", "Delete the selected file or folder"},
+		{"new", "alt+n", "Create a file or folder"},
+		{"rescan", "ctrl+r", "Stop the global scan, or rescan"},
+		{"edit", "alt+e", "Edit the selected file"},
+		{"open", "alt+x", "Open with the default application"},
+		{"category", "alt+t", "Cycle file category"},
+		{"hidden", "alt+.", "Show or hide hidden files"},
+		{"sort", "alt+o", "Cycle sort order (name, size, modified, extension)"},
+		{"grep", "alt+f", "Search file contents"},
+		{"import_path", "alt+g", "Copy the Go import path"},
+		{"hex_view", "alt+h", "Hex view"},
+		{"terminal", "alt+s", "Open a terminal here"},
+		{"path", "ctrl+l", "Edit the path"},
+	},
+}
+
+// reserved are keys a scope handles itself (navigation, text entry,
+// quitting); they cannot be given to an action
+var reserved = map[string][]string{
+	"editor": {
+		"esc", "ctrl+c", "ctrl+q", "enter", "tab", "shift+tab", "backspace", "delete",
+		"up", "down", "left", "right", "home", "end", "pgup", "pgdown",
+		"ctrl+up", "ctrl+down", "ctrl+left", "ctrl+right", "ctrl+home", "ctrl+end",
+		"alt+left", "alt+right", "ctrl+_", "ctrl+@", "ctrl+]",
+		"shift+left", "shift+right", "shift+up", "shift+down", "shift+home", "shift+end", "ctrl+x", "ctrl+v",
+	},
+	"filemanager": {
+		"esc", "ctrl+c", "enter", "tab", "backspace", "up", "down", "left",
+		"alt+up", "alt+down", "ctrl+g",
+	},
+}
+
+// aliases are names terminals cannot tell apart from another key
+var aliases = map[string]string{
+	"ctrl+i": "tab",
+	"ctrl+m": "enter",
+	"ctrl+[": "esc",
+}
+
+// keyTypes maps Bubble Tea key names to their key type
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for k := tea.KeyType(-512); k < 512; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes && k != tea.KeySpace {
+			types[name] = k
+		}
+	}
+	return types
+}()
+
+// Normalize rewrites a configured key to the name Bubble Tea reports:
+// lower case, "control"/"option"/"meta" spelled ctrl and alt, alt first
+func Normalize(key string) string {
+	key = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(key), " ", ""))
+	if key == "" {
+		return ""
+	}
+	var alt bool
+	var mods []string
+	parts := strings.Split(key, "+")
+	last := parts[len(parts)-1]
+	if last == "" && len(parts) > 1 {
+		last = "+" // "ctrl++"
+		parts = parts[:len(parts)-1]
+	}
+	for _, p := range parts[:len(parts)-1] {
+		switch p {
+		case "alt", "option", "opt", "meta":
+			alt = true
+		case "control", "ctl":
+			mods = append(mods, "ctrl")
+		default:
+			mods = append(mods, p)
+		}
+	}
+	name := strings.Join(append(mods, last), "+")
+	if alt {
+		name = "alt+" + name
+	}
+	return name
+}
+
+// KeyMsg returns the key event Bubble Tea sends for key (a normalized
+// name); ok is false for names it never reports
+func KeyMsg(key string) (msg tea.KeyMsg, ok bool) {
+	name, alt := strings.CutPrefix(key, "alt+")
+	if t, found := keyTypes[name]; found {
+		return tea.KeyMsg{Type: t, Alt: alt}, true
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// Label formats key for help and footers: "ctrl+r" becomes "Ctrl+R"
+func Label(key string) string {
+	parts := strings.Split(key, "+")
+	for i, p := range parts {
+		switch p {
+		case "pgup":
+			parts[i] = "PgUp"
+		case "pgdown":
+			parts[i] = "PgDn"
+		default:
+			if r, size := utf8.DecodeRuneInString(p); size > 0 {
+				parts[i] = strings.ToUpper(string(r)) + p[size:]
+			}
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// Map is the active keymap of one scope
+type Map struct {
+	scope    string
+	keys     map[string]string // action -> key
+	bound    map[string]string // key -> action
+	defaults map[string]string // default key -> action
+}
+
+// Load builds the keymap of scope, reading keys.<scope>.<action> with get
+// (config.GetString). An override that is not a usable key or that clashes
+// with another binding is ignored, with a warning saying why.
+func Load(scope string, get func(key string) string) (Map, []string) {
+	m := Map{scope: scope, keys: map[string]string{}, bound: map[string]string{}, defaults: map[string]string{}}
+	var warnings []string
+	isReserved := map[string]bool{}
+	for _, key := range reserved[scope] {
+		isReserved[key] = true
+	}
+
+	overridden := map[string]bool{}
+	for _, b := range Defaults[scope] {
+		m.keys[b.Action] = b.Default
+		m.defaults[b.Default] = b.Action
+		raw := get(ConfigKey(scope, b.Action))
+		key := Normalize(raw)
+		if key == "" || key == b.Default {
+			continue
+		}
+		var problem string
+		msg, known := KeyMsg(key)
+		switch {
+		case aliases[key] != "":
+			problem = fmt.Sprintf("terminals send %s as %s", key, aliases[key])
+		case !known:
+			problem = "unknown key"
+		case msg.Type == tea.KeyRunes && !msg.Alt:
+			problem = "needs Ctrl, Alt or a function key (a plain key is typed as text)"
+		case isReserved[key]:
+			problem = "the key is reserved"
+		}
+		if problem != "" {
+			warnings = append(warnings, fmt.Sprintf("%s = %q ignored: %s", ConfigKey(scope, b.Action), raw, problem))
+			continue
+		}
+		m.keys[b.Action] = key
+		overridden[b.Action] = true
+	}
+
+	// Overrides that collide with another binding fall back to their
+	// default; repeat since that can create a new collision
+	for changed := true; changed; {
+		changed = false
+		byKey := map[string][]string{}
+		for _, b := range Defaults[scope] {
+			byKey[m.keys[b.Action]] = append(byKey[m.keys[b.Action]], b.Action)
+		}
+		for _, b := range Defaults[scope] {
+			actions := byKey[m.keys[b.Action]]
+			if len(actions) < 2 || !overridden[b.Action] {
+				continue
+			}
+			var others []string
+			for _, a := range actions {
+				if a != b.Action {
+					others = append(others, ConfigKey(scope, a))
+				}
+			}
+			warnings = append(warnings, fmt.Sprintf("%s = %q ignored: conflicts with %s", ConfigKey(scope, b.Action), m.keys[b.Action], strings.Join(others, ", ")))
+			m.keys[b.Action] = b.Default
+			overridden[b.Action] = false
+			changed = true
+			break
+		}
+	}
+
+	for action, key := range m.keys {
+		m.bound[key] = action
+	}
+	return m, warnings
+}
+
+// ConfigKey is the config key that sets action's key in scope
+func ConfigKey(scope, action string) string {
+	return "keys." + scope + "." + action
+}
+
+// Key returns the key bound to action
+func (m Map) Key(action string) string {
+	return m.keys[action]
+}
+
+// Translate turns a key press into the default key of the action bound to
+// it, so handlers can keep matching the defaults. ok is false for the
+// default key of an action that was moved, which should be ignored.
+func (m Map) Translate(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	pressed := msg.String()
+	if action, found := m.bound[pressed]; found {
+		def := m.defaultOf(action)
+		if def == pressed {
+			return msg, true
+		}
+		out, _ := KeyMsg(def)
+		return out, true
+	}
+	if _, found := m.defaults[pressed]; found {
+		return msg, false
+	}
+	return msg, true
+}
+
+func (m Map) defaultOf(action string) string {
+	for _, b := range Defaults[m.scope] {
+		if b.Action == action {
+			return b.Default
+		}
+	}
+	return ""
+}
+
+// Relabel returns label with the key of every action bound to a default
+// key shown instead; label is a footer key such as "Ctrl+R"
+func (m Map) Relabel(label string) string {
+	for _, b := range Defaults[m.scope] {
+		if Label(b.Default) == label && m.keys[b.Action] != b.Default {
+			return Label(m.keys[b.Action])
+		}
+	}
+	return label
+}
+
+// Scope names the part of the TUI the map belongs to
+func (m Map) Scope() string {
+	return m.scope
+}
+
+// Check loads every scope and returns all warnings, sorted
+func Check(get func(key string) string) []string {
+	var warnings []string
+	for scope := range Defaults {
+		_, w := Load(scope, get)
+		warnings = append(warnings, w...)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
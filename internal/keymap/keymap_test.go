@@ -0,0 +1,133 @@
+package keymap
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// getter serves config values from a map
+func getter(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Ctrl+R":         "ctrl+r",
+		" control + s ":  "ctrl+s",
+		"Ctrl+Alt+R":     "alt+ctrl+r",
+		"option+m":       "alt+m",
+		"F5":             "f5",
+		"":               "",
+		"Meta+Shift+Tab": "alt+shift+tab",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKeyMsg_MatchesBubbleTeaNames(t *testing.T) {
+	for _, name := range []string{"ctrl+r", "alt+m", "f5", "alt+ctrl+r", "pgup", "shift+tab"} {
+		msg, ok := KeyMsg(name)
+		if !ok {
+			t.Errorf("KeyMsg(%q) not found", name)
+			continue
+		}
+		if msg.String() != name {
+			t.Errorf("KeyMsg(%q).String() = %q", name, msg.String())
+		}
+	}
+	if _, ok := KeyMsg("ctrl+shift+r"); ok {
+		t.Error("KeyMsg(ctrl+shift+r) should be unknown")
+	}
+}
+
+func TestLoad_Overrides(t *testing.T) {
+	m, warnings := Load("editor", getter(map[string]string{
+		"keys.editor.run":  "F5",
+		"keys.editor.save": "ctrl+r", // Free now that run moved
+	}))
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %q", warnings)
+	}
+	if m.Key("run") != "f5" || m.Key("save") != "ctrl+r" || m.Key("new") != "ctrl+n" {
+		t.Errorf("keys = run %q, save %q, new %q", m.Key("run"), m.Key("save"), m.Key("new"))
+	}
+
+	// F5 runs (handlers still see Ctrl+R); Ctrl+R now saves (Ctrl+S)
+	if got, ok := m.Translate(tea.KeyMsg{Type: tea.KeyF5}); !ok || got.String() != "ctrl+r" {
+		t.Errorf("F5 -> %q, %v; want ctrl+r", got.String(), ok)
+	}
+	if got, ok := m.Translate(tea.KeyMsg{Type: tea.KeyCtrlR}); !ok || got.String() != "ctrl+s" {
+		t.Errorf("Ctrl+R -> %q, %v; want ctrl+s", got.String(), ok)
+	}
+	// Ctrl+S has no action any more; other keys pass through
+	if _, ok := m.Translate(tea.KeyMsg{Type: tea.KeyCtrlS}); ok {
+		t.Error("Ctrl+S should be ignored once save moved")
+	}
+	if got, ok := m.Translate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); !ok || got.String() != "x" {
+		t.Errorf("x -> %q, %v; want unchanged", got.String(), ok)
+	}
+	if got := m.Relabel("Ctrl+R"); got != "F5" {
+		t.Errorf("Relabel(Ctrl+R) = %q, want F5", got)
+	}
+}
+
+func TestLoad_Warnings(t *testing.T) {
+	m, warnings := Load("filemanager", getter(map[string]string{
+		"keys.filemanager.move":     "m",          // Plain key
+		"keys.filemanager.copy":     "alt+e",      // Edit's key
+		"keys.filemanager.edit":     "esc",        // Reserved
+		"keys.filemanager.category": "ctrl+i",     // Same as Tab
+		"keys.filemanager.grep":     "hyper+f",    // Unknown
+		"keys.filemanager.terminal": "ctrl+shift", // Unknown
+	}))
+	if len(warnings) != 6 {
+		t.Fatalf("got %d warnings, want 6: %q", len(warnings), warnings)
+	}
+	for _, want := range []string{"plain key", "conflicts with keys.filemanager.edit", "reserved", "as tab", "unknown key"} {
+		if !strings.Contains(strings.Join(warnings, "\n"), want) {
+			t.Errorf("warnings %q lack %q", warnings, want)
+		}
+	}
+	// Every override was dropped
+	for _, b := range Defaults["filemanager"] {
+		if m.Key(b.Action) != b.Default {
+			t.Errorf("%s = %q, want default %q", b.Action, m.Key(b.Action), b.Default)
+		}
+	}
+}
+
+func TestDefaults_Unique(t *testing.T) {
+	for scope, bindings := range Defaults {
+		_, warnings := Load(scope, getter(nil))
+		if len(warnings) != 0 {
+			t.Errorf("%s: defaults warn %q", scope, warnings)
+		}
+		seen := map[string]string{}
+		for _, b := range bindings {
+			if other, dup := seen[b.Default]; dup {
+				t.Errorf("%s: %s and %s share %s", scope, b.Action, other, b.Default)
+			}
+			seen[b.Default] = b.Action
+			if _, ok := KeyMsg(b.Default); !ok {
+				t.Errorf("%s.%s: default %q is not a key name", scope, b.Action, b.Default)
+			}
+		}
+	}
+	if got := Check(getter(nil)); !reflect.DeepEqual(got, []string(nil)) {
+		t.Errorf("Check() = %q, want none", got)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	for in, want := range map[string]string{"ctrl+r": "Ctrl+R", "alt+ctrl+r": "Alt+Ctrl+R", "f5": "F5", "pgdown": "PgDn"} {
+		if got := Label(in); got != want {
+			t.Errorf("Label(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package procs
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/phravins/devcli/internal/config"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// EncodingKey names the encoding of captured program output ("auto",
+// "utf-8", "cp1252", "shift_jis", ...). Entries under LanguageEncodingsKey
+// override it for one language, e.g. exec.output_encodings.python.
+const (
+	EncodingKey          = "exec.output_encoding"
+	LanguageEncodingsKey = "exec.output_encodings"
+)
+
+// codePages covers the console code pages htmlindex does not know by
+// their Windows number
+var codePages = map[string]encoding.Encoding{
+	"437": charmap.CodePage437,
+	"850": charmap.CodePage850,
+	"852": charmap.CodePage852,
+	"866": charmap.CodePage866,
+}
+
+// LookupEncoding resolves an encoding name or Windows code page ("cp932",
+// "932", "windows-1252"). Nil means UTF-8.
+func LookupEncoding(name string) (encoding.Encoding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "", "auto", "utf-8", "utf8":
+		return nil, nil
+	}
+	cp := strings.TrimPrefix(name, "cp")
+	if e, ok := codePages[cp]; ok {
+		return e, nil
+	}
+	switch cp {
+	case "65001":
+		return nil, nil
+	case "932":
+		name = "shift_jis"
+	case "936":
+		name = "gbk"
+	case "949":
+		name = "euc-kr"
+	case "950":
+		name = "big5"
+	default:
+		if strings.HasPrefix(cp, "125") && len(cp) == 4 {
+			name = "windows-" + cp
+		}
+	}
+	e, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown output encoding %q", name)
+	}
+	return e, nil
+}
+
+// outputEncoding is the configured encoding for language, falling back to
+// the global setting and then "auto"
+func outputEncoding(language string) string {
+	if language != "" {
+		if name := config.GetString(LanguageEncodingsKey + "." + strings.ToLower(language)); name != "" {
+			return name
+		}
+	}
+	if name := config.GetString(EncodingKey); name != "" {
+		return name
+	}
+	return "auto"
+}
+
+// DecodeOutput turns captured program output into valid UTF-8. With "auto",
+// valid UTF-8 is kept as is and anything else is read in the system code
+// page (Windows) or has its invalid bytes replaced with U+FFFD.
+func DecodeOutput(data []byte, language string) string {
+	return decodeWith(data, outputEncoding(language))
+}
+
+func decodeWith(data []byte, name string) string {
+	auto := strings.EqualFold(strings.TrimSpace(name), "auto")
+	if auto {
+		if utf8.Valid(data) {
+			return string(data)
+		}
+		name = systemCodePage()
+	}
+	enc, err := LookupEncoding(name)
+	if err == nil && enc != nil {
+		if out, err := enc.NewDecoder().Bytes(data); err == nil {
+			return strings.ToValidUTF8(string(out), "\uFFFD")
+		}
+	}
+	return strings.ToValidUTF8(string(data), "\uFFFD")
+}
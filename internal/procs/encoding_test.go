@@ -0,0 +1,62 @@
+package procs
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// cp1252Sample is `café “quoted” costs €5` as a cp1252 console writes it
+var cp1252Sample = []byte("caf\xe9 \x93quoted\x94 costs \x805")
+
+func TestDecodeWith_CP1252(t *testing.T) {
+	want := "café “quoted” costs €5"
+	for _, name := range []string{"cp1252", "1252", "windows-1252", "Windows-1252"} {
+		if got := decodeWith(cp1252Sample, name); got != want {
+			t.Errorf("decodeWith(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDecodeWith_UTF8(t *testing.T) {
+	if got := decodeWith([]byte("héllo ✓"), "auto"); got != "héllo ✓" {
+		t.Errorf("valid UTF-8 changed under auto: %q", got)
+	}
+	// Forced UTF-8 replaces invalid bytes rather than passing them through
+	if got := decodeWith(cp1252Sample, "utf-8"); got != "caf� �quoted� costs �5" {
+		t.Errorf("decodeWith(utf-8) = %q", got)
+	}
+}
+
+func TestDecodeWith_ShiftJIS(t *testing.T) {
+	sjis := []byte{0x82, 0xb1, 0x82, 0xf1, 0x82, 0xc9, 0x82, 0xbf, 0x82, 0xcd} // こんにちは
+	for _, name := range []string{"cp932", "shift_jis"} {
+		if got := decodeWith(sjis, name); got != "こんにちは" {
+			t.Errorf("decodeWith(%q) = %q", name, got)
+		}
+	}
+}
+
+func TestDecodeWith_UnknownEncoding(t *testing.T) {
+	if _, err := LookupEncoding("klingon"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+	// Output is still sanitized
+	if got := decodeWith([]byte("ok\xff"), "klingon"); got != "ok�" {
+		t.Errorf("decodeWith(unknown) = %q", got)
+	}
+}
+
+func TestDecodeOutput_LanguageOverride(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set(EncodingKey, "utf-8")
+	viper.Set(LanguageEncodingsKey+".python", "cp1252")
+
+	if got := DecodeOutput(cp1252Sample, "python"); got != "café “quoted” costs €5" {
+		t.Errorf("python override not applied: %q", got)
+	}
+	if got := DecodeOutput([]byte("caf\xe9"), "go"); got != "caf�" {
+		t.Errorf("global utf-8 not applied to go: %q", got)
+	}
+}
@@ -0,0 +1,313 @@
+// Package procs keeps a registry of the child processes DevCLI spawns
+// (dev servers, compiled programs, tasks) so they can be terminated if the
+// TUI exits uncleanly. The registry is mirrored to processes.json in the
+// config directory, which lets `devcli kill` clean up after a crash.
+package procs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+type Entry struct {
+	PID     int       `json:"pid"`
+	Owner   int       `json:"owner"` // PID of the DevCLI process that started it
+	Name    string    `json:"name"`
+	Started time.Time `json:"started"`
+	// StartID is the OS's record of when the process started. After a
+	// crash or reboot the PID may belong to an unrelated process; a
+	// different StartID tells them apart.
+	StartID string `json:"start_id,omitempty"`
+}
+
+// recorded reports whether the entry's process is still the one DevCLI
+// started. Entries without a StartID (older builds) are never trusted.
+func (e Entry) recorded() bool {
+	return e.StartID != "" && alive(e.PID) && processStartID(e.PID) == e.StartID
+}
+
+var (
+	mu      sync.Mutex
+	tracked = map[int]Entry{}
+)
+
+func registryPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "processes.json"), nil
+}
+
+// load reads the on-disk registry; a missing or corrupt file is empty
+func load() []Entry {
+	path, err := registryPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	return entries
+}
+
+func save(entries []Entry) error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		err := os.Remove(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename it over the registry, so another
+	// DevCLI instance never reads a half-written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".processes-*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// staleLock is how old a registry lock may be before it is taken to be
+// left over from a DevCLI instance that crashed while holding it
+const staleLock = 5 * time.Second
+
+// lockRegistry guards a read-modify-write of processes.json against other
+// DevCLI instances with an O_EXCL lock file, returning its release. If the
+// lock cannot be had in time the update goes ahead unlocked rather than
+// being lost.
+func lockRegistry() (unlock func()) {
+	path, err := registryPath()
+	if err != nil {
+		return func() {}
+	}
+	lock := path + ".lock"
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }
+		}
+		if !os.IsExist(err) || time.Now().After(deadline) {
+			return func() {}
+		}
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(lock)
+		}
+	}
+}
+
+// update rewrites the on-disk registry, dropping pid and adding add (if set)
+func update(pid int, add *Entry) {
+	defer lockRegistry()()
+
+	var entries []Entry
+	for _, e := range load() {
+		if e.PID != pid {
+			entries = append(entries, e)
+		}
+	}
+	if add != nil {
+		entries = append(entries, *add)
+	}
+	save(entries)
+}
+
+// Start starts cmd in its own process group and registers it. For commands
+// made with exec.CommandContext, cancelling the context kills the group.
+func Start(cmd *exec.Cmd, name string) error {
+	setProcessGroup(cmd)
+	if cmd.Cancel != nil {
+		cmd.Cancel = func() error { return killTree(cmd.Process.Pid) }
+		if cmd.WaitDelay == 0 {
+			cmd.WaitDelay = time.Second // Don't wait on pipes a killed grandchild held open
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	Register(cmd, name)
+	return nil
+}
+
+// Run is Start followed by Wait, deregistering the process when it exits
+func Run(cmd *exec.Cmd, name string) error {
+	if err := Start(cmd, name); err != nil {
+		return err
+	}
+	defer Unregister(cmd)
+	return cmd.Wait()
+}
+
+// CombinedOutput is the registered equivalent of cmd.CombinedOutput
+func CombinedOutput(cmd *exec.Cmd, name string) ([]byte, error) {
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := Run(cmd, name)
+	return out.Bytes(), err
+}
+
+// Register records a started process. Call Unregister once it has exited.
+func Register(cmd *exec.Cmd, name string) {
+	if cmd.Process == nil {
+		return
+	}
+	pid := cmd.Process.Pid
+	entry := Entry{PID: pid, Owner: os.Getpid(), Name: name, Started: time.Now(), StartID: processStartID(pid)}
+
+	mu.Lock()
+	defer mu.Unlock()
+	tracked[entry.PID] = entry
+	update(entry.PID, &entry)
+}
+
+func Unregister(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	pid := cmd.Process.Pid
+
+	mu.Lock()
+	defer mu.Unlock()
+	delete(tracked, pid)
+	update(pid, nil)
+}
+
+// Kill terminates cmd together with any processes it spawned and
+// removes it from the registry
+func Kill(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	defer Unregister(cmd)
+	return killTree(cmd.Process.Pid)
+}
+
+// KillAll terminates every process this DevCLI instance started. It is
+// meant as an exit hook and returns how many processes were signalled.
+func KillAll() int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	killed := 0
+	for pid := range tracked {
+		if alive(pid) && killTree(pid) == nil {
+			killed++
+		}
+		delete(tracked, pid)
+		update(pid, nil)
+	}
+	return killed
+}
+
+// Running returns the processes this DevCLI instance started that are
+// still alive
+func Running() []Entry {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var running []Entry
+	for pid, e := range tracked {
+		if alive(pid) {
+			running = append(running, e)
+		}
+	}
+	return running
+}
+
+// StopAll asks every process this instance started to exit, giving dev
+// servers a chance to shut down cleanly, and kills whatever is left after
+// grace. It returns how many processes were running.
+func StopAll(grace time.Duration) int {
+	running := Running()
+	for _, e := range running {
+		terminateTree(e.PID)
+	}
+	for deadline := time.Now().Add(grace); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if len(Running()) == 0 {
+			break
+		}
+	}
+	KillAll()
+	return len(running)
+}
+
+// List returns the recorded processes that are still running
+func List() []Entry {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var running []Entry
+	for _, e := range load() {
+		if e.recorded() {
+			running = append(running, e)
+		}
+	}
+	return running
+}
+
+// KillRecorded terminates every process in the on-disk registry, including
+// those left behind by DevCLI instances that crashed, and clears it. Entries
+// whose PID now belongs to another process are dropped without a signal.
+func KillRecorded() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	defer lockRegistry()()
+
+	var killed, remaining []Entry
+	var firstErr error
+	for _, e := range load() {
+		if !e.recorded() {
+			continue
+		}
+		if err := killTree(e.PID); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			remaining = append(remaining, e) // Keep it so a retry can find it
+			continue
+		}
+		killed = append(killed, e)
+		delete(tracked, e.PID)
+	}
+	if err := save(remaining); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return killed, firstErr
+}
@@ -0,0 +1,75 @@
+package procs
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestProcessStartID_Stable(t *testing.T) {
+	id := processStartID(os.Getpid())
+	if id == "" {
+		t.Skip("process start time not available here")
+	}
+	if again := processStartID(os.Getpid()); again != id {
+		t.Errorf("start ID changed between calls: %q then %q", id, again)
+	}
+}
+
+func TestEntryRecorded_ReusedPID(t *testing.T) {
+	self := os.Getpid()
+	id := processStartID(self)
+	if id == "" {
+		t.Skip("process start time not available here")
+	}
+	if !(Entry{PID: self, StartID: id}).recorded() {
+		t.Error("entry with matching start ID not recognized")
+	}
+	if (Entry{PID: self, StartID: id + "x"}).recorded() {
+		t.Error("entry with a different start ID treated as ours")
+	}
+	if (Entry{PID: self}).recorded() {
+		t.Error("entry without a start ID treated as ours")
+	}
+}
+
+func TestLockRegistry_Excludes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	unlock := lockRegistry()
+	acquired := make(chan struct{})
+	go func() {
+		lockRegistry()()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second lock acquired while the first was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+}
+
+func TestAlive_Zombie(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no zombies on Windows")
+	}
+	cmd := exec.Command("sh", "-c", "exit 0")
+	if err := cmd.Start(); err != nil {
+		t.Skip("sh not available")
+	}
+	defer cmd.Wait()
+	for deadline := time.Now().Add(2 * time.Second); alive(cmd.Process.Pid); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("exited child not yet reaped still reported alive")
+		}
+	}
+}
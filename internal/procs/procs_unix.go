@@ -0,0 +1,83 @@
+//go:build !windows
+
+package procs
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// setProcessGroup puts the child in its own process group, so killing the
+// group also takes down anything it spawned (e.g. node under npm).
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killTree kills the whole process group, falling back to the process
+// itself when it is not a group leader (e.g. started by an older build)
+func killTree(pid int) error {
+	if err := syscall.Kill(-pid, syscall.SIGKILL); err == nil {
+		return nil
+	}
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// terminateTree asks the process group to exit with SIGTERM
+func terminateTree(pid int) error {
+	if err := syscall.Kill(-pid, syscall.SIGTERM); err == nil {
+		return nil
+	}
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+// alive reports whether pid is running. An exited child that was never
+// waited for (a zombie) still accepts signals, so it is ruled out too.
+func alive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil && err != syscall.EPERM {
+		return false
+	}
+	return !zombie(pid)
+}
+
+// zombie reads the process state from /proc on Linux and from ps elsewhere
+func zombie(pid int) bool {
+	if stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat"); err == nil {
+		fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+		return len(fields) > 0 && fields[0] == "Z"
+	}
+	out, err := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	return err == nil && strings.HasPrefix(strings.TrimSpace(string(out)), "Z")
+}
+
+// processStartID identifies when pid started: the boot ID plus the start
+// tick from /proc on Linux, and the start time from ps elsewhere. It is
+// empty if the process cannot be inspected.
+func processStartID(pid int) string {
+	if stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat"); err == nil {
+		// The command name may contain spaces; fields resume after ')'
+		fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+		if len(fields) < 20 {
+			return ""
+		}
+		boot, _ := os.ReadFile("/proc/sys/kernel/random/boot_id")
+		return strings.TrimSpace(string(boot)) + "/" + fields[19]
+	}
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// systemCodePage is the encoding non-UTF-8 output is read in under "auto".
+// Unix locales are UTF-8 in practice, so invalid bytes are just replaced.
+func systemCodePage() string {
+	return "utf-8"
+}
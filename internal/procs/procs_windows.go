@@ -0,0 +1,79 @@
+//go:build windows
+
+package procs
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+var procGetACP = syscall.NewLazyDLL("kernel32.dll").NewProc("GetACP")
+
+// processQueryLimitedInformation is PROCESS_QUERY_LIMITED_INFORMATION,
+// which the syscall package does not define
+const processQueryLimitedInformation = 0x1000
+
+// setProcessGroup starts the child in a new process group so console
+// Ctrl+C events aimed at DevCLI do not reach it directly
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// killTree terminates the process and all of its descendants
+func killTree(pid int) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// terminateTree asks the process and its descendants to close. Console
+// programs usually ignore this; StopAll kills them after the grace period.
+func terminateTree(pid int) error {
+	return exec.Command("taskkill", "/T", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// stillActive is the exit code GetExitCodeProcess reports for a process
+// that has not exited (STILL_ACTIVE)
+const stillActive = 259
+
+// alive reports whether pid is running. A handle to an exited process can
+// still be opened while its parent holds one, so the exit code is checked.
+func alive(pid int) bool {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// processStartID identifies when pid started by its creation time. It is
+// empty if the process cannot be opened.
+func processStartID(pid int) string {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return ""
+	}
+	defer syscall.CloseHandle(h)
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return ""
+	}
+	return strconv.FormatInt(creation.Nanoseconds(), 10)
+}
+
+// systemCodePage is the ANSI code page (e.g. cp1252, cp932), which is what
+// most programs write when their output is a pipe rather than a console
+func systemCodePage() string {
+	if err := procGetACP.Find(); err != nil {
+		return "utf-8"
+	}
+	acp, _, _ := procGetACP.Call()
+	return "cp" + strconv.Itoa(int(acp))
+}
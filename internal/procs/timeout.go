@@ -0,0 +1,86 @@
+package procs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// TimeoutKey is the config key that bounds one-shot commands (code runs,
+// compiles, tasks, installs). Dev servers run until stopped and are exempt.
+const TimeoutKey = "exec.default_timeout"
+
+// timeoutOverride is set from --timeout and wins over the config
+var timeoutOverride *time.Duration
+
+// ParseTimeout accepts a Go duration ("90s", "5m") or plain seconds ("30").
+// Empty and "0" mean no timeout.
+func ParseTimeout(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		secs, serr := strconv.ParseFloat(s, 64)
+		if serr != nil {
+			return 0, fmt.Errorf("invalid timeout %q: use a duration like 90s or 5m", s)
+		}
+		d = time.Duration(secs * float64(time.Second))
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid timeout %q: must not be negative", s)
+	}
+	return d, nil
+}
+
+// SetTimeout overrides exec.default_timeout for the rest of the process
+func SetTimeout(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	timeoutOverride = &d
+}
+
+// Timeout is the limit for one-shot commands, 0 meaning none. An invalid
+// config value is treated as unset.
+func Timeout() time.Duration {
+	mu.Lock()
+	override := timeoutOverride
+	mu.Unlock()
+	if override != nil {
+		return *override
+	}
+	d, _ := ParseTimeout(config.GetString(TimeoutKey))
+	return d
+}
+
+// WithTimeout bounds ctx by d; with d <= 0 it is only cancelable. Commands
+// made with exec.CommandContext and started through Start are killed with
+// their whole process group when it expires.
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// TimeoutError replaces err with "timed out after d" if ctx hit its deadline
+func TimeoutError(ctx context.Context, err error, d time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", formatTimeout(d))
+	}
+	return err
+}
+
+// formatTimeout prints whole seconds as "90s" rather than "1m30s"
+func formatTimeout(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	}
+	return d.String()
+}
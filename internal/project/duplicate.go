@@ -0,0 +1,142 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/devserver"
+	"github.com/phravins/devcli/internal/history"
+)
+
+// DuplicateExcludeKey lists folder names left out when duplicating a
+// project. Unset, defaultDuplicateExclude applies.
+const DuplicateExcludeKey = "projects.duplicate_exclude"
+
+// defaultDuplicateExclude holds dependencies and VCS state that belong to
+// the original: they are rebuilt by the install command or git init
+var defaultDuplicateExclude = []string{".git", "node_modules", ".venv", "venv", "__pycache__"}
+
+// DuplicateExcludes returns the folder names skipped by DuplicateProject
+func DuplicateExcludes() []string {
+	if names := config.GetStringSlice(DuplicateExcludeKey); len(names) > 0 {
+		return names
+	}
+	return defaultDuplicateExclude
+}
+
+// DuplicateTarget resolves the destination for a duplicate of srcDir: a
+// bare name is placed next to the original, anything else is a path. It
+// fails when the destination already exists or lies inside srcDir.
+func (m *Manager) DuplicateTarget(srcDir, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("enter a name for the copy")
+	}
+	src, err := filepath.Abs(m.ExpandPath(srcDir))
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(filepath.Dir(src), name)
+	if strings.ContainsAny(name, `/\~$`) || filepath.IsAbs(name) {
+		if dest, err = filepath.Abs(m.ExpandPath(name)); err != nil {
+			return "", err
+		}
+	}
+	if dest == src {
+		return "", fmt.Errorf("the copy needs a different name")
+	}
+	if rel, err := filepath.Rel(src, dest); err == nil && !strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("the copy cannot go inside the original project")
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists", dest)
+	}
+	if _, err := m.ValidateParentDir(filepath.Dir(dest)); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// SuggestDuplicateName returns "<name>-copy", numbered if that is taken
+func SuggestDuplicateName(srcDir string) string {
+	base := filepath.Base(srcDir) + "-copy"
+	name := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(srcDir), name)); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// DuplicateProject copies srcDir to destDir (see DuplicateTarget) without
+// the DuplicateExcludes folders, optionally runs git init, and records the
+// copy in history. Copies outside the workspace are registered like
+// imported projects so the project list shows them.
+func (m *Manager) DuplicateProject(srcDir, destDir string, reinitGit bool) error {
+	srcDir = m.ExpandPath(srcDir)
+	skip := map[string]bool{}
+	for _, name := range DuplicateExcludes() {
+		skip[name] = true
+	}
+	err := copyTree(srcDir, destDir, func(rel string) bool {
+		return skip[filepath.Base(rel)]
+	})
+	if err != nil {
+		os.RemoveAll(destDir)
+		return err
+	}
+
+	if reinitGit {
+		initGit(destDir)
+	}
+
+	workspace, _ := filepath.Abs(m.Workspace)
+	if filepath.Dir(destDir) != workspace {
+		_, err := m.ImportProject(destDir)
+		return err
+	}
+	return history.Add(filepath.Base(destDir), destDir)
+}
+
+// installCommands maps dependency manifests to the command that installs
+// them
+var installCommands = []struct{ marker, cmd string }{
+	{"package.json", "npm install"},
+	{"requirements.txt", "pip install -r requirements.txt"},
+	{"go.mod", "go mod tidy"},
+	{"Cargo.toml", "cargo fetch"},
+	{"pom.xml", "mvn clean install"},
+	{"pubspec.yaml", "dart pub get"},
+}
+
+// InstallCommand guesses the command that installs dir's dependencies. For
+// fullstack layouts each part is installed in its own folder. Empty means
+// nothing was recognized.
+func InstallCommand(dir string) string {
+	for _, ic := range installCommands {
+		if _, err := os.Stat(filepath.Join(dir, ic.marker)); err == nil {
+			return ic.cmd
+		}
+	}
+
+	info := devserver.Detect(dir)
+	if info.Type != devserver.TypeFullstack {
+		return ""
+	}
+	var steps []string
+	for _, srv := range info.Servers {
+		rel, err := filepath.Rel(dir, srv.Dir)
+		if err != nil || rel == "." || strings.ContainsAny(rel, `/\`) {
+			continue
+		}
+		if cmd := InstallCommand(srv.Dir); cmd != "" {
+			// "cd .." rather than "cd -" so it works in cmd.exe too
+			steps = append(steps, "cd "+rel+" && "+cmd+" && cd ..")
+		}
+	}
+	return strings.Join(steps, " && ")
+}
@@ -0,0 +1,120 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/phravins/devcli/internal/history"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDuplicateProject_SkipsDependenciesAndRecordsHistory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("AppData", filepath.Join(home, "AppData"))
+
+	workspace := t.TempDir()
+	src := filepath.Join(workspace, "api")
+	writeFiles(t, src, map[string]string{
+		"package.json":            `{"name": "api"}`,
+		"src/index.js":            "console.log('hi')",
+		"node_modules/x/index.js": "module.exports = 1",
+		".git/HEAD":               "ref: refs/heads/main",
+		"run.sh":                  "#!/bin/sh\necho run",
+	})
+	os.Chmod(filepath.Join(src, "run.sh"), 0755)
+
+	m := NewManager(workspace)
+	dest, err := m.DuplicateTarget(src, "api-copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dest != filepath.Join(workspace, "api-copy") {
+		t.Fatalf("DuplicateTarget = %s, want a sibling of the original", dest)
+	}
+	if err := m.DuplicateProject(src, dest, false); err != nil {
+		t.Fatalf("DuplicateProject failed: %v", err)
+	}
+
+	for _, kept := range []string{"package.json", "src/index.js"} {
+		if _, err := os.Stat(filepath.Join(dest, kept)); err != nil {
+			t.Errorf("%s not copied: %v", kept, err)
+		}
+	}
+	for _, skipped := range []string{"node_modules", ".git"} {
+		if _, err := os.Stat(filepath.Join(dest, skipped)); !os.IsNotExist(err) {
+			t.Errorf("%s should have been skipped (err = %v)", skipped, err)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(filepath.Join(dest, "run.sh")); err != nil || info.Mode().Perm()&0100 == 0 {
+			t.Errorf("run.sh lost its executable bit (%v, %v)", info.Mode(), err)
+		}
+	}
+
+	entries, _ := history.Load()
+	if len(entries) == 0 || entries[0].Path != dest {
+		t.Errorf("history not recorded: %+v", entries)
+	}
+	if got := InstallCommand(dest); got != "npm install" {
+		t.Errorf("InstallCommand = %q, want npm install", got)
+	}
+}
+
+func TestDuplicateTarget_Collisions(t *testing.T) {
+	workspace := t.TempDir()
+	src := filepath.Join(workspace, "app")
+	writeFiles(t, src, map[string]string{"main.go": "package main"})
+	os.Mkdir(filepath.Join(workspace, "taken"), 0755)
+	m := NewManager(workspace)
+
+	cases := map[string]string{
+		"taken":                            "already exists",
+		"app":                              "different name",
+		filepath.Join(src, "nested"):       "inside the original",
+		"":                                 "enter a name",
+		filepath.Join(workspace, "x", "y"): "does not exist",
+	}
+	for name, want := range cases {
+		if _, err := m.DuplicateTarget(src, name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("DuplicateTarget(%q) error = %v, want it to mention %q", name, err, want)
+		}
+	}
+	if got := SuggestDuplicateName(src); got != "app-copy" {
+		t.Errorf("SuggestDuplicateName = %q", got)
+	}
+	os.Mkdir(filepath.Join(workspace, "app-copy"), 0755)
+	if got := SuggestDuplicateName(src); got != "app-copy-2" {
+		t.Errorf("SuggestDuplicateName with app-copy taken = %q", got)
+	}
+}
+
+func TestInstallCommand_Fullstack(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"backend/go.mod":          "module x",
+		"backend/main.go":         "package main",
+		"frontend/package.json":   `{"scripts": {"dev": "vite"}}`,
+		"frontend/vite.config.js": "export default {}",
+	})
+	want := "cd backend && go mod tidy && cd .. && cd frontend && npm install && cd .."
+	if got := InstallCommand(dir); got != want {
+		t.Errorf("InstallCommand = %q, want %q", got, want)
+	}
+}
@@ -87,8 +87,8 @@ func Generate(cfg ProjectConfig) (string, error) {
 		initGit(targetDir)
 	}
 
-	// 4. Create README
-	if cfg.AddReadme && found {
+	// 4. Create README, unless the template ships its own
+	if _, hasReadme := selectedTpl.Files["README.md"]; cfg.AddReadme && found && !hasReadme {
 		createReadme(targetDir, cfg, selectedTpl)
 	}
 
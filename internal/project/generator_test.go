@@ -0,0 +1,51 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phravins/devcli/internal/devserver"
+)
+
+func TestGenerate_FullstackDetectsAsFullstack(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shop")
+	installCmd, err := Generate(ProjectConfig{Name: "shop", Path: dir, Stack: "Fullstack (React + Go)", AddReadme: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, want := range []string{"go mod tidy", "npm install"} {
+		if !strings.Contains(installCmd, want) {
+			t.Errorf("install command %q does not run %q", installCmd, want)
+		}
+	}
+
+	info := devserver.Detect(dir)
+	if info.Type != devserver.TypeFullstack {
+		t.Fatalf("Detect = %s, want %s", info.Type, devserver.TypeFullstack)
+	}
+	if len(info.Servers) != 2 {
+		t.Fatalf("expected backend and frontend servers, got %+v", info.Servers)
+	}
+	backend, frontend := info.Servers[0], info.Servers[1]
+	if backend.Type != devserver.TypeGo || backend.Dir != filepath.Join(dir, "backend") {
+		t.Errorf("backend = %+v, want a Go server in backend/", backend)
+	}
+	if frontend.Type != devserver.TypeVite || frontend.Dir != filepath.Join(dir, "frontend") {
+		t.Errorf("frontend = %+v, want a Vite server in frontend/", frontend)
+	}
+
+	// The template's own README is kept rather than replaced by the generic one
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(readme), "frontend/") {
+		t.Errorf("README was overwritten:\n%s", readme)
+	}
+	gomod, err := os.ReadFile(filepath.Join(dir, "backend", "go.mod"))
+	if err != nil || !strings.Contains(string(gomod), "module shop/backend") {
+		t.Errorf("backend/go.mod not rendered: %s (%v)", gomod, err)
+	}
+}
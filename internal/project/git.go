@@ -0,0 +1,212 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitStatus is a snapshot of a working tree from git status --porcelain
+type GitStatus struct {
+	Root   string            // Top-level directory of the repository
+	Branch string            // Current branch, or the short hash when detached
+	Files  map[string]string // Porcelain XY code by slash path relative to Root
+}
+
+// Dirty reports whether anything is modified, staged or untracked
+func (s GitStatus) Dirty() bool {
+	return len(s.Files) > 0
+}
+
+// Summary is a one-line description such as "main (clean)" or
+// "main (3 changed)"
+func (s GitStatus) Summary() string {
+	branch := s.Branch
+	if branch == "" {
+		branch = "no commits"
+	}
+	if !s.Dirty() {
+		return branch + " (clean)"
+	}
+	return fmt.Sprintf("%s (%d changed)", branch, len(s.Files))
+}
+
+// FileState describes path's status in the tree: "modified", "staged",
+// "untracked", "added", "deleted", "renamed", "conflict" or "clean".
+// ok is false when path is outside the repository.
+func (s GitStatus) FileState(path string) (state string, ok bool) {
+	rel, err := filepath.Rel(s.Root, resolvePath(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	code, found := s.Files[rel]
+	if !found {
+		// Untracked folders are listed once, with a trailing slash
+		for p, c := range s.Files {
+			if c == "??" && strings.HasSuffix(p, "/") && strings.HasPrefix(rel, p) {
+				code, found = c, true
+				break
+			}
+		}
+	}
+	if !found {
+		return "clean", true
+	}
+	return describeCode(code), true
+}
+
+func describeCode(code string) string {
+	if len(code) != 2 {
+		return "modified"
+	}
+	x, y := code[0], code[1]
+	switch {
+	case code == "??":
+		return "untracked"
+	case x == 'U' || y == 'U' || code == "AA" || code == "DD":
+		return "conflict"
+	case y == 'M' || y == 'T':
+		return "modified"
+	case y == 'D' || x == 'D':
+		return "deleted"
+	case x == 'R' || x == 'C':
+		return "renamed"
+	case x == 'A':
+		return "added"
+	default:
+		return "staged"
+	}
+}
+
+// gitTimeout keeps a slow network drive from stalling the project list
+const gitTimeout = 5 * time.Second
+
+type gitEntry struct {
+	status GitStatus
+	ok     bool
+}
+
+var (
+	gitMu      sync.Mutex
+	gitCache   = map[string]gitEntry{}
+	gitLoading = map[string]bool{}
+)
+
+// Git returns the status of the repository containing dir. ok is false
+// for folders outside version control or when git is not installed.
+// Results are cached per directory until RefreshGit is called.
+func Git(dir string) (GitStatus, bool) {
+	dir = resolvePath(dir)
+	gitMu.Lock()
+	e, cached := gitCache[dir]
+	gitMu.Unlock()
+	if cached {
+		return e.status, e.ok
+	}
+	status, ok := readGit(dir)
+	gitMu.Lock()
+	gitCache[dir] = gitEntry{status, ok}
+	gitMu.Unlock()
+	return status, ok
+}
+
+// CachedGit is Git without blocking: when dir has not been read yet it
+// starts reading in the background and returns ok=false, so a later
+// call (e.g. on the next render) has the answer
+func CachedGit(dir string) (GitStatus, bool) {
+	dir = resolvePath(dir)
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	if e, cached := gitCache[dir]; cached {
+		return e.status, e.ok
+	}
+	if !gitLoading[dir] {
+		gitLoading[dir] = true
+		go func() {
+			status, ok := readGit(dir)
+			gitMu.Lock()
+			gitCache[dir] = gitEntry{status, ok}
+			delete(gitLoading, dir)
+			gitMu.Unlock()
+		}()
+	}
+	return GitStatus{}, false
+}
+
+// RefreshGit drops the cached status for dir, or for every directory
+// when dir is empty, so the next Git call reads it again
+func RefreshGit(dir string) {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	if dir == "" {
+		gitCache = map[string]gitEntry{}
+		return
+	}
+	delete(gitCache, resolvePath(dir))
+}
+
+func readGit(dir string) (GitStatus, bool) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return GitStatus{}, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	git := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		return string(out), err
+	}
+
+	root, err := git("rev-parse", "--show-toplevel")
+	root = strings.TrimSpace(root)
+	if err != nil || root == "" {
+		return GitStatus{}, false
+	}
+	status := GitStatus{Root: resolvePath(filepath.FromSlash(root)), Files: map[string]string{}}
+
+	branch, err := git("rev-parse", "--abbrev-ref", "HEAD")
+	switch branch = strings.TrimSpace(branch); {
+	case err != nil:
+		// No commits yet: HEAD names a branch that does not exist
+		branch, _ = git("symbolic-ref", "--short", "HEAD")
+	case branch == "HEAD":
+		branch, _ = git("rev-parse", "--short", "HEAD") // Detached
+	}
+	status.Branch = strings.TrimSpace(branch)
+
+	out, err := git("status", "--porcelain", "-z")
+	if err != nil {
+		return GitStatus{}, false
+	}
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		e := entries[i]
+		if len(e) < 4 {
+			continue
+		}
+		code := e[:2]
+		status.Files[e[3:]] = code
+		if code[0] == 'R' || code[0] == 'C' {
+			i++ // The entry after a rename or copy is its source path
+		}
+	}
+	return status, true
+}
+
+// resolvePath makes path absolute with symlinks resolved, so it compares
+// equal to git's top-level directory
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	return path
+}
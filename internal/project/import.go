@@ -0,0 +1,83 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/devserver"
+	"github.com/phravins/devcli/internal/history"
+	"github.com/phravins/devcli/internal/venv"
+)
+
+// ImportedProjectsKey lists the folders registered with ImportProject
+const ImportedProjectsKey = "projects.imported"
+
+// ImportedProject describes what was found in an imported folder
+type ImportedProject struct {
+	Name string
+	Path string
+	Info devserver.ProjectInfo
+	Envs []venv.Environment
+}
+
+// ImportProject registers an existing folder as a DevCLI project: its stack
+// is detected, a history entry is recorded and the path is remembered so the
+// project list, dev server and venv wizard can find it. Nothing in the folder
+// is created or modified.
+func (m *Manager) ImportProject(dir string) (ImportedProject, error) {
+	p, err := m.InspectProject(dir)
+	if err != nil {
+		return p, err
+	}
+	return p, RegisterImport(p)
+}
+
+// InspectProject detects the stack and environments of an existing folder
+// without registering it. It walks the folder's environments to size them,
+// so callers with a UI should run it in the background.
+func (m *Manager) InspectProject(dir string) (ImportedProject, error) {
+	if dir == "" {
+		dir = "."
+	}
+	path, err := m.ValidateParentDir(dir)
+	if err != nil {
+		return ImportedProject{}, err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return ImportedProject{}, err
+	}
+
+	return ImportedProject{
+		Name: filepath.Base(path),
+		Path: path,
+		Info: devserver.Detect(path),
+		Envs: venv.ProjectEnvs(path),
+	}, nil
+}
+
+// RegisterImport remembers an inspected project and records it in history
+func RegisterImport(p ImportedProject) error {
+	paths := config.GetStringSlice(ImportedProjectsKey)
+	known := false
+	for _, existing := range paths {
+		known = known || filepath.Clean(existing) == p.Path
+	}
+	if !known {
+		if err := config.SaveConfig(ImportedProjectsKey, append(paths, p.Path)); err != nil {
+			return err
+		}
+	}
+	return history.Add(p.Name, p.Path)
+}
+
+// ImportedPaths returns the imported project folders that still exist
+func ImportedPaths() []string {
+	var paths []string
+	for _, p := range config.GetStringSlice(ImportedProjectsKey) {
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
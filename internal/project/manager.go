@@ -101,6 +101,9 @@ func (m *Manager) SuggestProjectName(templateName string) string {
 	if strings.Contains(base, "c++") || strings.Contains(base, "cpp") {
 		base = "cpp-project"
 	}
+	if strings.Contains(base, "fullstack") {
+		base = "fullstack-project"
+	}
 
 	name := base
 	counter := 1
@@ -121,6 +124,12 @@ func (m *Manager) BackupProject(srcDir, destPath string) error {
 	destPath = m.ExpandPath(destPath)
 
 	// 2. Walk and copy
+	return copyTree(srcDir, destPath, nil)
+}
+
+// copyTree copies srcDir to destPath. Directories for which skip returns
+// true (given their path relative to srcDir) are left out.
+func copyTree(srcDir, destPath string, skip func(rel string) bool) error {
 	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -139,21 +148,25 @@ func (m *Manager) BackupProject(srcDir, destPath string) error {
 		targetPath := filepath.Join(destPath, relPath)
 
 		if info.IsDir() {
+			if skip != nil && skip(relPath) {
+				return filepath.SkipDir
+			}
 			return os.MkdirAll(targetPath, info.Mode())
 		}
 
-		return copyFile(path, targetPath)
+		return copyFile(path, targetPath, info.Mode().Perm())
 	})
 }
 
-func copyFile(src, dst string) error {
+func copyFile(src, dst string, perm os.FileMode) error {
 	in, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer in.Close()
 
-	out, err := os.Create(dst)
+	// Keep the mode so scripts like gradlew stay executable
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
 	if err != nil {
 		return err
 	}
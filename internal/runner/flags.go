@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Characters that only make sense to a shell. Commands are run without a
+// shell, so these would be passed literally and almost certainly indicate
+// a mistake (or an attempt to chain commands).
+const shellMetaChars = ";|&`$<>(){}\n\r"
+
+// SplitFlags splits a flag string on whitespace, honouring simple
+// single/double quotes so values like -DNAME="a b" stay together.
+func SplitFlags(s string) []string {
+	var args []string
+	var cur strings.Builder
+	var quote rune
+	inArg := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args
+}
+
+// ValidateFlags checks a compiler flag list configured under key
+func ValidateFlags(key, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if strings.ContainsAny(value, shellMetaChars) {
+		return fmt.Errorf("%s must not contain shell characters (%s)", key, strings.TrimSpace(shellMetaChars))
+	}
+	if strings.Count(value, `"`)%2 != 0 || strings.Count(value, "'")%2 != 0 {
+		return fmt.Errorf("%s has an unterminated quote", key)
+	}
+
+	args := SplitFlags(value)
+	if !strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("%s: %q is not a flag (flags must start with '-')", key, args[0])
+	}
+	for _, arg := range args {
+		if arg == "-o" || strings.HasPrefix(arg, "--out") {
+			return fmt.Errorf("%s: the output file is managed by DevCLI, remove %q", key, arg)
+		}
+	}
+	return nil
+}
+
+// Flags returns the validated, split flags configured under key
+func Flags(key string) ([]string, error) {
+	value := strings.TrimSpace(config.GetString(key))
+	if err := ValidateFlags(key, value); err != nil {
+		return nil, err
+	}
+	return SplitFlags(value), nil
+}
@@ -0,0 +1,256 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	userHome, _ := os.UserHomeDir()
+	mingw := func(exe string) []string {
+		return []string{
+			`C:\Program Files\CodeBlocks\MinGW\bin\` + exe,
+			`C:\Program Files (x86)\CodeBlocks\MinGW\bin\` + exe,
+			`C:\MinGW\bin\` + exe,
+			`C:\TDM-GCC-64\bin\` + exe,
+		}
+	}
+	jdk := func(exe string) []string {
+		return []string{
+			`C:\Program Files\Java\jdk*\bin\` + exe,
+			`C:\Program Files\Eclipse Adoptium\jdk*\bin\` + exe,
+		}
+	}
+	python := []string{
+		`C:\Python*\python.exe`,
+		`C:\Program Files\Python*\python.exe`,
+	}
+	// compileExe builds Exe from the source with the compiler named tool
+	compileExe := func(tool string) func(b Build) []string {
+		return func(b Build) []string {
+			return append([]string{b.Tool(tool), b.Source, "-o", b.Exe}, b.Flags...)
+		}
+	}
+	runExe := func(b Build) []string { return []string{b.Exe} }
+
+	Register(&Runner{
+		Name:        "python",
+		Label:       "Py (Python)",
+		Aliases:     []string{"py", "python3"},
+		Extensions:  []string{".py"},
+		Boilerplate: "print(\"Hello from Python!\")\n",
+		Tools:       [][]string{{"python", "python3"}},
+		Fallbacks:   map[string][]string{"python": python, "python3": python},
+		BinKey:      "runner.python_bin",
+		SourceFile:  func(string) string { return "script.py" },
+		Run: func(b Build) []string {
+			return []string{b.Tool("python"), "-u", b.SourcePath()}
+		},
+		// -i keeps the prompts without a terminal, -u streams the output
+		Repl: func(b Build) []string {
+			return []string{b.Tool("python"), "-i", "-u"}
+		},
+	})
+
+	Register(&Runner{
+		Name:        "java",
+		Label:       "Java",
+		Extensions:  []string{".java"},
+		Boilerplate: "public class Main {\n    public static void main(String[] args) {\n        System.out.println(\"Hello from Java!\");\n    }\n}\n",
+		Tools:       [][]string{{"javac"}, {"java"}},
+		Fallbacks:   map[string][]string{"javac": jdk("javac.exe"), "java": jdk("java.exe")},
+		FlagsKey:    "runner.java_flags",
+		// The file must be named after the public class
+		SourceFile: func(code string) string { return JavaClassName(code) + ".java" },
+		Compile: func(b Build) []string {
+			return append(append([]string{b.Tool("javac")}, b.Flags...), "-d", ".", b.Source)
+		},
+		Run: func(b Build) []string {
+			return []string{b.Tool("java"), "-cp", ".", strings.TrimSuffix(b.Source, ".java")}
+		},
+	})
+
+	Register(&Runner{
+		Name:        "cpp",
+		Label:       "C++",
+		Aliases:     []string{"c++"},
+		Extensions:  []string{".cpp", ".cxx", ".cc"},
+		Boilerplate: "#include <iostream>\n\nint main() {\n    std::cout << \"Hello from C++!\" << std::endl;\n    return 0;\n}\n",
+		Tools:       [][]string{{"g++"}},
+		Fallbacks:   map[string][]string{"g++": mingw("g++.exe")},
+		FlagsKey:    "runner.cpp_flags",
+		Compile:     compileExe("g++"),
+		Run:         runExe,
+	})
+
+	Register(&Runner{
+		Name:        "c",
+		Label:       "C",
+		Extensions:  []string{".c", ".h"},
+		Boilerplate: "#include <stdio.h>\n\nint main() {\n    printf(\"Hello from C!\\n\");\n    return 0;\n}\n",
+		Tools:       [][]string{{"gcc"}},
+		Fallbacks:   map[string][]string{"gcc": mingw("gcc.exe")},
+		FlagsKey:    "runner.c_flags",
+		Compile:     compileExe("gcc"),
+		Run:         runExe,
+	})
+
+	Register(&Runner{
+		Name:        "csharp",
+		Label:       "C#",
+		Aliases:     []string{"c#", "cs"},
+		Extensions:  []string{".cs"},
+		Boilerplate: "using System;\n\nclass Program {\n    static void Main() {\n        Console.WriteLine(\"Hello from C#!\");\n    }\n}\n",
+		Tools:       [][]string{{"dotnet"}},
+		SourceFile:  func(string) string { return "Program.cs" },
+		// Without a project C# needs a console template; Program.cs is
+		// replaced by the buffer afterwards
+		Setup: func(b Build) []string {
+			return []string{b.Tool("dotnet"), "new", "console", "-o", b.Dir, "--force"}
+		},
+		Run: func(b Build) []string {
+			return []string{b.Tool("dotnet"), "run", "--project", b.Dir}
+		},
+	})
+
+	Register(&Runner{
+		Name:        "rust",
+		Label:       "Rust",
+		Aliases:     []string{"rs"},
+		Extensions:  []string{".rs"},
+		Boilerplate: "fn main() {\n    println!(\"Hello from Rust!\");\n}\n",
+		Tools:       [][]string{{"rustc"}},
+		Fallbacks:   map[string][]string{"rustc": {filepath.Join(userHome, `.cargo\bin\rustc.exe`)}},
+		FlagsKey:    "runner.rust_flags",
+		Compile:     compileExe("rustc"),
+		Run:         runExe,
+	})
+
+	Register(&Runner{
+		Name:        "zig",
+		Label:       "Zig",
+		Extensions:  []string{".zig"},
+		Boilerplate: "const std = @import(\"std\");\n\npub fn main() !void {\n    std.debug.print(\"Hello from Zig!\\n\", .{});\n}\n",
+		Tools:       [][]string{{"zig"}},
+		Fallbacks: map[string][]string{"zig": {
+			`C:\Program Files\Zig*\zig.exe`,
+			`C:\zig*\zig.exe`,
+		}},
+		Run: func(b Build) []string {
+			return []string{b.Tool("zig"), "run", b.SourcePath()}
+		},
+	})
+
+	Register(&Runner{
+		Name:        "php",
+		Label:       "PHP",
+		Extensions:  []string{".php"},
+		Boilerplate: "<?php\n\necho \"Hello from PHP!\\n\";\n",
+		Tools:       [][]string{{"php"}},
+		Fallbacks: map[string][]string{"php": {
+			`C:\php*\php.exe`,
+			`C:\tools\php*\php.exe`,
+			`C:\xampp\php\php.exe`,
+		}},
+		Run: func(b Build) []string {
+			return []string{b.Tool("php"), b.SourcePath()}
+		},
+	})
+
+	Register(&Runner{
+		Name:        "swift",
+		Label:       "Swift",
+		Extensions:  []string{".swift"},
+		Boilerplate: "print(\"Hello from Swift!\")\n",
+		Tools:       [][]string{{"swiftc"}},
+		Fallbacks: map[string][]string{"swiftc": {
+			`C:\Library\Developer\Toolchains\*\usr\bin\swiftc.exe`,
+			filepath.Join(userHome, `AppData\Local\Programs\Swift\Toolchains\*\usr\bin\swiftc.exe`),
+			"/usr/share/swift/usr/bin/swiftc",
+			"/opt/swift*/usr/bin/swiftc",
+		}},
+		// Compiled rather than run with "swift main.swift", so build errors
+		// are reported as such
+		Compile: compileExe("swiftc"),
+		Run:     runExe,
+	})
+
+	node := []string{
+		`C:\Program Files\nodejs\node.exe`,
+		`C:\Program Files (x86)\nodejs\node.exe`,
+	}
+	Register(&Runner{
+		Name:        "javascript",
+		Label:       "JS (Node.js)",
+		Aliases:     []string{"js", "node"},
+		Extensions:  []string{".js"},
+		Boilerplate: "console.log(\"Hello from JavaScript!\");\n",
+		Tools:       [][]string{{"node"}},
+		Fallbacks:   map[string][]string{"node": node},
+		Run: func(b Build) []string {
+			return []string{b.Tool("node"), b.SourcePath()}
+		},
+		Repl: func(b Build) []string {
+			return []string{b.Tool("node"), "-i"}
+		},
+	})
+
+	// ts-node or tsx, installed globally with npm; both take the file
+	// name and nothing else
+	npmBin := func(exe string) []string {
+		return []string{filepath.Join(userHome, `AppData\Roaming\npm\`+exe)}
+	}
+	Register(&Runner{
+		Name:        "typescript",
+		Label:       "TS (TypeScript)",
+		Aliases:     []string{"ts", "ts-node"},
+		Extensions:  []string{".ts"},
+		Boilerplate: "const greeting: string = \"Hello from TypeScript!\";\nconsole.log(greeting);\n",
+		Tools:       [][]string{{"ts-node", "tsx"}},
+		Fallbacks:   map[string][]string{"ts-node": npmBin("ts-node.cmd"), "tsx": npmBin("tsx.cmd")},
+		Run: func(b Build) []string {
+			return []string{b.Tool("ts-node"), b.SourcePath()}
+		},
+	})
+
+	// "go run" compiles and runs; main.go needs no module around it
+	Register(&Runner{
+		Name:        "go",
+		Label:       "Go",
+		Aliases:     []string{"golang"},
+		Extensions:  []string{".go"},
+		Boilerplate: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello from Go!\")\n}\n",
+		Tools:       [][]string{{"go"}},
+		Fallbacks: map[string][]string{"go": {
+			`C:\Program Files\Go\bin\go.exe`,
+			`C:\Go\bin\go.exe`,
+			"/usr/local/go/bin/go",
+		}},
+		Run: func(b Build) []string {
+			return []string{b.Tool("go"), "run", b.SourcePath()}
+		},
+	})
+}
+
+// JavaClassName returns the name of the last class declared in code, which
+// the source file must be named after, or "Main"
+func JavaClassName(code string) string {
+	className := "Main"
+	for _, line := range strings.Split(code, "\n") {
+		if strings.Contains(line, "class ") {
+			parts := strings.Fields(line)
+			for i, p := range parts {
+				if p == "class" && i+1 < len(parts) {
+					// Strip braces if present
+					name := strings.Trim(parts[i+1], "{")
+					if name != "" {
+						className = name
+						break
+					}
+				}
+			}
+		}
+	}
+	return className
+}
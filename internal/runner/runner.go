@@ -0,0 +1,218 @@
+// Package runner knows how to build and run a single source file of each
+// language the editor, web compiler and external terminal runs support.
+// Adding a language is one Register call (see languages.go).
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Runner describes one language
+type Runner struct {
+	Name        string   // Key used by the editor and web runner, e.g. "python"
+	Label       string   // Entry in the editor's language menu; "" keeps it out
+	Aliases     []string // Other names Lookup accepts, e.g. "py"
+	Extensions  []string // File extensions, the first one is used for the source file
+	Boilerplate string   // Starting code for a new buffer
+
+	// Tools lists the executables the language needs. Each entry is a set of
+	// alternatives; the first one found satisfies it and Build.Tool knows it
+	// by the first name.
+	Tools [][]string
+	// Fallbacks lists install locations (globs) checked for a tool that is
+	// not on PATH
+	Fallbacks map[string][]string
+	// BinKey names a config key whose value replaces the first tool, e.g.
+	// runner.python_bin
+	BinKey string
+	// FlagsKey names the config key holding extra compiler flags
+	FlagsKey string
+
+	// SourceFile names the file the code is written to; nil means "main"
+	// plus the first extension
+	SourceFile func(code string) string
+	// Setup, Compile and Run build the commands for each stage. Setup runs
+	// before the source is written (e.g. to create a project around it);
+	// Setup and Compile are nil when the language does not need them.
+	Setup   func(b Build) []string
+	Compile func(b Build) []string
+	Run     func(b Build) []string
+	// Repl starts an interactive interpreter that reads code from stdin;
+	// nil when the language has none
+	Repl func(b Build) []string
+}
+
+// Build is what the command builders work with
+type Build struct {
+	Dir    string            // Temp folder everything runs in
+	Source string            // Source file name inside Dir
+	Exe    string            // Path a compiler should write the program to
+	Tools  map[string]string // Resolved executables by tool name
+	Flags  []string          // Extra compiler flags from FlagsKey
+}
+
+// Tool returns the resolved path of tool, or its bare name
+func (b Build) Tool(name string) string {
+	if path := b.Tools[name]; path != "" {
+		return path
+	}
+	return name
+}
+
+// SourcePath is the absolute path of the source file
+func (b Build) SourcePath() string {
+	return filepath.Join(b.Dir, b.Source)
+}
+
+// MissingToolError reports a tool that could not be found
+type MissingToolError struct {
+	Tool string
+}
+
+func (e *MissingToolError) Error() string {
+	return fmt.Sprintf("%s not found (install it or set compilers.%s)", e.Tool, e.Tool)
+}
+
+var (
+	registry = map[string]*Runner{}
+	order    []*Runner // Registration order, used for menus
+)
+
+// Register adds a language, replacing one registered under the same name
+func Register(r *Runner) {
+	if old, ok := registry[r.Name]; ok {
+		for i, o := range order {
+			if o == old {
+				order = append(order[:i], order[i+1:]...)
+				break
+			}
+		}
+	}
+	registry[r.Name] = r
+	order = append(order, r)
+}
+
+// Lookup finds a runner by name or alias, ignoring case
+func Lookup(name string) (*Runner, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if r, ok := registry[name]; ok {
+		return r, true
+	}
+	for _, r := range order {
+		for _, a := range r.Aliases {
+			if a == name {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ForExtension finds the runner for a file extension such as ".py"
+func ForExtension(ext string) (*Runner, bool) {
+	ext = strings.ToLower(ext)
+	for _, r := range order {
+		for _, e := range r.Extensions {
+			if e == ext {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// All returns the runners in registration order
+func All() []*Runner {
+	return append([]*Runner{}, order...)
+}
+
+// Names returns the registered names, sorted
+func Names() []string {
+	names := make([]string, 0, len(order))
+	for _, r := range order {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SourceName is the file name the code is written to
+func (r *Runner) SourceName(code string) string {
+	if r.SourceFile != nil {
+		return r.SourceFile(code)
+	}
+	return "main" + r.Extensions[0]
+}
+
+// Resolve locates the tools with find, which gets a tool name and its
+// fallbacks and returns "" when the tool is missing. A tool that cannot be
+// found is reported as a *MissingToolError.
+func (r *Runner) Resolve(find func(tool string, fallbacks []string) string) (map[string]string, error) {
+	tools := map[string]string{}
+	groups := r.Tools
+	if r.BinKey != "" && len(groups) > 0 {
+		if bin := strings.TrimSpace(config.GetString(r.BinKey)); bin != "" {
+			path, err := exec.LookPath(bin)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %q is not an executable", r.BinKey, bin)
+			}
+			tools[groups[0][0]] = path
+			groups = groups[1:]
+		}
+	}
+	for _, alternatives := range groups {
+		for _, tool := range alternatives {
+			if path := find(tool, r.Fallbacks[tool]); path != "" {
+				tools[alternatives[0]] = path
+				break
+			}
+		}
+		if tools[alternatives[0]] == "" {
+			return nil, &MissingToolError{Tool: alternatives[0]}
+		}
+	}
+	return tools, nil
+}
+
+// ToolNames lists the tools the language needs, for status messages. It is
+// empty when BinKey names the only one.
+func (r *Runner) ToolNames() []string {
+	var names []string
+	for i, alternatives := range r.Tools {
+		if i == 0 && r.BinKey != "" && strings.TrimSpace(config.GetString(r.BinKey)) != "" {
+			continue
+		}
+		names = append(names, alternatives[0])
+	}
+	return names
+}
+
+// NewBuild reads the language's flags and lays out a build of code in dir
+// with the resolved tools
+func (r *Runner) NewBuild(code, dir string, tools map[string]string) (Build, error) {
+	b := Build{Dir: dir, Source: r.SourceName(code), Exe: filepath.Join(dir, "main"), Tools: tools}
+	if runtime.GOOS == "windows" {
+		b.Exe += ".exe"
+	}
+	if r.FlagsKey != "" {
+		flags, err := Flags(r.FlagsKey)
+		if err != nil {
+			return Build{}, err
+		}
+		b.Flags = flags
+	}
+	return b, nil
+}
+
+// WriteSource writes code to the build's source file
+func (b Build) WriteSource(code string) error {
+	return os.WriteFile(b.SourcePath(), []byte(code), 0644)
+}
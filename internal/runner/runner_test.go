@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]string{"py": "python", "Python": "python", "c++": "cpp", "js": "javascript", "golang": "go", "php": "php", "SWIFT": "swift", "ts": "typescript"} {
+		r, ok := Lookup(name)
+		if !ok || r.Name != want {
+			t.Errorf("Lookup(%q) = %v, want %s", name, r, want)
+		}
+	}
+	if _, ok := Lookup("cobol"); ok {
+		t.Error("Lookup(cobol) found a runner")
+	}
+	for ext, want := range map[string]string{".CC": "cpp", ".h": "c", ".php": "php", ".swift": "swift", ".ts": "typescript"} {
+		if r, ok := ForExtension(ext); !ok || r.Name != want {
+			t.Errorf("ForExtension(%q) = %v, want %s", ext, r, want)
+		}
+	}
+}
+
+func TestBuildCommands(t *testing.T) {
+	dir := t.TempDir()
+	build := func(name, code string) (*Runner, Build) {
+		r, _ := Lookup(name)
+		b, err := r.NewBuild(code, dir, map[string]string{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r, b
+	}
+
+	r, b := build("java", "public class Hello {\n}\n")
+	if b.Source != "Hello.java" {
+		t.Errorf("java source %q, want Hello.java", b.Source)
+	}
+	if got := r.Run(b); !reflect.DeepEqual(got, []string{"java", "-cp", ".", "Hello"}) {
+		t.Errorf("java run %q", got)
+	}
+
+	config.Set("runner.cpp_flags", `-O2 -DNAME="a b"`)
+	defer config.Set("runner.cpp_flags", "")
+	r, b = build("cpp", "")
+	if got := r.Compile(b); !reflect.DeepEqual(got, []string{"g++", "main.cpp", "-o", b.Exe, "-O2", "-DNAME=a b"}) {
+		t.Errorf("cpp compile %q", got)
+	}
+
+	r, b = build("php", "")
+	if got := r.Run(b); !reflect.DeepEqual(got, []string{"php", filepath.Join(dir, "main.php")}) || r.Compile != nil {
+		t.Errorf("php run %q", got)
+	}
+
+	r, b = build("swift", "")
+	if got := r.Compile(b); !reflect.DeepEqual(got, []string{"swiftc", "main.swift", "-o", b.Exe}) {
+		t.Errorf("swift compile %q", got)
+	}
+
+	r, b = build("go", "")
+	if got := r.Run(b); !reflect.DeepEqual(got, []string{"go", "run", filepath.Join(dir, "main.go")}) || r.Compile != nil {
+		t.Errorf("go run %q", got)
+	}
+
+	// tsx stands in for ts-node under the same tool name
+	r, b = build("typescript", "")
+	b.Tools = map[string]string{"ts-node": "/usr/bin/tsx"}
+	if got := r.Run(b); !reflect.DeepEqual(got, []string{"/usr/bin/tsx", filepath.Join(dir, "main.ts")}) {
+		t.Errorf("typescript run %q", got)
+	}
+
+	r, b = build("js", "")
+	if got := r.Repl(b); !reflect.DeepEqual(got, []string{"node", "-i"}) {
+		t.Errorf("javascript repl %q", got)
+	}
+	if r, _ := Lookup("c"); r.Repl != nil {
+		t.Error("c has a repl")
+	}
+
+	config.Set("runner.c_flags", "-O2; rm -rf /")
+	defer config.Set("runner.c_flags", "")
+	r, _ = Lookup("c")
+	if _, err := r.NewBuild("", dir, nil); err == nil {
+		t.Error("flags with shell characters were accepted")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	r, _ := Lookup("python")
+	find := func(found ...string) func(string, []string) string {
+		return func(tool string, _ []string) string {
+			for _, f := range found {
+				if f == tool {
+					return "/bin/" + tool
+				}
+			}
+			return ""
+		}
+	}
+
+	tools, err := r.Resolve(find("python3"))
+	if err != nil || tools["python"] != "/bin/python3" {
+		t.Errorf("got %v, %v; want python3 as python", tools, err)
+	}
+	_, err = r.Resolve(find())
+	var missing *MissingToolError
+	if !errors.As(err, &missing) || missing.Tool != "python" {
+		t.Errorf("got %v, want python missing", err)
+	}
+
+	sh, lookErr := exec.LookPath("sh")
+	if lookErr != nil {
+		t.Skip("no sh to use as runner.python_bin")
+	}
+	config.Set("runner.python_bin", "sh")
+	defer config.Set("runner.python_bin", "")
+	if tools, err := r.Resolve(find()); err != nil || tools["python"] != sh {
+		t.Errorf("runner.python_bin: got %v, %v", tools, err)
+	}
+	if names := r.ToolNames(); len(names) != 0 {
+		t.Errorf("ToolNames with runner.python_bin = %v", names)
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	valid := []string{"", "-O2 -std=c++17", `-DNAME="a b"`}
+	invalid := []string{"-O2; ls", "-o out", "O2", `-D"x`, "--output=x"}
+	for _, v := range valid {
+		if err := ValidateFlags("runner.cpp_flags", v); err != nil {
+			t.Errorf("%q rejected: %v", v, err)
+		}
+	}
+	for _, v := range invalid {
+		if err := ValidateFlags("runner.cpp_flags", v); err == nil {
+			t.Errorf("%q accepted", v)
+		}
+	}
+	if got := SplitFlags(`-a 'b c'  -d`); strings.Join(got, "|") != "-a|b c|-d" {
+		t.Errorf("SplitFlags = %q", got)
+	}
+}
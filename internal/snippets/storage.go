@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/phravins/devcli/internal/config"
 )
 
 type Snippet struct {
@@ -26,16 +28,11 @@ type Storage struct {
 }
 
 func NewStorage() (*Storage, error) {
-	homeDir, err := os.UserHomeDir()
+	devCLIDir, err := config.Dir()
 	if err != nil {
 		return nil, err
 	}
 
-	devCLIDir := filepath.Join(homeDir, ".devcli")
-	if err := os.MkdirAll(devCLIDir, 0755); err != nil {
-		return nil, err
-	}
-
 	return &Storage{
 		filePath: filepath.Join(devCLIDir, "snippets.json"),
 	}, nil
@@ -131,6 +128,33 @@ func (s *Storage) Get(id string) (*Snippet, error) {
 	return nil, fmt.Errorf("snippet not found")
 }
 
+// Library returns the saved snippets, or the built-in samples from
+// GetDefaultSnippets while none are saved, as the snippet library shows
+func (s *Storage) Library() ([]Snippet, error) {
+	snips, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(snips) == 0 {
+		snips = GetDefaultSnippets()
+	}
+	return snips, nil
+}
+
+// Find returns the library snippet whose ID or title is name, ignoring case
+func (s *Storage) Find(name string) (*Snippet, error) {
+	snips, err := s.Library()
+	if err != nil {
+		return nil, err
+	}
+	for _, snip := range snips {
+		if snip.ID == name || strings.EqualFold(snip.Title, strings.TrimSpace(name)) {
+			return &snip, nil
+		}
+	}
+	return nil, fmt.Errorf("snippet not found")
+}
+
 func (s *Storage) Search(query string) ([]Snippet, error) {
 	all, err := s.LoadAll()
 	if err != nil {
@@ -0,0 +1,35 @@
+package snippets
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStorage_LibraryAndFind(t *testing.T) {
+	s := &Storage{filePath: filepath.Join(t.TempDir(), "snippets.json")}
+
+	library, err := s.Library()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(library) != len(GetDefaultSnippets()) {
+		t.Errorf("empty store should show the default snippets, got %d", len(library))
+	}
+
+	if err := s.Add(Snippet{Title: "Retry Helper", Language: "go", Code: "func retry() {}"}); err != nil {
+		t.Fatal(err)
+	}
+	snip, err := s.Find("retry helper")
+	if err != nil {
+		t.Fatalf("Find by title: %v", err)
+	}
+	if snip.Code != "func retry() {}" {
+		t.Errorf("Code = %q", snip.Code)
+	}
+	if byID, err := s.Find(snip.ID); err != nil || byID.Title != "Retry Helper" {
+		t.Errorf("Find by ID = %v, %v", byID, err)
+	}
+	if _, err := s.Find("missing"); err == nil {
+		t.Error("Find returned a snippet for an unknown name")
+	}
+}
@@ -2,11 +2,9 @@ package taskrunner
 
 import (
 	"bufio"
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 )
@@ -30,6 +28,24 @@ type Task struct {
 	Icon        string
 }
 
+// longRunningWords mark run tasks that keep going until stopped (dev
+// servers, watchers); they are exempt from exec.default_timeout
+var longRunningWords = []string{"dev", "start", "serve", "watch"}
+
+// LongRunning reports whether the task is expected to run until stopped
+func (t Task) LongRunning() bool {
+	if t.Type != TaskRun {
+		return false
+	}
+	command := strings.ToLower(t.Command)
+	for _, w := range longRunningWords {
+		if strings.Contains(command, w) {
+			return true
+		}
+	}
+	return false
+}
+
 // DetectTasks scans a project directory and detects available tasks
 func DetectTasks(projectPath string) []Task {
 	var tasks []Task
@@ -334,32 +350,67 @@ func detectGoTasks(projectPath string) []Task {
 
 func detectMakefileTasks(makefilePath string) []Task {
 	var tasks []Task
+	for _, target := range MakefileTargets(makefilePath) {
+		tasks = append(tasks, Task{
+			Name:        fmt.Sprintf("make %s", target),
+			Type:        TaskRun,
+			Command:     fmt.Sprintf("make %s", target),
+			Description: fmt.Sprintf("Run make target: %s", target),
+			Icon:        "",
+		})
+	}
+	return tasks
+}
 
+// MakefileTargets returns the targets a Makefile defines, in file order.
+// Special (.PHONY), pattern (%.o) and variable targets are skipped, as are
+// file targets with a path or extension (main.o, dist/app.js).
+func MakefileTargets(makefilePath string) []string {
 	data, err := os.ReadFile(makefilePath)
 	if err != nil {
-		return tasks
+		return nil
 	}
 
-	// Simple makefile parsing - look for targets
+	var targets []string
+	seen := map[string]bool{}
+	inDefine := false
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.HasSuffix(line, ":") && !strings.HasPrefix(line, "#") {
-			target := strings.TrimSuffix(line, ":")
-			target = strings.TrimSpace(target)
-			if target != "" && !strings.Contains(target, " ") {
-				tasks = append(tasks, Task{
-					Name:        fmt.Sprintf("make %s", target),
-					Type:        TaskRun,
-					Command:     fmt.Sprintf("make %s", target),
-					Description: fmt.Sprintf("Run make target: %s", target),
-					Icon:        "",
-				})
+		raw := scanner.Text()
+		if strings.HasPrefix(raw, "\t") {
+			continue // Recipe line
+		}
+		line, _, _ := strings.Cut(raw, "#")
+		line = strings.TrimSpace(line)
+
+		// define ... endef holds text, not rules
+		if word := strings.Fields(line); len(word) > 0 {
+			switch word[0] {
+			case "define":
+				inDefine = true
+				continue
+			case "endef":
+				inDefine = false
+				continue
 			}
 		}
-	}
+		if inDefine {
+			continue
+		}
 
-	return tasks
+		names, rest, found := strings.Cut(line, ":")
+		if !found || strings.Contains(names, "=") || strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":=") {
+			continue // Not a rule, or a := / ::= assignment
+		}
+		for _, target := range strings.Fields(names) {
+			if seen[target] || strings.HasPrefix(target, ".") || strings.ContainsAny(target, "%$/.") {
+				continue
+			}
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets
 }
 
 func detectRustTasks() []Task {
@@ -476,42 +527,6 @@ func detectCTasks(projectPath string) []Task {
 	return tasks
 }
 
-// ExecuteTask runs a task in the specified directory
-func ExecuteTask(ctx context.Context, task Task, workDir string, outputChan chan<- string) error {
-	defer close(outputChan)
-
-	// Parse command
-	parts := strings.Fields(task.Command)
-	if len(parts) == 0 {
-		return fmt.Errorf("empty command")
-	}
-
-	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
-	cmd.Dir = workDir
-
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return err
-	}
-	cmd.Stderr = cmd.Stdout
-
-	if err := cmd.Start(); err != nil {
-		return err
-	}
-
-	// Stream output
-	scanner := bufio.NewScanner(stdout)
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case outputChan <- scanner.Text():
-		}
-	}
-
-	return cmd.Wait()
-}
-
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
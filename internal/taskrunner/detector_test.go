@@ -0,0 +1,49 @@
+package taskrunner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMakefileTargets(t *testing.T) {
+	makefile := filepath.Join(t.TempDir(), "Makefile")
+	content := `# Build settings
+CC := gcc
+FLAGS = -O2 # comment: not a target
+PREFIX ::= /usr/local
+
+.PHONY: all dev clean
+
+all: build
+build: main.o util.o
+	$(CC) -o app main.o util.o   # recipe: not a target
+
+%.o: %.c
+	$(CC) -c $<
+
+dev serve:
+	./app --watch
+
+define HELP
+usage: make dev
+endef
+
+clean:
+	rm -f *.o app
+dist/app.js: src/app.js
+build: docs
+`
+	if err := os.WriteFile(makefile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"all", "build", "dev", "serve", "clean"}
+	if got := MakefileTargets(makefile); !reflect.DeepEqual(got, want) {
+		t.Errorf("MakefileTargets() = %q, want %q", got, want)
+	}
+	if got := MakefileTargets(filepath.Join(t.TempDir(), "Makefile")); got != nil {
+		t.Errorf("missing Makefile: got %q, want nil", got)
+	}
+}
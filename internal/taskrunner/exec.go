@@ -0,0 +1,134 @@
+package taskrunner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/phravins/devcli/internal/procs"
+)
+
+// maxLineLen splits longer output lines, so a task printing without
+// newlines cannot grow a line without bound
+const maxLineLen = 64 * 1024
+
+// ExecuteTask runs a task in the specified directory, sending its output
+// line by line to outputChan and closing it when the task has exited.
+// Unless the task is long-running it is killed after exec.default_timeout.
+// Cancelling ctx kills the task with everything it spawned and returns
+// context.Canceled.
+func ExecuteTask(ctx context.Context, task Task, workDir string, outputChan chan<- string) error {
+	defer close(outputChan)
+
+	var timeout time.Duration
+	if !task.LongRunning() {
+		timeout = procs.Timeout()
+	}
+	ctx, cancel := procs.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Parse command
+	parts := strings.Fields(task.Command)
+	if len(parts) == 0 {
+		return fmt.Errorf("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Dir = workDir
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := procs.Start(cmd, task.Name); err != nil {
+		return err
+	}
+	defer procs.Unregister(cmd)
+
+	// The pipe is read to the end even once nobody listens: a child blocked
+	// on a full pipe would never exit. Cancelling kills the process group,
+	// which closes it.
+	streamLines(stdout, func(line string) {
+		select {
+		case outputChan <- line:
+		case <-ctx.Done():
+		}
+	})
+
+	err = cmd.Wait()
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return context.Canceled
+	}
+	return procs.TimeoutError(ctx, err, timeout)
+}
+
+// streamLines calls emit with every line read from r, without the line
+// ending. Lines over maxLineLen are passed on in pieces.
+func streamLines(r io.Reader, emit func(line string)) {
+	reader := bufio.NewReaderSize(r, maxLineLen)
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if len(chunk) > 0 {
+			emit(strings.TrimRight(string(chunk), "\r\n"))
+		}
+		if err != nil && err != bufio.ErrBufferFull {
+			return
+		}
+	}
+}
+
+// Output keeps the last lines a task printed, dropping the oldest ones
+// once it holds its maximum
+type Output struct {
+	lines   []string
+	next    int // Where the next line goes once lines is full
+	max     int
+	dropped int
+}
+
+// NewOutput returns an Output holding up to max lines
+func NewOutput(max int) *Output {
+	return &Output{max: max}
+}
+
+// Add appends a line, replacing the oldest one when full
+func (o *Output) Add(line string) {
+	if len(o.lines) < o.max {
+		o.lines = append(o.lines, line)
+		return
+	}
+	o.lines[o.next] = line
+	o.next = (o.next + 1) % o.max
+	o.dropped++
+}
+
+// Lines returns the kept lines, oldest first
+func (o *Output) Lines() []string {
+	return append(append([]string{}, o.lines[o.next:]...), o.lines[:o.next]...)
+}
+
+// Dropped is how many lines were pushed out
+func (o *Output) Dropped() int {
+	return o.dropped
+}
+
+// Reset empties the buffer
+func (o *Output) Reset() {
+	o.lines, o.next, o.dropped = o.lines[:0], 0, 0
+}
+
+// String joins the kept lines, noting how many were dropped before them
+func (o *Output) String() string {
+	text := strings.Join(o.Lines(), "\n")
+	if o.dropped > 0 {
+		text = fmt.Sprintf("... (%d earlier lines dropped) ...\n", o.dropped) + text
+	}
+	return text
+}
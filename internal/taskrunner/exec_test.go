@@ -0,0 +1,149 @@
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const helperEnv = "DEVCLI_TASK_HELPER"
+
+// TestHelperProcess is the task run by the tests below, selected by
+// DEVCLI_TASK_HELPER; it does nothing in a normal test run
+func TestHelperProcess(t *testing.T) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		return
+	case "chatty":
+		for i := 0; i < 50000; i++ {
+			fmt.Printf("line %d\n", i)
+		}
+		fmt.Print(strings.Repeat("x", 150000) + "\nend")
+	case "hang":
+		// A grandchild sharing stdout: ExecuteTask only returns once it is gone too
+		child := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+		child.Env = append(os.Environ(), helperEnv+"=sleep")
+		child.Stdout = os.Stdout
+		if err := child.Start(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("ready")
+		time.Sleep(time.Hour)
+	case "sleep":
+		time.Sleep(time.Hour)
+	case "fail":
+		fmt.Println("boom")
+		os.Exit(3)
+	}
+	os.Exit(0)
+}
+
+// helperTask runs TestHelperProcess in the given mode
+func helperTask(t *testing.T, mode string) Task {
+	t.Helper()
+	home := t.TempDir() // Keeps the process registry out of the real config
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("AppData", filepath.Join(home, "AppData"))
+	t.Setenv(helperEnv, mode)
+	if strings.ContainsAny(os.Args[0], " \t") {
+		t.Skip("test binary path contains spaces")
+	}
+	return Task{Name: "helper " + mode, Type: TaskBuild, Command: os.Args[0] + " -test.run=^TestHelperProcess$"}
+}
+
+func TestExecuteTask_Chatty(t *testing.T) {
+	task := helperTask(t, "chatty")
+	out := make(chan string) // Unbuffered: the reader is slower than the task
+	errc := make(chan error, 1)
+	go func() { errc <- ExecuteTask(context.Background(), task, t.TempDir(), out) }()
+
+	buf := NewOutput(100)
+	count := 0
+	for line := range out {
+		buf.Add(line)
+		count++
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("ExecuteTask: %v", err)
+	}
+	// 50000 lines, the long line in 64 KB pieces (3) and "end"
+	if count != 50004 {
+		t.Errorf("got %d lines, want 50004", count)
+	}
+	lines := buf.Lines()
+	if len(lines) != 100 || lines[99] != "end" || buf.Dropped() != count-100 {
+		t.Errorf("kept %d lines ending in %q with %d dropped", len(lines), lines[len(lines)-1], buf.Dropped())
+	}
+	if len(lines[98]) != 150000-2*maxLineLen {
+		t.Errorf("last piece of the long line has %d bytes", len(lines[98]))
+	}
+}
+
+func TestExecuteTask_Cancel(t *testing.T) {
+	task := helperTask(t, "hang")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan string)
+	errc := make(chan error, 1)
+	go func() { errc <- ExecuteTask(ctx, task, t.TempDir(), out) }()
+
+	if line := <-out; line != "ready" {
+		t.Fatalf("first line %q, want ready", line)
+	}
+	cancel()
+	// Nobody reads out any more; ExecuteTask must still finish
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want context.Canceled", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("ExecuteTask did not return after cancel; the process tree is still running")
+	}
+	for range out {
+		// Closed once ExecuteTask returned
+	}
+}
+
+func TestExecuteTask_Failure(t *testing.T) {
+	task := helperTask(t, "fail")
+	out := make(chan string, 10)
+	err := ExecuteTask(context.Background(), task, t.TempDir(), out)
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("got %v, want exit status 3", err)
+	}
+	if line := <-out; line != "boom" {
+		t.Errorf("got %q, want boom", line)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	o := NewOutput(3)
+	for _, l := range []string{"a", "b"} {
+		o.Add(l)
+	}
+	if got := o.String(); got != "a\nb" {
+		t.Errorf("got %q", got)
+	}
+	for _, l := range []string{"c", "d", "e"} {
+		o.Add(l)
+	}
+	if got := o.String(); got != "... (2 earlier lines dropped) ...\nc\nd\ne" {
+		t.Errorf("got %q", got)
+	}
+	o.Reset()
+	o.Add("f")
+	if got := o.String(); got != "f" || o.Dropped() != 0 {
+		t.Errorf("after Reset got %q with %d dropped", got, o.Dropped())
+	}
+}
@@ -303,6 +303,148 @@ int main() {
 			".gitignore": `build/
 .vscode/
 .idea/
+`,
+		},
+	},
+	{
+		Name:        "Fullstack (React + Go)",
+		Description: "Vite React frontend and Go HTTP API in frontend/ and backend/",
+		Stack:       "Fullstack",
+		InstallCmd:  "cd backend && go mod tidy && cd .. && cd frontend && npm install",
+		RunCmd:      "cd backend && go run .", // Frontend: cd frontend && npm run dev
+		Files: map[string]string{
+			"backend/go.mod": `module {{.Name}}/backend
+
+go 1.22
+`,
+			"backend/main.go": `package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"message": "Hello from the Go backend!"})
+	})
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("API listening on http://localhost:%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, mux))
+}
+`,
+			"frontend/package.json": `{
+  "name": "{{.Name}}-frontend",
+  "private": true,
+  "version": "0.1.0",
+  "type": "module",
+  "scripts": {
+    "dev": "vite",
+    "build": "vite build",
+    "preview": "vite preview"
+  },
+  "dependencies": {
+    "react": "^18.3.1",
+    "react-dom": "^18.3.1"
+  },
+  "devDependencies": {
+    "@vitejs/plugin-react": "^4.3.1",
+    "vite": "^5.4.0"
+  }
+}
+`,
+			"frontend/vite.config.js": `import { defineConfig } from 'vite'
+import react from '@vitejs/plugin-react'
+
+// Requests to /api go to the Go backend, so the app needs no CORS setup
+export default defineConfig({
+  plugins: [react()],
+  server: {
+    proxy: { '/api': 'http://localhost:8080' },
+  },
+})
+`,
+			"frontend/index.html": `<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>{{.Name}}</title>
+  </head>
+  <body>
+    <div id="root"></div>
+    <script type="module" src="/src/main.jsx"></script>
+  </body>
+</html>
+`,
+			"frontend/src/main.jsx": `import React from 'react'
+import ReactDOM from 'react-dom/client'
+import App from './App.jsx'
+
+ReactDOM.createRoot(document.getElementById('root')).render(
+  <React.StrictMode>
+    <App />
+  </React.StrictMode>,
+)
+`,
+			"frontend/src/App.jsx": `import { useEffect, useState } from 'react'
+
+export default function App() {
+  const [message, setMessage] = useState('Loading...')
+
+  useEffect(() => {
+    fetch('/api/hello')
+      .then((res) => res.json())
+      .then((data) => setMessage(data.message))
+      .catch(() => setMessage('Backend not reachable - is it running on :8080?'))
+  }, [])
+
+  return (
+    <main>
+      <h1>{{.Name}}</h1>
+      <p>{message}</p>
+    </main>
+  )
+}
+`,
+			"README.md": `# {{.Name}}
+
+A React (Vite) frontend with a Go HTTP API.
+
+- **backend/**: Go API on http://localhost:8080 (` + "`GET /api/hello`" + `)
+- **frontend/**: Vite dev server on http://localhost:5173, proxying ` + "`/api`" + ` to the backend
+
+## Install
+
+` + "```bash" + `
+cd backend && go mod tidy
+cd ../frontend && npm install
+` + "```" + `
+
+## Run
+
+Start both servers from DevCLI's Dev Server (it detects the backend/ and
+frontend/ layout), or in two terminals:
+
+` + "```bash" + `
+cd backend && go run .
+cd frontend && npm run dev
+` + "```" + `
+`,
+			".gitignore": `node_modules/
+frontend/dist/
+backend/bin/
+.env
+.idea/
+.vscode/
 `,
 		},
 	},
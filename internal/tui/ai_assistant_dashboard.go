@@ -17,6 +17,8 @@ type AIAssistantModel struct {
 	output      viewport.Model
 	spinner     spinner.Model
 	provider    ai.Provider
+	cfg         *config.Config // AI profile in use (Alt+P switches)
+	profileErr  error
 	state       int // 0: input, 1: generating, 2: result
 	activeAgent int // 0: CodeGen, 1: Architect, 2: Debugger
 	prompt      string
@@ -56,6 +58,7 @@ func NewAIAssistantModel() AIAssistantModel {
 		output:   vp,
 		spinner:  s,
 		provider: p,
+		cfg:      cfg,
 		helpView: viewport.New(80, 20),
 		state:    aiStateInput,
 	}
@@ -78,6 +81,16 @@ func (m AIAssistantModel) Update(msg tea.Msg) (AIAssistantModel, tea.Cmd) {
 		case "shift+tab", "]", "ctrl+p":
 			m.activeAgent = (m.activeAgent - 1 + 3) % 3
 			return m, nil
+		case "alt+p":
+			if m.state == aiStateGenerating {
+				return m, nil
+			}
+			p, err := switchAIProfile(m.cfg)
+			m.profileErr = err
+			if err == nil {
+				m.provider = p
+			}
+			return m, nil
 		case "?":
 			if m.state != aiStateHelp {
 				m.state = aiStateHelp
@@ -243,6 +256,10 @@ func (m AIAssistantModel) View() string {
 			}
 			return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" Ready")
 		}(),
+		"\n\n",
+		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4")).Render(" PROFILE"),
+		"\n",
+		m.profileView(sidebarWidth-2),
 	))
 
 	renderHeader := func(text string, color string) string {
@@ -265,7 +282,7 @@ func (m AIAssistantModel) View() string {
 			Width(mainAreaWidth).
 			Height(m.height - 6).
 			Render(m.input.View())
-		footer := subtleStyle.Render("Ctrl+D: Dispatch • Ctrl+P/Tab: Switch Agent")
+		footer := subtleStyle.Render("Ctrl+D: Dispatch • Ctrl+P/Tab: Switch Agent • Alt+P: Switch Profile")
 		mainContent = lipgloss.JoinVertical(lipgloss.Center, header, inputBox, footer)
 
 	case aiStateGenerating:
@@ -287,7 +304,7 @@ func (m AIAssistantModel) View() string {
 			Width(mainAreaWidth).
 			Height(m.height - 6).
 			Render(m.output.View())
-		footer := subtleStyle.Render("N: New • Esc: Back • Ctrl+P/Tab: Switch Agent")
+		footer := subtleStyle.Render("N: New • Esc: Back • Ctrl+P/Tab: Switch Agent • Alt+P: Switch Profile")
 		mainContent = lipgloss.JoinVertical(lipgloss.Center, header, viewportBox, footer)
 
 	case aiStateHelp:
@@ -297,18 +314,35 @@ func (m AIAssistantModel) View() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainContent)
 }
 
+// profileView lists the active profile and backend in the sidebar, or why
+// the last switch failed
+func (m AIAssistantModel) profileView(width int) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(width)
+	if m.profileErr != nil {
+		return style.Foreground(lipgloss.Color("196")).Render(" " + m.profileErr.Error())
+	}
+	text := " " + profileLabel(m.cfg)
+	if m.provider != nil {
+		text += "\n " + m.provider.Name()
+	}
+	return style.Render(text)
+}
+
 type aiResponseMsg string
 
+// System prompt per agent, indexed by activeAgent
+var agentSystemPrompts = []string{
+	"You are an expert AI software engineer specialized in code generation. Provide high-quality, efficient code directly.",
+	"You are a Senior System Architect. Provide high-level design patterns, architecture diagrams (markdown), and structural advice.",
+	"You are an expert Debugger. Focus on identifying potential bugs, performance bottlenecks, and security vulnerabilities in the provided context.",
+}
+
+const debuggerAgent = 2
+
 func (m AIAssistantModel) sendToAI(prompt string) tea.Cmd {
 	return func() tea.Msg {
-		systemPrompts := []string{
-			"You are an expert AI software engineer specialized in code generation. Provide high-quality, efficient code directly.",
-			"You are a Senior System Architect. Provide high-level design patterns, architecture diagrams (markdown), and structural advice.",
-			"You are an expert Debugger. Focus on identifying potential bugs, performance bottlenecks, and security vulnerabilities in the provided context.",
-		}
-
 		messages := []ai.Message{
-			{Role: "system", Content: systemPrompts[m.activeAgent]},
+			{Role: "system", Content: agentSystemPrompts[m.activeAgent]},
 			{Role: "user", Content: prompt},
 		}
 		resp, err := m.provider.Send(messages)
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/phravins/devcli/internal/ai"
+	"github.com/phravins/devcli/internal/ai/providers"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// switchAIProfile moves cfg to its next profile (Alt+P in the chat and
+// the AI assistant) and builds a provider for it. On failure cfg stays on
+// the current profile.
+func switchAIProfile(cfg *config.Config) (ai.Provider, error) {
+	if cfg == nil || len(cfg.Profiles) == 0 {
+		return nil, fmt.Errorf("no AI profiles configured (add them under profiles: in %s)", configFileName())
+	}
+	prev := cfg.ActiveProfile()
+	if err := cfg.UseProfile(cfg.NextProfile()); err != nil {
+		return nil, err
+	}
+	p, err := providers.GetProvider(cfg)
+	if err != nil {
+		cfg.UseProfile(prev)
+		return nil, err
+	}
+	return p, nil
+}
+
+// profileLabel names the active profile; "settings" stands for the ai_*
+// keys edited in Settings
+func profileLabel(cfg *config.Config) string {
+	if cfg == nil || cfg.ActiveProfile() == "" {
+		return "settings"
+	}
+	return cfg.ActiveProfile()
+}
+
+// configFileName is the config path for messages, or a generic name when
+// it cannot be resolved
+func configFileName() string {
+	if path, err := config.Path(); err == nil {
+		return path
+	}
+	return "config.yaml"
+}
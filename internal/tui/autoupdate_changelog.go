@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// conventionalCommit matches "type(scope)!: subject"
+var conventionalCommit = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changelogSections maps conventional-commit types to headings, in the
+// order they are shown. Anything else lands in "Other Changes".
+var changelogSections = []struct {
+	title string
+	types []string
+}{
+	{"Features", []string{"feat", "feature"}},
+	{"Bug Fixes", []string{"fix", "bugfix", "hotfix"}},
+	{"Performance", []string{"perf"}},
+	{"Refactoring", []string{"refactor"}},
+	{"Documentation", []string{"docs", "doc"}},
+	{"Tests", []string{"test", "tests"}},
+	{"Build & CI", []string{"build", "ci", "deps"}},
+	{"Chores", []string{"chore", "style", "revert"}},
+}
+
+var changelogTemplate = template.Must(template.New("changelog").Parse(`# Update Summary
+{{if .Note}}
+> {{.Note}}
+{{end}}
+{{len .Commits}} new commit{{if ne (len .Commits) 1}}s{{end}} upstream.
+{{range .Sections}}
+## {{.Title}}
+{{range .Entries}}
+- {{if .Scope}}**{{.Scope}}:** {{end}}{{.Subject}} ` + "(`{{.Hash}}`)" + `{{end}}
+{{end}}`))
+
+type changelogEntry struct {
+	Hash, Scope, Subject string
+}
+
+type changelogSection struct {
+	Title   string
+	Entries []changelogEntry
+}
+
+// localChangelog groups `git log --oneline` output by conventional-commit
+// prefix into Markdown. It is the summary when no AI provider is available;
+// note explains why and is shown at the top.
+func localChangelog(log, note string) string {
+	var commits []string
+	breaking := changelogSection{Title: "Breaking Changes"}
+	other := changelogSection{Title: "Other Changes"}
+	byType := map[string]*changelogSection{}
+	sections := make([]changelogSection, len(changelogSections))
+	for i, s := range changelogSections {
+		sections[i].Title = s.title
+		for _, t := range s.types {
+			byType[t] = &sections[i]
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(log), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		commits = append(commits, line)
+		hash, subject, _ := strings.Cut(line, " ")
+		entry := changelogEntry{Hash: hash, Subject: strings.TrimSpace(subject)}
+
+		m := conventionalCommit.FindStringSubmatch(entry.Subject)
+		if m == nil {
+			other.Entries = append(other.Entries, entry)
+			continue
+		}
+		entry.Scope, entry.Subject = m[2], m[4]
+		if m[3] == "!" {
+			breaking.Entries = append(breaking.Entries, entry)
+			continue
+		}
+		if s, ok := byType[strings.ToLower(m[1])]; ok {
+			s.Entries = append(s.Entries, entry)
+		} else {
+			other.Entries = append(other.Entries, entry)
+		}
+	}
+
+	var shown []changelogSection
+	for _, s := range append(append([]changelogSection{breaking}, sections...), other) {
+		if len(s.Entries) > 0 {
+			shown = append(shown, s)
+		}
+	}
+
+	var sb strings.Builder
+	err := changelogTemplate.Execute(&sb, struct {
+		Note     string
+		Commits  []string
+		Sections []changelogSection
+	}{note, commits, shown})
+	if err != nil {
+		return "Raw logs:\n" + log
+	}
+	return sb.String()
+}
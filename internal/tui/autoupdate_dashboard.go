@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -18,6 +19,7 @@ import (
 	"github.com/phravins/devcli/internal/ai"
 	"github.com/phravins/devcli/internal/ai/providers"
 	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
 	"github.com/phravins/devcli/pkg/utils"
 )
 
@@ -34,6 +36,8 @@ const (
 	StateAutoUpdateCheck       // Checking git
 	StateAutoUpdateSummarizing // Generating AI summary
 	StateAutoUpdateReview      // Reviewing AI Summary
+	StateAutoUpdatePlanning    // Computing the dry-run plan
+	StateAutoUpdatePlan        // Reviewing the dry-run plan
 	StateAutoUpdateInstalling
 	StateAutoUpdateKeyInput
 	StateAutoUpdateDone
@@ -55,6 +59,8 @@ type AutoUpdateModel struct {
 	updateLog     string // Raw git log
 	updateSummary string // The AI generated summary
 	provider      ai.Provider
+	plan          updatePlanMsg // Dry-run shown before installing
+	installLog    string        // Step output of the last install run
 
 	// Error handling
 	err       error
@@ -73,10 +79,64 @@ type summaryMsg struct {
 	err     error
 }
 
+// updatePlanMsg is the dry-run of an update: what would change and
+// exactly which commands would run.
+type updatePlanMsg struct {
+	branch     string
+	dirtyFiles string // `git status --porcelain` output, empty if clean
+	diffStat   string // Files changed upstream
+	err        error
+}
+
 type installMsg struct {
+	log string // Output of every step that ran
 	err error
 }
 
+// updateSteps lists the commands run by an update, in order. Stashing is
+// only included when the user explicitly opts in on a dirty tree.
+func updateSteps(branch string, stash bool) [][]string {
+	var steps [][]string
+	if stash {
+		steps = append(steps, []string{"git", "stash", "push", "--include-untracked", "-m", "DevCLI auto-update backup"})
+	}
+	steps = append(steps, []string{"git", "pull", "origin", branch})
+	if stash {
+		steps = append(steps, []string{"git", "stash", "pop"})
+	}
+	steps = append(steps, []string{"go", "build", "-o", "devcli.exe", "."})
+	return steps
+}
+
+// render formats the plan for the output view
+func (p updatePlanMsg) render() string {
+	var sb strings.Builder
+	sb.WriteString("# Update Dry Run\n\n")
+	sb.WriteString(fmt.Sprintf("Branch: `%s`\n\n", p.branch))
+
+	sb.WriteString("## Files that would change\n\n```\n")
+	if p.diffStat == "" {
+		sb.WriteString("(no file changes reported)\n")
+	} else {
+		sb.WriteString(p.diffStat + "\n")
+	}
+	sb.WriteString("```\n\n")
+
+	if p.dirtyFiles != "" {
+		sb.WriteString("## Uncommitted local changes\n\n")
+		sb.WriteString("Your working tree is **not clean**, so the update will not run by default. ")
+		sb.WriteString("Commit or stash these changes yourself, or press **s** to let DevCLI stash them, pull and restore them (may conflict).\n\n```\n")
+		sb.WriteString(p.dirtyFiles + "\n```\n\n")
+	}
+
+	sb.WriteString("## Commands\n\n```\n")
+	for _, step := range updateSteps(p.branch, p.dirtyFiles != "") {
+		sb.WriteString(strings.Join(step, " ") + "\n")
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
 func showMainMenu(m *AutoUpdateModel) {
 	m.list.SetItems(autoUpdateMenuItems)
 	m.list.Title = "Auto-Update Center"
@@ -116,41 +176,10 @@ func NewAutoUpdateModel() AutoUpdateModel {
 		BorderForeground(lipgloss.Color("62")).
 		Padding(1, 2)
 
-	// Initialize provider (Copied from ChatModel)
+	// Initialize provider from the default AI profile
 	cfg, _ := config.LoadConfig()
-	var p ai.Provider
-
-	backend := strings.TrimSpace(strings.ToLower(cfg.AIBackend))
-	if backend == "" {
-		backend = "ollama"
-	}
-
-	switch backend {
-	case "ollama":
-		p = &providers.OllamaProvider{}
-	case "huggingface":
-		p = &providers.HFProvider{}
-	case "local":
-		p = &providers.LocalHFProvider{}
-	case "claude", "anthropic":
-		p = &providers.AnthropicProvider{}
-	case "gemini", "google":
-		p = &providers.GeminiProvider{}
-	case "mistral":
-		p = &providers.OpenAIProvider{BaseURL: "https://api.mistral.ai/v1"}
-	case "kimi", "moonshot":
-		p = &providers.OpenAIProvider{BaseURL: "https://api.moonshot.cn/v1"}
-	case "groq":
-		p = &providers.OpenAIProvider{BaseURL: "https://api.groq.com/openai/v1"}
-	case "deepseek":
-		p = &providers.OpenAIProvider{BaseURL: "https://api.deepseek.com/v1"}
-	case "lmstudio":
-		p = &providers.OpenAIProvider{}
-	default:
-		p = &providers.OpenAIProvider{}
-	}
-
-	if err := p.Configure(cfg); err != nil {
+	p, err := providers.GetProvider(cfg)
+	if err != nil {
 		fmt.Printf("Error configuring provider: %v\n", err)
 	}
 
@@ -266,9 +295,31 @@ func (m AutoUpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// In review mode, handle confirmation or cancel
 			switch msg.String() {
 			case "y", "Y":
+				m.state = StateAutoUpdatePlanning
+				m.statusMsg = "Preparing dry run..."
+				return m, tea.Batch(m.spinner.Tick, planDevCLIUpdateCmd())
+			case "n", "N", "esc":
+				m.state = StateAutoUpdateMenu
+				return m, nil
+			}
+		} else if m.state == StateAutoUpdatePlan {
+			// Clean tree: [y] pulls and builds. Dirty tree: refused unless [s] opts into stashing.
+			dirty := m.plan.dirtyFiles != ""
+			switch msg.String() {
+			case "y", "Y":
+				if dirty {
+					return m, nil
+				}
 				m.state = StateAutoUpdateInstalling
 				m.statusMsg = "Updating DevCLI..."
-				return m, tea.Batch(m.spinner.Tick, installDevCLIUpdatesCmd())
+				return m, tea.Batch(m.spinner.Tick, installDevCLIUpdatesCmd(m.plan.branch, false))
+			case "s", "S":
+				if !dirty {
+					return m, nil
+				}
+				m.state = StateAutoUpdateInstalling
+				m.statusMsg = "Stashing local changes and updating DevCLI..."
+				return m, tea.Batch(m.spinner.Tick, installDevCLIUpdatesCmd(m.plan.branch, true))
 			case "n", "N", "esc":
 				m.state = StateAutoUpdateMenu
 				return m, nil
@@ -278,6 +329,7 @@ func (m AutoUpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = StateAutoUpdateMenu
 				m.err = nil
 				m.statusMsg = ""
+				m.installLog = ""
 				return m, nil
 			}
 		} else if m.state == StateAutoUpdateHelp {
@@ -308,7 +360,7 @@ func (m AutoUpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.updateLog = msg.log
 			m.state = StateAutoUpdateSummarizing
-			m.statusMsg = "Found updates! Generating AI summary..."
+			m.statusMsg = "Found updates! Generating summary..."
 			return m, tea.Batch(m.spinner.Tick, summarizeUpdatesCmd(m.provider, msg.log))
 		}
 
@@ -342,12 +394,34 @@ func (m AutoUpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.outputView.SetContent(m.updateSummary)
 		}
 
+	case updatePlanMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.state = StateAutoUpdateDone
+			break
+		}
+		m.plan = msg
+		m.state = StateAutoUpdatePlan
+		renderer, _ := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(m.width-10),
+		)
+		out, err := renderer.Render(msg.render())
+		if err != nil {
+			out = msg.render()
+		}
+		m.outputView.SetContent(out)
+		m.outputView.GotoTop()
+
 	case installMsg:
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
 			m.statusMsg = "Update Complete! Please restart DevCLI."
 		}
+		m.installLog = msg.log
+		m.outputView.SetContent(msg.log)
+		m.outputView.GotoBottom()
 		m.state = StateAutoUpdateDone
 	}
 
@@ -358,7 +432,7 @@ func (m AutoUpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	// Update viewport in review/done/langs/help
-	if m.state == StateAutoUpdateReview || m.state == StateAutoUpdateDone || m.state == StateAutoUpdateLanguages || m.state == StateAutoUpdateHelp {
+	if m.state == StateAutoUpdateReview || m.state == StateAutoUpdatePlan || m.state == StateAutoUpdateDone || m.state == StateAutoUpdateLanguages || m.state == StateAutoUpdateHelp {
 		m.outputView, cmd = m.outputView.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -408,7 +482,7 @@ func (m AutoUpdateModel) View() string {
 			),
 		)
 
-	case StateAutoUpdateCheck, StateAutoUpdateSummarizing, StateAutoUpdateInstalling:
+	case StateAutoUpdateCheck, StateAutoUpdateSummarizing, StateAutoUpdatePlanning, StateAutoUpdateInstalling:
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
 			lipgloss.JoinVertical(lipgloss.Center,
 				m.spinner.View(),
@@ -418,7 +492,23 @@ func (m AutoUpdateModel) View() string {
 
 	case StateAutoUpdateReview:
 		header := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).Render("New Updates Available!")
-		footer := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("Press [y] to Install • [n] to Cancel")
+		footer := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("Press [y] to Review Install Plan • [n] to Cancel")
+
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			lipgloss.JoinVertical(lipgloss.Center,
+				header,
+				m.outputView.View(),
+				footer,
+			),
+		)
+
+	case StateAutoUpdatePlan:
+		header := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).Render("Review Update Plan")
+		footerText := "Press [y] to Run These Commands • [n] to Cancel"
+		if m.plan.dirtyFiles != "" {
+			footerText = "Uncommitted changes: update refused • [s] Stash, Update & Restore • [n] to Cancel"
+		}
+		footer := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(footerText)
 
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
 			lipgloss.JoinVertical(lipgloss.Center,
@@ -429,11 +519,17 @@ func (m AutoUpdateModel) View() string {
 		)
 
 	case StateAutoUpdateDone:
+		// Step output from an install run, if any
+		var stepLog string
+		if m.installLog != "" {
+			stepLog = "\n" + m.outputView.View()
+		}
 		if m.err != nil {
 			return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
 				lipgloss.JoinVertical(lipgloss.Center,
 					lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render(" Error"),
 					m.err.Error(),
+					stepLog,
 					"\nPress [Esc] to go back",
 				),
 			)
@@ -441,6 +537,7 @@ func (m AutoUpdateModel) View() string {
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
 			lipgloss.JoinVertical(lipgloss.Center,
 				lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true).Render(" "+m.statusMsg),
+				stepLog,
 				"\nPress [Esc] to go back",
 			),
 		)
@@ -463,9 +560,10 @@ func showHelp(m *AutoUpdateModel) {
 
 	sb.WriteString("## 3. DevCLI Self-Update\n")
 	sb.WriteString("Checks the official DevCLI repository for updates. If updates are found, it:\n")
-	sb.WriteString("- **Pulls** the latest changes via Git.\n")
-	sb.WriteString("- **Generates** an AI-powered summary of the release notes.\n")
-	sb.WriteString("- **Rebuilds** the DevCLI executable automatically if you confirm.\n\n")
+	sb.WriteString("- **Generates** an AI-powered summary of the release notes (without a provider, or if it fails, commits are grouped locally by feat/fix/docs/... prefix).\n")
+	sb.WriteString("- **Shows a dry run**: the files that would change and the exact commands that will run.\n")
+	sb.WriteString("- **Pulls** the latest changes via Git and **rebuilds** DevCLI only after you confirm.\n")
+	sb.WriteString("- **Refuses** to update a working tree with uncommitted changes unless you explicitly choose to stash them.\n\n")
 
 	sb.WriteString("---\n")
 	sb.WriteString("### How to Use\n")
@@ -529,6 +627,15 @@ func checkLanguageVersionsCmd() tea.Cmd {
 				sb.WriteString(fmt.Sprintf("• Version: %s\n", pinky.Render("Unknown (Check triggered error)")))
 			}
 			sb.WriteString(fmt.Sprintf("• Path:    %s\n", pinky.Render(pathStr)))
+			if path == "" {
+				if install := installHintFor(cmdName); install != "" {
+					sb.WriteString(fmt.Sprintf("• Install: %s\n", install))
+				}
+				if hint, ok := toolInstallHints[cmdName]; ok && hint.url != "" {
+					sb.WriteString(fmt.Sprintf("• Link:    %s\n", hint.url))
+				}
+				sb.WriteString(fmt.Sprintf("• Config:  set `compilers.%s` in config.yaml to a manual path\n", cmdName))
+			}
 			sb.WriteString("\n")
 		}
 
@@ -636,69 +743,109 @@ func checkDevCLIUpdatesCmd() tea.Cmd {
 
 func summarizeUpdatesCmd(p ai.Provider, log string) tea.Cmd {
 	return func() tea.Msg {
+		// Without a working provider, group the log locally instead
 		if p == nil {
-			return summaryMsg{err: fmt.Errorf("no AI provider configured")}
+			return summaryMsg{content: localChangelog(log, "No AI provider configured: showing commits grouped by type.")}
 		}
 
 		prompt := fmt.Sprintf("Visualize these git commit logs into a nice, human-readable release note summary. Highlight new features and fixes. Keep it concise.\n\nLogs:\n%s", log)
 
 		msgs := []ai.Message{{Role: "user", Content: prompt}}
 		resp, err := p.Send(msgs)
-		return summaryMsg{content: resp, err: err}
+		if err != nil {
+			return summaryMsg{content: localChangelog(log, fmt.Sprintf("AI summary failed (%v): showing commits grouped by type.", err))}
+		}
+		if strings.TrimSpace(resp) == "" {
+			return summaryMsg{content: localChangelog(log, "The AI returned an empty summary: showing commits grouped by type.")}
+		}
+		return summaryMsg{content: resp}
 	}
 }
 
-func installDevCLIUpdatesCmd() tea.Cmd {
+// planDevCLIUpdateCmd gathers the dry-run: upstream file changes and local
+// uncommitted changes. Nothing is modified.
+func planDevCLIUpdateCmd() tea.Cmd {
 	return func() tea.Msg {
-		// Get current branch
-		branchCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-		branchOut, err := branchCmd.Output()
+		branchOut, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
 		if err != nil {
-			return installMsg{err: fmt.Errorf("failed to get current branch: %w", err)}
+			return updatePlanMsg{err: fmt.Errorf("failed to get current branch: %w", err)}
 		}
 		branch := strings.TrimSpace(string(branchOut))
 
-		// Check if there are uncommitted changes
-		statusCmd := exec.Command("git", "status", "--porcelain")
-		statusOut, err := statusCmd.Output()
-		hasChanges := err == nil && len(statusOut) > 0
+		statusOut, err := exec.Command("git", "status", "--porcelain").Output()
+		if err != nil {
+			return updatePlanMsg{err: fmt.Errorf("git status failed: %w", err)}
+		}
 
-		// Stash changes if any exist
-		if hasChanges {
-			stash := exec.Command("git", "stash", "push", "-m", "DevCLI auto-update backup")
-			if output, err := stash.CombinedOutput(); err != nil {
-				return installMsg{err: fmt.Errorf("git stash failed: %s", string(output))}
-			}
+		diffOut, err := exec.Command("git", "diff", "--stat", "HEAD...origin/"+branch).Output()
+		if err != nil {
+			return updatePlanMsg{err: fmt.Errorf("git diff failed (no upstream for branch '%s'?)", branch)}
 		}
 
-		// git pull with explicit remote and branch
-		pull := exec.Command("git", "pull", "origin", branch)
-		if output, err := pull.CombinedOutput(); err != nil {
-			// If pull fails, restore stashed changes
-			if hasChanges {
-				exec.Command("git", "stash", "pop").Run()
-			}
-			return installMsg{err: fmt.Errorf("git pull failed: %s", string(output))}
+		return updatePlanMsg{
+			branch:     branch,
+			dirtyFiles: strings.TrimRight(string(statusOut), "\n"),
+			diffStat:   strings.TrimRight(string(diffOut), "\n"),
 		}
+	}
+}
 
-		// Restore stashed changes after successful pull
-		if hasChanges {
-			pop := exec.Command("git", "stash", "pop")
-			if output, err := pop.CombinedOutput(); err != nil {
-				// Don't fail the update if stash pop has conflicts, just warn
-				return installMsg{err: fmt.Errorf("update succeeded but stash restore had conflicts: %s\nYour changes are in 'git stash list'", string(output))}
+// installDevCLIUpdatesCmd runs updateSteps, stopping at the first failure.
+// The output of each step is returned so it can be shown to the user.
+func installDevCLIUpdatesCmd(branch string, stash bool) tea.Cmd {
+	return func() tea.Msg {
+		var log strings.Builder
+		stashBefore, created := stashTop(), "" // created: the stash this run pushed
+
+		timeout := procs.Timeout() // Per step
+		for _, step := range updateSteps(branch, stash) {
+			cmdLine := strings.Join(step, " ")
+			if step[1] == "stash" && step[2] == "pop" {
+				if created == "" {
+					continue // Nothing was stashed, so there is nothing to restore
+				}
+				if stashTop() != created {
+					return installMsg{log: log.String(), err: fmt.Errorf("update succeeded but the stash changed meanwhile\nYour changes are in 'git stash list'")}
+				}
+			}
+			log.WriteString("$ " + cmdLine + "\n")
+			ctx, cancel := procs.WithTimeout(context.Background(), timeout)
+			output, err := procs.CombinedOutput(exec.CommandContext(ctx, step[0], step[1:]...), "update: "+cmdLine)
+			err = procs.TimeoutError(ctx, err, timeout)
+			cancel()
+			log.Write(output)
+			log.WriteString("\n")
+			if err != nil {
+				switch {
+				case step[1] == "pull" && created != "" && stashTop() == created:
+					// Restore the user's changes before bailing out
+					popOut, _ := exec.Command("git", "stash", "pop").CombinedOutput()
+					log.WriteString("$ git stash pop\n" + string(popOut) + "\n")
+				case step[1] == "stash" && step[2] == "pop":
+					return installMsg{log: log.String(), err: fmt.Errorf("update succeeded but stash restore had conflicts\nYour changes are in 'git stash list'")}
+				}
+				return installMsg{log: log.String(), err: fmt.Errorf("%s failed: %v", cmdLine, err)}
+			}
+			if step[1] == "stash" && step[2] == "push" {
+				if top := stashTop(); top != stashBefore {
+					created = top
+				}
 			}
 		}
 
-		// go build
-		// Assuming we run this from the project root or we can find it
-		build := exec.Command("go", "build", "-o", "devcli.exe", ".")
-		if output, err := build.CombinedOutput(); err != nil {
-			return installMsg{err: fmt.Errorf("go build failed: %s", string(output))}
-		}
+		return installMsg{log: log.String()}
+	}
+}
 
-		return installMsg{err: nil}
+// stashTop returns the commit of the newest stash entry, or "" if there is
+// none. Comparing it around "git stash push" tells whether anything was
+// stashed, so only a stash this update created is ever popped.
+func stashTop() string {
+	out, err := exec.Command("git", "rev-parse", "-q", "--verify", "refs/stash").Output()
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(string(out))
 }
 
 func saveKeyCmd(provider, key string) {
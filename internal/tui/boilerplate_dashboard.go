@@ -14,6 +14,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/phravins/devcli/internal/boilerplate"
+	"github.com/phravins/devcli/internal/config"
 )
 
 // Boilerplate States
@@ -71,8 +72,12 @@ func bpTickCmd() tea.Cmd {
 
 func NewBoilerplateDashboardModel(workspace string) BoilerplateDashboardModel {
 	mgr := boilerplate.NewManager(workspace)
-	home, _ := os.UserHomeDir()
-	tplMgr := boilerplate.NewTemplateManager(home)
+	configDir, err := config.Dir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		configDir = filepath.Join(home, ".devcli")
+	}
+	tplMgr := boilerplate.NewTemplateManager(configDir)
 
 	// 1. Main Menu
 	menuItems := []list.Item{
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/ai"
+	"github.com/phravins/devcli/internal/ai/providers"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// isTerminal reports whether f is attached to a terminal rather than a
+// pipe or file
+func isTerminal(f *os.File) bool {
+	st, err := f.Stat()
+	return err == nil && st.Mode()&os.ModeCharDevice != 0
+}
+
+// readPipedPrompt reads stdin when something is piped into it, as in
+// "cat spec.md | devcli ai chat". piped is false on a terminal and when
+// stdin is empty (e.g. </dev/null or a closed pipe under an IDE).
+func readPipedPrompt() (prompt string, piped bool, err error) {
+	if isTerminal(os.Stdin) {
+		return "", false, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", true, err
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", false, nil
+	}
+	return string(data), true, nil
+}
+
+// askOnce sends prompt as the only user message and prints the answer,
+// for scripts and pipelines
+func askOnce(prompt string) error {
+	cfg, _ := config.LoadConfig()
+	p, err := providers.GetProvider(cfg)
+	if err != nil {
+		return err
+	}
+	resp, err := p.Send([]ai.Message{{Role: "user", Content: prompt}})
+	if err != nil {
+		return err
+	}
+	fmt.Print(resp)
+	if !strings.HasSuffix(resp, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+// seed makes prompt the first user message; Init sends it
+func (m *ChatModel) seed(prompt string) {
+	if m.provider == nil {
+		m.err = fmt.Errorf("no AI provider available, check the AI settings")
+		return
+	}
+	m.messages = append(m.messages, ai.Message{Role: "user", Content: prompt})
+	m.loading = true
+}
+
+// seededInit sends the seeded prompt, if any, when the chat starts
+func (m ChatModel) seededInit() tea.Cmd {
+	if !m.loading {
+		return textarea.Blink
+	}
+	return tea.Batch(textarea.Blink, m.spinner.Tick, m.sendToAI(m.messages))
+}
@@ -23,6 +23,7 @@ type ChatModel struct {
 	textarea textarea.Model
 	spinner  spinner.Model
 	provider ai.Provider
+	cfg      *config.Config // AI profile in use (Alt+P switches)
 	messages []ai.Message
 	err      error
 	loading  bool
@@ -75,13 +76,14 @@ func NewChatModel() ChatModel {
 		viewport: vp,
 		spinner:  sp,
 		provider: p,
+		cfg:      cfg,
 		messages: []ai.Message{},
 		helpView: hv,
 	}
 }
 
 func (m ChatModel) Init() tea.Cmd {
-	return textarea.Blink
+	return m.seededInit()
 }
 
 type errMsg error
@@ -110,6 +112,11 @@ func (m ChatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.helpView.Width = msg.Width - 6
 		m.helpView.Height = msg.Height - 10
 
+		// A piped-in prompt arrives before the first size is known
+		if len(m.messages) > 0 {
+			m.renderMessages()
+		}
+
 	case tea.MouseMsg:
 		if m.showHelp {
 			m.helpView, cmd = m.helpView.Update(msg)
@@ -131,6 +138,16 @@ func (m ChatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if msg.String() == "alt+p" && !m.loading {
+			// The conversation so far goes to the new backend with the next message
+			p, err := switchAIProfile(m.cfg)
+			m.err = err
+			if err == nil {
+				m.provider = p
+			}
+			return m, nil
+		}
+
 		switch msg.Type {
 		case tea.KeyRunes:
 			if msg.String() == "?" {
@@ -264,7 +281,7 @@ func (m ChatModel) View() string {
 		Foreground(lipgloss.Color("#FFFFFF")).
 		Background(lipgloss.Color("#008069")). // WhatsApp Teal Header
 		Bold(true).
-		Render(fmt.Sprintf(" Devcli Chat :: %s (%s) ", m.provider.Name(), m.provider.Model()))
+		Render(fmt.Sprintf(" Devcli Chat :: %s (%s) [profile: %s] ", m.provider.Name(), m.provider.Model(), profileLabel(m.cfg)))
 
 	chatView := m.viewport.View()
 
@@ -280,10 +297,10 @@ func (m ChatModel) View() string {
 		footerContent = fmt.Sprintf("%s Generating response...", m.spinner.View())
 	} else if m.err != nil {
 		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
-		helpHint := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" [?] Help • [Esc] Quit")
+		helpHint := " " + renderKeyFooter(m.width-4, []keyHint{{"Enter", "Send"}, {"Alt+P", "Profile"}, {"?", "Help"}, {"Esc", "Quit"}})
 		footerContent = fmt.Sprintf("%s\n%s\n%s", errStyle.Render("Error: "+m.err.Error()), m.textarea.View(), helpHint)
 	} else {
-		helpHint := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" [?] Help • [Esc] Quit")
+		helpHint := " " + renderKeyFooter(m.width-4, []keyHint{{"Enter", "Send"}, {"Alt+P", "Profile"}, {"?", "Help"}, {"Esc", "Quit"}})
 		footerContent = m.textarea.View() + "\n" + helpHint
 	}
 
@@ -292,8 +309,19 @@ func (m ChatModel) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, header, chatView, footer)
 }
 
-func RunChat() {
-	p := tea.NewProgram(Wrap(NewChatModel()), tea.WithAltScreen(), tea.WithMouseCellMotion())
+// RunChat starts the chat TUI. A non-empty prompt is sent as the first
+// message. Keys are read from the terminal whenever stdin is not one, as
+// it held the prompt or was empty.
+func RunChat(prompt string) {
+	m := NewChatModel()
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if prompt != "" {
+		m.seed(prompt)
+	}
+	if !isTerminal(os.Stdin) {
+		opts = append(opts, tea.WithInputTTY())
+	}
+	p := tea.NewProgram(Wrap(m), opts...)
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running chat: %v\n", err)
 		os.Exit(1)
@@ -303,7 +331,39 @@ func RunChat() {
 var ChatCmd = &cobra.Command{
 	Use:   "chat",
 	Short: "Start AI chat session (TUI)",
+	Long: `Start an AI chat session. Text piped in becomes the first message:
+
+  cat spec.md | devcli ai chat            # chat about spec.md in the TUI
+  cat spec.md | devcli ai chat > plan.md  # print the answer and exit
+
+The answer is printed instead of opening the TUI when stdout is not a
+terminal, or with --print.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		RunChat()
+		printOnly, _ := cmd.Flags().GetBool("print")
+		prompt, piped, err := readPipedPrompt()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !piped {
+			if printOnly {
+				fmt.Fprintln(os.Stderr, "Error: --print needs a prompt piped in, e.g. cat spec.md | devcli ai chat --print")
+				os.Exit(1)
+			}
+			RunChat("")
+			return
+		}
+		if printOnly || !isTerminal(os.Stdout) {
+			if err := askOnce(prompt); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		RunChat(prompt)
 	},
 }
+
+func init() {
+	ChatCmd.Flags().BoolP("print", "p", false, "Print the answer to the piped prompt and exit instead of opening the TUI")
+}
@@ -36,22 +36,30 @@ func generateCommandsHelp() string {
 	addKey("↑ / ↓", "Move Up / Down")
 	addKey("Enter", "Select / Confirm")
 	addKey("Esc / q", "Go Back / Exit")
+	addKey("F1", "Show / Hide Key Footer")
 	cmds.WriteString("\n")
 
 	// 3. Project Tools
 	cmds.WriteString(sectionStyle.Render("PROJECT TOOLS:") + "\n")
 	addKey("b", "Backup Project (List)")
+	addKey("t", "Open Terminal in Project (List)")
 	addKey("d", "Delete History (History)")
 	cmds.WriteString("\n")
 
 	// 4. Dev Server
 	cmds.WriteString(sectionStyle.Render("DEV SERVER:") + "\n")
 	addKey("s", "Start/Stop Server")
+	addKey("R", "Restart Server")
+	addKey("c", "Edit Server Command (before start)")
+	addKey("v", "Set Port / Env Vars (before start)")
 	addKey("f", "Toggle Filters")
 	addKey("b", "Toggle Server Source (Fullstack)")
+	addKey("t", "Toggle Per-Server Tabs")
+	addKey("Tab", "Next Server Tab")
 	addKey("/", "Search Logs")
 	addKey("a", "Toggle Auto-scroll")
-	addKey("c", "Clear Logs")
+	addKey("c", "Clear Logs (while running)")
+	addKey("w", "Save Shown Logs to a File")
 	addKey("?", "Help & Documentation")
 	cmds.WriteString("\n")
 
@@ -69,7 +77,19 @@ func generateCommandsHelp() string {
 	addKey("Tab", "Toggle Global Search")
 	addKey("Alt+M", "Move/Rename File")
 	addKey("Alt+C", "Copy File")
+	addKey("Alt+D", "Delete File or Folder")
+	addKey("Alt+N", "New File (name/ for a Folder)")
+	addKey("Ctrl+R", "Stop Global Scan / Rescan")
 	addKey("Alt+E", "Edit File")
+	addKey("Alt+X", "Open with Default App")
+	addKey("Alt+T", "Cycle File Category")
+	addKey("Alt+O", "Cycle Sort Order")
+	addKey("Alt+.", "Show/Hide Hidden Files")
+	addKey("Alt+Up/Down", "Recall Recent Search")
+	addKey("Alt+G", "Copy Go Import Path")
+	addKey("Alt+H", "Hex View of the File")
+	addKey("Alt+F/Ctrl+G", "Search File Contents (grep)")
+	addKey("Alt+S", "Open Terminal Here")
 	cmds.WriteString("\n")
 
 	// 7. AI Chat
@@ -80,12 +100,44 @@ func generateCommandsHelp() string {
 
 	// 8. Editor Shortcuts
 	cmds.WriteString(sectionStyle.Render("EDITOR (Multi-Lang):") + "\n")
-	addKey("Ctrl+R", "Run Code")
+	addKey("Ctrl+R", "Run Code (Menu: Recent Files)")
+	addKey("Ctrl+K", "Stop Running Program")
 	addKey("Ctrl+S", "Save File")
 	addKey("Ctrl+N", "New File")
+	addKey("Ctrl+Z/Y", "Undo/Redo")
+	addKey("Ctrl+D", "Delete Line")
+	addKey("Alt+D", "Duplicate Line")
+	addKey("Ctrl+G", "Go to Line")
+	addKey("Home/End", "Line Start (Text, Then Column 0) / End")
+	addKey("Ctrl+←/→", "Previous/Next Word")
+	addKey("Ctrl+Home/End", "Start/End of Buffer")
+	addKey("Shift+Arrows", "Select Text")
+	addKey("Ctrl+C/X/V", "Copy/Cut/Paste Selection")
+	addKey("Ctrl+T", "Open File in New Tab")
+	addKey("Ctrl+W", "Close Tab")
+	addKey("Alt+I", "Insert File at Cursor")
+	addKey("Alt+S", "Program Input (stdin) for Runs")
+	addKey("Alt+N", "Rename Current File")
+	addKey("Alt+L", "Toggle LF/CRLF Line Endings")
+	addKey("Alt+G", "Copy Go Import Path")
+	addKey("Alt+C", "Copy Run Command")
+	addKey("Alt+P", "Python/Node REPL (sends marked lines)")
+	addKey("Alt+Left/Right", "Switch Tab")
+	addKey("Ctrl+L", "Clear Output")
+	addKey("Tab/Enter", "Cycle/Jump to Error Location (output focused)")
 	addKey("Ctrl+P", "Command Prompt")
+	addKey("Ctrl+]", "Jump to Matching Bracket")
+	addKey("Ctrl+/", "Toggle Line Comment")
+	addKey("Alt+F", "Format Document")
+	addKey("Ctrl+Space", "Set/Clear Mark")
+	addKey("Ctrl+B", "Column (Block) Selection")
+	addKey("Alt+R", "Run Marked Lines / Current Line")
+	addKey("Alt+E", "Run in External Terminal")
+	addKey("Alt+X", "Explain Last Error (AI)")
+	addKey("Ctrl+M", "Maximize/Restore Output (remembered)")
+	addKey("Ctrl+Up/Down", "Grow/Shrink Output Pane")
 	addKey("Ctrl+H", "Toggle Help")
-	addKey("Ctrl+C", "Exit Editor")
+	addKey("Ctrl+Q", "Exit Editor")
 
 	cmds.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("  Press Esc to go back"))
 
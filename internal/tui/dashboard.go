@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/keymap"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -15,6 +16,7 @@ import (
 
 type item struct {
 	id, title, desc string // Added id field
+	tags            string // Extra text for custom filters (e.g. a project's stack)
 }
 
 func (i item) Title() string       { return i.title }
@@ -31,6 +33,7 @@ type DashboardModel struct {
 	width        int
 	height       int
 	commandView  viewport.Model
+	warning      string // One-time notice (e.g. config was reset)
 }
 
 func NewDashboard() DashboardModel {
@@ -45,9 +48,19 @@ func NewDashboard() DashboardModel {
 		item{title: "🚪 Exit", desc: "Quit DevCLI"},
 	}
 
+	// Load config up-front so a corrupt file is recovered before any sub-model reads it
+	config.LoadConfig()
+
 	m := DashboardModel{
 		list:     list.New(items, list.NewDefaultDelegate(), 0, 0),
 		settings: NewSettingsModel(),
+		warning:  config.TakeWarning(),
+	}
+	if warnings := keymap.Check(config.GetString); len(warnings) > 0 && m.warning == "" {
+		m.warning = "Keymap: " + warnings[0]
+		if len(warnings) > 1 {
+			m.warning += fmt.Sprintf(" (and %d more)", len(warnings)-1)
+		}
 	}
 	m.list.SetShowTitle(false)
 
@@ -65,6 +78,9 @@ func (m DashboardModel) Init() tea.Cmd {
 func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Any key dismisses the one-time warning
+		m.warning = ""
+
 		if m.showCommands {
 			if msg.String() == "esc" || msg.String() == "q" {
 				m.showCommands = false
@@ -158,6 +174,11 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+	case settingsModelsMsg:
+		updatedSettings, cmd := m.settings.Update(msg)
+		m.settings = updatedSettings.(SettingsModel)
+		return m, cmd
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -222,6 +243,9 @@ func (m DashboardModel) View() string {
 		Render(config.Version)
 
 	centeredHeader := headerStyle.Render(logo + "\n" + title + "\n" + version)
+	if m.warning != "" {
+		centeredHeader += "\n" + headerStyle.Foreground(colorYellow).Render("⚠ "+m.warning)
+	}
 
 	// --- COMMANDS VIEW ---
 	if m.showCommands {
@@ -248,6 +272,7 @@ func (m DashboardModel) View() string {
 		centeredHeader,
 		"\n",
 		m.list.View(),
+		renderKeyFooter(m.width, []keyHint{{"↑/↓", "Navigate"}, {"Enter", "Select"}, {"/", "Filter"}, {"q", "Quit"}}),
 	)
 	availableHeight := m.height - 2
 	contentHeight := lipgloss.Height(contentView)
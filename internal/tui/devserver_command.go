@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/devserver"
+)
+
+// Per-project server settings edited on the ready screen: the command
+// ("c") and extra environment variables ("v", see devserver_env.go). Each
+// is a list of "<project path>\t<server name>\t<value>" entries; paths are
+// not used as config keys because viper splits keys on dots.
+const (
+	commandOverridesKey = "devserver.command_overrides"
+	envOverridesKey     = "devserver.env_overrides"
+)
+
+func serverSettingPrefix(project, server string) string {
+	if abs, err := filepath.Abs(project); err == nil {
+		project = abs
+	}
+	return project + "\t" + server + "\t"
+}
+
+// serverSetting returns the value saved under key for a server of project
+func serverSetting(key, project, server string) (string, bool) {
+	prefix := serverSettingPrefix(project, server)
+	for _, entry := range config.GetStringSlice(key) {
+		if value, ok := strings.CutPrefix(entry, prefix); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// saveServerSetting stores value under key for a server of project; an
+// empty value drops the entry
+func saveServerSetting(key, project, server, value string) error {
+	prefix := serverSettingPrefix(project, server)
+	var entries []string
+	for _, entry := range config.GetStringSlice(key) {
+		if !strings.HasPrefix(entry, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+	if value != "" {
+		entries = append(entries, prefix+value)
+	}
+	return config.SaveConfig(key, entries)
+}
+
+// applyServerSettings swaps the detected commands for the saved ones and
+// adds the saved environment variables. Broken entries are skipped.
+func (m *DevServerDashboardModel) applyServerSettings() {
+	m.detectedServers = append([]devserver.ServerConfig(nil), m.projectInfo.Servers...)
+	for i := range m.projectInfo.Servers {
+		srv := &m.projectInfo.Servers[i]
+		if line, ok := serverSetting(commandOverridesKey, m.projectPath, srv.Name); ok {
+			srv.SetCommandLine(line)
+		}
+		if line, ok := serverSetting(envOverridesKey, m.projectPath, srv.Name); ok {
+			if env, err := devserver.ParseEnvPairs(line); err == nil {
+				srv.Env = env
+			}
+		}
+	}
+	m.missingTools = m.projectInfo.MissingTools()
+}
+
+// isOverridden reports whether server i runs an edited command
+func (m DevServerDashboardModel) isOverridden(i int) bool {
+	return i < len(m.detectedServers) &&
+		m.projectInfo.Servers[i].CommandLine() != m.detectedServers[i].CommandLine()
+}
+
+// startCommandEdit opens the prompt for server i's command ("c") or, with
+// env set, its environment variables ("v")
+func (m *DevServerDashboardModel) startCommandEdit(i int, env bool) tea.Cmd {
+	m.commandServer = i
+	m.editEnv = env
+	if env {
+		m.commandInput.Placeholder = "e.g. PORT=4000 API_URL=http://localhost:9000"
+		m.commandInput.SetValue(devserver.FormatEnvPairs(m.projectInfo.Servers[i].Env))
+	} else {
+		m.commandInput.Placeholder = "e.g. pnpm dev --port 4000"
+		m.commandInput.SetValue(m.projectInfo.Servers[i].CommandLine())
+	}
+	m.commandInput.CursorEnd()
+	m.commandInput.Focus()
+	m.err = nil
+	m.state = StateDevServerCommandInput
+	return textinput.Blink
+}
+
+// updateCommandInput handles a key in the command/environment prompt.
+// Enter saves the value and moves on to the next server, if any; Esc
+// stops editing.
+func (m *DevServerDashboardModel) updateCommandInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.commandInput.Blur()
+		m.commandErr = nil
+		m.state = StateDevServerReady
+		return nil
+	case "enter":
+		line := strings.TrimSpace(m.commandInput.Value())
+		save := m.saveCommand
+		if m.editEnv {
+			save = m.saveEnv
+		}
+		if err := save(line); err != nil {
+			m.commandErr = err
+			return nil
+		}
+		m.commandErr = nil
+		m.missingTools = m.projectInfo.MissingTools()
+		if m.commandServer+1 < len(m.projectInfo.Servers) {
+			return m.startCommandEdit(m.commandServer+1, m.editEnv)
+		}
+		m.commandInput.Blur()
+		m.state = StateDevServerReady
+		if m.editEnv {
+			return notify("Environment saved")
+		}
+		return notify("Server command saved")
+	}
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return cmd
+}
+
+// saveCommand sets the edited server's command; an empty line or the
+// detected command drops the override
+func (m *DevServerDashboardModel) saveCommand(line string) error {
+	srv := &m.projectInfo.Servers[m.commandServer]
+	edited := *srv
+	if line == "" && m.commandServer < len(m.detectedServers) {
+		detected := m.detectedServers[m.commandServer] // Back to the detected command
+		edited.Cmd, edited.Args = detected.Cmd, detected.Args
+	} else if err := edited.SetCommandLine(line); err != nil {
+		return err
+	}
+	override := edited.CommandLine()
+	if m.commandServer < len(m.detectedServers) && override == m.detectedServers[m.commandServer].CommandLine() {
+		override = ""
+	}
+	if err := saveServerSetting(commandOverridesKey, m.projectPath, srv.Name, override); err != nil {
+		return err
+	}
+	*srv = edited
+	return nil
+}
+
+func (m DevServerDashboardModel) renderCommandInput() string {
+	srv := m.projectInfo.Servers[m.commandServer]
+	what, label := "command", "Command to run:"
+	if m.editEnv {
+		what, label = "environment", "Environment (KEY=VALUE, space separated):"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true).Render("Server " + strings.ToUpper(what[:1]) + what[1:])
+	if len(m.projectInfo.Servers) > 1 {
+		label = fmt.Sprintf("%s %s (%d/%d):", srv.Name, what, m.commandServer+1, len(m.projectInfo.Servers))
+	}
+	inputBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("141")).
+		Padding(0, 1).
+		Width(64).
+		Render(m.commandInput.View())
+
+	lines := []string{title, "", lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(label), inputBox}
+	if m.editEnv {
+		lines = append(lines, subtleStyle.Render("Set over your shell and .env. PORT is honoured by most Node frameworks;\nfor others add a port flag to the command (c)."))
+	} else if m.commandServer < len(m.detectedServers) {
+		lines = append(lines, subtleStyle.Render("Detected: "+m.detectedServers[m.commandServer].CommandLine()+" (clear the line to use it again)"))
+	}
+	if m.commandErr != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(m.commandErr.Error()))
+	}
+	lines = append(lines, "", subtleStyle.Render("Saved for "+m.projectPath), "",
+		renderKeyFooter(0, []keyHint{{"Enter", "Save"}, {"Esc", "Cancel"}}))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("141")).
+		Padding(2, 4).
+		Width(75).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
 	"github.com/phravins/devcli/internal/devserver"
+	"github.com/phravins/devcli/pkg/utils"
 )
 
 type DevServerDashboardModel struct {
@@ -30,6 +32,28 @@ type DevServerDashboardModel struct {
 	err                 error
 	pendingAction       string // Stores the action waiting for confirmation
 	confirmationMessage string // Message to display in confirmation dialog
+
+	serverURL    string   // First local URL a server announced, for "o"
+	missingTools []string // Server commands not on PATH, found at detection
+	makeCursor   int      // Selected Makefile target, -1 for none
+
+	// Command override ("c" on the ready screen), saved per project
+	detectedServers []devserver.ServerConfig // Servers as Detect found them
+	commandInput    textinput.Model
+	commandServer   int  // Index of the server being edited
+	editEnv         bool // The prompt edits environment variables, not the command
+	commandErr      error
+
+	restarting bool // Stopping for a restart ("R"), not for good
+
+	// Result of the last log export ("w"), shown in the running view
+	exportNote string
+	exportErr  bool
+
+	// Tabbed mode: one log view per server instead of the merged view
+	tabbed    bool
+	tabs      []serverTab
+	activeTab int
 }
 
 type logEntry struct {
@@ -48,6 +72,7 @@ const (
 	StateDevServerConfirmation // Confirmation dialog state
 	StateDevServerStopping     // Server stopping state
 	StateDevServerHelp
+	StateDevServerCommandInput // Editing a server's command
 )
 
 type detectDoneMsg struct {
@@ -55,8 +80,11 @@ type detectDoneMsg struct {
 	err  error
 }
 
+// logReceivedMsg and tickMsg carry the runner they came from, so waiting
+// on a runner that a restart replaced stops instead of being renewed
 type logReceivedMsg struct {
-	log devserver.LogLine
+	log    devserver.LogLine
+	runner *devserver.Runner
 }
 
 type serverStoppedMsg struct{}
@@ -80,6 +108,10 @@ func NewDevServerDashboardModel(projectPath string) DevServerDashboardModel {
 	pi.Width = 62
 	pi.Focus() // Focus the input immediately
 
+	ci := textinput.New()
+	ci.CharLimit = 500
+	ci.Width = 60
+
 	// Initialize help viewport
 	hv := viewport.New(80, 20)
 	hv.Style = lipgloss.NewStyle().
@@ -95,6 +127,7 @@ func NewDevServerDashboardModel(projectPath string) DevServerDashboardModel {
 		helpView:     hv,
 		searchInput:  ti,
 		pathInput:    pi,
+		commandInput: ci,
 		logs:         make([]logEntry, 0),
 		filterMode:   "all",
 		serverFilter: "all",
@@ -134,15 +167,17 @@ func waitForLogCmd(runner *devserver.Runner) tea.Cmd {
 				// Channel closed, server stopped
 				return nil
 			}
-			return logReceivedMsg{log: log}
+			return logReceivedMsg{log: log, runner: runner}
 		case <-time.After(100 * time.Millisecond):
 			// Timeout - return a tick message to check again
-			return tickMsg{}
+			return tickMsg{runner: runner}
 		}
 	}
 }
 
-type tickMsg struct{}
+type tickMsg struct {
+	runner *devserver.Runner
+}
 
 func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -174,6 +209,7 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if path == "" {
 					path = m.projectPath
 				}
+				rememberPath(pathDevServer, path)
 				m.projectPath = path
 				m.state = StateDevServerDetecting
 				return m, detectProjectCmd(path)
@@ -182,6 +218,10 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.state == StateDevServerCommandInput {
+			return m, m.updateCommandInput(msg)
+		}
+
 		// Handle search input when focused - but allow Esc to unfocus
 		if m.searchInput.Focused() {
 			switch msg.String() {
@@ -219,116 +259,142 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Leaving mid-restart would orphan the servers the restart is
+		// about to start, so wait for serverRestartedMsg
+		if m.restarting {
+			switch msg.String() {
+			case "ctrl+c", "q", "esc":
+				return m, notify("Restarting, please wait")
+			}
+		}
+
 		// Handle main keyboard shortcuts
 		switch msg.String() {
 		case "ctrl+c", "q":
-			if m.runner != nil {
-				m.runner.Stop()
-			}
+			// The servers keep running until the quit is confirmed; the
+			// quit guard stops them then
 			return m, tea.Quit
 		case "esc":
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before stopping and going back
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "back"
-				m.confirmationMessage = "Stop the server and go back?"
-				m.confirmationMessage = "Stop the server and go back?"
-				return m, nil
+				return m.confirmAction("back", "Stop the server and go back?")
 			} else {
 				return m, func() tea.Msg { return DevServerBackMsg{} }
 			}
 		case "?":
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before showing help
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "help"
-				m.confirmationMessage = "Show help?"
-				m.confirmationMessage = "Show help?"
-				return m, nil
+				return m.confirmAction("help", "Show help?")
 			}
 			m.showHelp = !m.showHelp
 			return m, nil
 		case "s":
+			if m.state == StateDevServerReady && len(m.projectInfo.Servers) == 0 {
+				return m, nil // A Makefile target has to be picked first
+			}
 			if m.state == StateDevServerReady {
 				m.runner = devserver.NewRunner()
+				m.runner.LoadEnvFile = loadEnvFileEnabled()
 				if err := m.runner.Start(m.projectInfo); err != nil {
 					m.err = err
 					return m, nil
 				} else {
 					m.state = StateDevServerRunning
+					m.serverURL = ""
+					m.exportNote = ""
+					m.initServerTabs()
 					return m, waitForLogCmd(m.runner)
 				}
 			} else if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before stopping
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "stop"
-				m.confirmationMessage = "Stop the server?"
-				m.pendingAction = "stop"
-				m.confirmationMessage = "Stop the server?"
-				return m, nil
+				return m.confirmAction("stop", "Stop the server?")
 			}
 			return m, nil
-		case "f":
+		case "R":
+			if m.state == StateDevServerRunning && m.runner != nil {
+				return m.confirmAction("restart", "Restart the server?")
+			}
+			return m, nil
+		case "w":
+			// Saving a copy of the logs is harmless too
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before changing filter
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "filter"
-				m.confirmationMessage = "Change filter mode?"
-				m.pendingAction = "filter"
-				m.confirmationMessage = "Change filter mode?"
+				m.exportLogs()
+			}
+			return m, nil
+		case "o":
+			// Opening the browser is harmless, so no confirmation
+			if m.state == StateDevServerRunning && m.serverURL != "" {
+				utils.OpenBrowser(m.serverURL)
+			}
+			return m, nil
+		case "e":
+			if m.state == StateDevServerReady {
+				toggleEnvFileLoading()
 				return m, nil
 			}
+		case "v":
+			if m.state == StateDevServerReady && len(m.projectInfo.Servers) > 0 {
+				return m, m.startCommandEdit(0, true)
+			}
+		case "f":
+			if m.state == StateDevServerRunning && m.runner != nil {
+				return m.confirmAction("filter", "Change filter mode?")
+			}
 			return m, nil
 		case "b":
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before changing server filter
 				if m.projectInfo.Type == devserver.TypeFullstack {
-					m.state = StateDevServerConfirmation
-					m.pendingAction = "source"
-					m.confirmationMessage = "Change server source filter?"
-					m.pendingAction = "source"
-					m.confirmationMessage = "Change server source filter?"
-					return m, nil
+					return m.confirmAction("source", "Change server source filter?")
 				}
 			}
 			return m, nil
 		case "a":
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before toggling auto-scroll
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "autoscroll"
-				m.confirmationMessage = "Toggle auto-scroll?"
-				m.pendingAction = "autoscroll"
-				m.confirmationMessage = "Toggle auto-scroll?"
-				return m, nil
+				return m.confirmAction("autoscroll", "Toggle auto-scroll?")
 			}
 			return m, nil
 		case "c":
+			if m.state == StateDevServerReady && len(m.projectInfo.Servers) > 0 {
+				return m, m.startCommandEdit(0, false)
+			}
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before clearing logs
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "clear"
-				m.confirmationMessage = "Clear all logs?"
-				m.pendingAction = "clear"
-				m.confirmationMessage = "Clear all logs?"
-				return m, nil
+				return m.confirmAction("clear", "Clear all logs?")
 			}
 			return m, nil
 		case "/":
 			if m.state == StateDevServerRunning && m.runner != nil {
-				// Ask for confirmation before opening search
-				m.state = StateDevServerConfirmation
-				m.pendingAction = "search"
-				m.confirmationMessage = "Open search?"
-				m.pendingAction = "search"
-				m.confirmationMessage = "Open search?"
-				return m, nil
+				return m.confirmAction("search", "Open search?")
+			}
+			return m, nil
+		case "t":
+			if m.state == StateDevServerRunning && m.runner != nil && len(m.tabs) > 1 {
+				if m.tabbed {
+					return m.confirmAction("tabs", "Switch to the merged log view?")
+				}
+				return m.confirmAction("tabs", "Switch to one tab per server?")
+			}
+			return m, nil
+		case "tab", "shift+tab":
+			// Cycle server tabs; no confirmation, like scrolling
+			if m.state == StateDevServerRunning && m.tabbed && len(m.tabs) > 0 {
+				if msg.String() == "tab" {
+					m.activeTab = (m.activeTab + 1) % len(m.tabs)
+				} else {
+					m.activeTab = (m.activeTab - 1 + len(m.tabs)) % len(m.tabs)
+				}
 			}
 			return m, nil
 		case "up", "down", "pgup", "pgdown", "home", "end":
+			if m.state == StateDevServerReady && len(m.projectInfo.MakeTargets) > 0 {
+				switch msg.String() {
+				case "up":
+					m.moveMakeTarget(-1)
+				case "down":
+					m.moveMakeTarget(1)
+				}
+				return m, nil
+			}
 			// These keys are for viewport scrolling only when running
 			if m.state == StateDevServerRunning && m.runner != nil {
-				m.logView, cmd = m.logView.Update(msg)
+				view := m.activeView()
+				*view, cmd = view.Update(msg)
 				return m, cmd
 			}
 			return m, nil
@@ -336,19 +402,27 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case detectDoneMsg:
 		m.projectInfo = msg.info
+		m.applyServerSettings()
+		m.initMakeTarget()
 		m.err = msg.err
 		if msg.err == nil {
 			m.state = StateDevServerReady
 		}
 
+	case serverRestartedMsg:
+		return m.handleRestarted(msg)
+
 	case serverStoppedMsg:
 		m.state = StateDevServerReady
 		m.runner = nil
-		return m, nil
+		return m, notify("Server stopped")
 
 	case logReceivedMsg:
 		timestamp := time.Now().Format("15:04:05")
 		isWarning := strings.Contains(strings.ToLower(msg.log.Line), "warn")
+		if m.serverURL == "" {
+			m.serverURL = msg.log.URL
+		}
 
 		m.logs = append(m.logs, logEntry{
 			timestamp:  timestamp,
@@ -361,17 +435,22 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateLogView()
 		if m.autoScroll {
 			m.logView.GotoBottom()
+			for i := range m.tabs {
+				if m.tabs[i].name == msg.log.ServerName {
+					m.tabs[i].view.GotoBottom()
+				}
+			}
 		}
 
 		// Only continue waiting if runner is still valid and server is running/stopping/confirming
-		if (m.state == StateDevServerRunning || m.state == StateDevServerConfirmation || m.state == StateDevServerStopping) && m.runner != nil {
+		if (m.state == StateDevServerRunning || m.state == StateDevServerConfirmation || m.state == StateDevServerStopping) && m.runner != nil && msg.runner == m.runner {
 			return m, waitForLogCmd(m.runner)
 		}
 		return m, nil
 
 	case tickMsg:
 		// Timeout occurred while waiting for logs, continue waiting if still active
-		if (m.state == StateDevServerRunning || m.state == StateDevServerConfirmation || m.state == StateDevServerStopping) && m.runner != nil {
+		if (m.state == StateDevServerRunning || m.state == StateDevServerConfirmation || m.state == StateDevServerStopping) && m.runner != nil && msg.runner == m.runner {
 			return m, waitForLogCmd(m.runner)
 		}
 		return m, nil
@@ -384,7 +463,8 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Pass to log view mainly, or list logic if we implemented a list
 		var cmd tea.Cmd
-		m.logView, cmd = m.logView.Update(msg)
+		view := m.activeView()
+		*view, cmd = view.Update(msg)
 		return m, cmd
 
 	case tea.WindowSizeMsg:
@@ -393,6 +473,7 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.logView.Width = msg.Width - 4    // Full width minus small padding
 		m.logView.Height = msg.Height - 14 // Increased padding for header
+		m.resizeServerTabs()
 
 		// Resize help view
 		m.helpView.Width = msg.Width - 8
@@ -410,62 +491,91 @@ func (m DevServerDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *DevServerDashboardModel) updateLogView() {
-	var content strings.Builder
 	searchTerm := strings.ToLower(m.searchInput.Value())
 
+	var content strings.Builder
 	for _, log := range m.logs {
-		// Apply filters
-		if m.filterMode == "errors" && !log.isError {
+		// Apply server filter
+		if m.serverFilter != "all" && !strings.Contains(strings.ToLower(log.serverName), m.serverFilter) {
 			continue
 		}
-		if m.filterMode == "warnings" && !log.isWarning {
-			continue
+		if logMatches(log, m.filterMode, searchTerm) {
+			content.WriteString(formatLogEntry(log, searchTerm))
 		}
+	}
+	m.logView.SetContent(content.String())
 
-		// Apply server filter
-		if m.serverFilter != "all" {
-			if m.serverFilter == "backend" && !strings.Contains(strings.ToLower(log.serverName), "backend") {
-				continue
+	// Route each server's lines into its own tab
+	for i := range m.tabs {
+		var tabContent strings.Builder
+		for _, log := range m.logs {
+			if log.serverName == m.tabs[i].name && logMatches(log, m.tabs[i].filterMode, searchTerm) {
+				tabContent.WriteString(formatLogEntry(log, searchTerm))
 			}
-			if m.serverFilter == "frontend" && !strings.Contains(strings.ToLower(log.serverName), "frontend") {
-				continue
-			}
-		}
-
-		// Apply search filter
-		if searchTerm != "" && !strings.Contains(strings.ToLower(log.line), searchTerm) {
-			continue
 		}
+		m.tabs[i].view.SetContent(tabContent.String())
+	}
+}
 
-		// Format log line
-		var lineStyle lipgloss.Style
-		if log.isError {
-			lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red
-		} else if log.isWarning {
-			lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("226")) // Yellow
-		} else {
-			lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255")) // White
-		}
+// logMatches applies the severity filter and search term to a log entry
+func logMatches(log logEntry, filterMode, searchTerm string) bool {
+	if filterMode == "errors" && !log.isError {
+		return false
+	}
+	if filterMode == "warnings" && !log.isWarning {
+		return false
+	}
+	return searchTerm == "" || strings.Contains(strings.ToLower(log.line), searchTerm)
+}
 
-		serverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true) // Purple
-		timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))              // Gray
+func formatLogEntry(log logEntry, searchTerm string) string {
+	var lineStyle lipgloss.Style
+	if log.isError {
+		lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red
+	} else if log.isWarning {
+		lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("226")) // Yellow
+	} else {
+		lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255")) // White
+	}
 
-		formattedLine := fmt.Sprintf("%s [%s] %s\n",
-			timeStyle.Render(log.timestamp),
-			serverStyle.Render(log.serverName),
-			lineStyle.Render(log.line),
-		)
+	serverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true) // Purple
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))              // Gray
 
-		// Highlight search term
-		if searchTerm != "" {
-			highlightStyle := lipgloss.NewStyle().Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
-			formattedLine = strings.ReplaceAll(formattedLine, searchTerm, highlightStyle.Render(searchTerm))
-		}
+	formattedLine := fmt.Sprintf("%s [%s] %s\n",
+		timeStyle.Render(log.timestamp),
+		serverStyle.Render(log.serverName),
+		lineStyle.Render(log.line),
+	)
 
-		content.WriteString(formattedLine)
+	// Highlight search term
+	if searchTerm != "" {
+		highlightStyle := lipgloss.NewStyle().Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+		formattedLine = strings.ReplaceAll(formattedLine, searchTerm, highlightStyle.Render(searchTerm))
 	}
+	return formattedLine
+}
 
-	m.logView.SetContent(content.String())
+// When set to true, every running-view action asks first, not just those
+// in destructiveActions
+const confirmActionsKey = "devserver.confirm_actions"
+
+// destructiveActions always ask for confirmation: they stop the server
+var destructiveActions = map[string]bool{"stop": true, "back": true}
+
+func confirmAllActions() bool {
+	return config.GetString(confirmActionsKey) == "true"
+}
+
+// confirmAction runs a running-view action, asking first when it stops the
+// server or devserver.confirm_actions is on
+func (m DevServerDashboardModel) confirmAction(action, message string) (tea.Model, tea.Cmd) {
+	m.pendingAction = action
+	if destructiveActions[action] || confirmAllActions() {
+		m.state = StateDevServerConfirmation
+		m.confirmationMessage = message
+		return m, nil
+	}
+	return m.executePendingAction()
 }
 
 // executePendingAction executes the action that was confirmed by the user
@@ -486,21 +596,29 @@ func (m DevServerDashboardModel) executePendingAction() (DevServerDashboardModel
 	case "filter":
 		// Cycle through filter modes
 		m.state = StateDevServerRunning
-		switch m.filterMode {
+		filterMode := m.currentFilter()
+		switch *filterMode {
 		case "all":
-			m.filterMode = "errors"
+			*filterMode = "errors"
 		case "errors":
-			m.filterMode = "warnings"
+			*filterMode = "warnings"
 		case "warnings":
-			m.filterMode = "all"
+			*filterMode = "all"
 		}
 		m.updateLogView()
 		return m, nil
 
+	case "tabs":
+		// Toggle between the merged view and one tab per server
+		m.state = StateDevServerRunning
+		m.tabbed = !m.tabbed
+		m.updateLogView()
+		return m, nil
+
 	case "source":
 		// Toggle server filter (for fullstack)
 		m.state = StateDevServerRunning
-		if m.projectInfo.Type == devserver.TypeFullstack {
+		if m.projectInfo.Type == devserver.TypeFullstack && !m.tabbed {
 			switch m.serverFilter {
 			case "all":
 				m.serverFilter = "backend"
@@ -544,6 +662,12 @@ func (m DevServerDashboardModel) executePendingAction() (DevServerDashboardModel
 		m.state = StateDevServerStopping
 		return m, stopServerCmd(m.runner)
 
+	case "restart":
+		// Stop and start again with the same servers, asynchronously
+		m.state = StateDevServerStopping
+		m.restarting = true
+		return m, restartServerCmd(m.runner, m.projectInfo)
+
 	default:
 		// Unknown action, just return to running state
 		m.state = StateDevServerRunning
@@ -575,6 +699,8 @@ func (m DevServerDashboardModel) View() string {
 		content = m.renderRunning() // Reuse running view, status will show stopping
 	case StateDevServerConfirmation:
 		content = m.renderConfirmation()
+	case StateDevServerCommandInput:
+		content = m.renderCommandInput()
 	default:
 		content = "Unknown state"
 	}
@@ -617,7 +743,7 @@ func (m DevServerDashboardModel) renderPathInput() string {
 		Foreground(lipgloss.Color("255")).
 		Render(fmt.Sprintf("Current: %s", m.projectPath))
 
-	helpText := subtleStyle.Render("[Enter] Scan This Path • [Esc] Back")
+	helpText := renderKeyFooter(0, []keyHint{{"Enter", "Scan This Path"}, {"Esc", "Back"}})
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -695,29 +821,9 @@ func (m DevServerDashboardModel) renderReady() string {
 	// Show what was found (detection method)
 	var detectionMethod string
 	if len(m.projectInfo.Servers) > 0 {
-		switch m.projectInfo.Type {
-		case devserver.TypeDjango:
-			detectionMethod = "Found: manage.py"
-		case devserver.TypeFastAPI:
-			detectionMethod = "Found: main.py + fastapi import"
-		case devserver.TypeReact:
-			detectionMethod = "Found: package.json (React)"
-		case devserver.TypeVite:
-			detectionMethod = "Found: vite.config.js"
-		case devserver.TypeWebpack:
-			detectionMethod = "Found: webpack.config.js"
-		case devserver.TypeSpring:
-			detectionMethod = "Found: pom.xml"
-		case devserver.TypeNode:
-			detectionMethod = "Found: package.json"
-		case devserver.TypePython:
-			detectionMethod = "Found: Python project files"
-		case devserver.TypeGo:
-			detectionMethod = "Found: go.mod"
-		case devserver.TypeFullstack:
-			detectionMethod = "Found: backend/ + frontend/ folders"
-		default:
-			detectionMethod = "Project detected"
+		detectionMethod = "Project detected"
+		if marker := m.projectInfo.Type.Info().Marker; marker != "" {
+			detectionMethod = "Found: " + marker
 		}
 	}
 
@@ -736,30 +842,56 @@ func (m DevServerDashboardModel) renderReady() string {
 			Foreground(lipgloss.Color("226")). // Yellow
 			Bold(true)
 
+		custom := ""
+		if m.isOverridden(i) {
+			custom = subtleStyle.Render(" (custom)")
+		}
 		if len(m.projectInfo.Servers) > 1 {
-			commandInfo.WriteString(fmt.Sprintf("  %s: %s\n",
+			commandInfo.WriteString(fmt.Sprintf("  %s: %s%s\n",
 				lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Render(srv.Name),
-				cmdStyle.Render(fmt.Sprintf("%s %s", srv.Cmd, strings.Join(srv.Args, " "))),
+				cmdStyle.Render(srv.CommandLine()), custom,
 			))
 		} else {
-			commandInfo.WriteString(fmt.Sprintf("  %s\n",
-				cmdStyle.Render(fmt.Sprintf("%s %s", srv.Cmd, strings.Join(srv.Args, " "))),
+			commandInfo.WriteString(fmt.Sprintf("  %s%s\n",
+				cmdStyle.Render(srv.CommandLine()), custom,
 			))
 		}
+		if len(srv.Env) > 0 {
+			commandInfo.WriteString(subtleStyle.Render("  with "+devserver.FormatEnvPairs(srv.Env)) + "\n")
+		}
 
 		if i < len(m.projectInfo.Servers)-1 {
 			commandInfo.WriteString("\n")
 		}
 	}
 
+	if len(m.projectInfo.MakeTargets) > 0 {
+		commandInfo.WriteString("\n" + m.renderMakeTargets() + "\n")
+	}
+
+	envInfo := m.renderEnvFiles()
+	if missing := m.renderMissingTools(); missing != "" {
+		envInfo = lipgloss.JoinVertical(lipgloss.Left, missing, "", envInfo)
+	}
+
 	// Big "Just press Start" instruction
 	startInstruction := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("46")).
 		Bold(true).
 		Render("Just press [s] to Start!")
+	if len(m.projectInfo.Servers) == 0 {
+		startInstruction = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("226")).
+			Bold(true).
+			Render("No dev target found: pick one with ↑/↓, then press [s]")
+	}
 
 	// Help text
-	helpText := subtleStyle.Render("[s] Start • [?] Help • [Esc] Back")
+	hints := []keyHint{{"s", "Start"}, {"c", "Edit Command"}, {"v", "Env/Port"}, {"e", "Toggle .env"}, {"?", "Help"}, {"Esc", "Back"}}
+	if len(m.projectInfo.MakeTargets) > 0 {
+		hints = append([]keyHint{{"↑/↓", "Target"}}, hints...)
+	}
+	helpText := renderKeyFooter(0, hints)
 
 	// Assemble content
 	content := lipgloss.JoinVertical(lipgloss.Left,
@@ -771,6 +903,7 @@ func (m DevServerDashboardModel) renderReady() string {
 		"",
 		commandInfo.String(),
 		"",
+		envInfo,
 		"",
 		startInstruction,
 		"",
@@ -804,11 +937,21 @@ func (m DevServerDashboardModel) renderRunning() string {
 		Bold(true).
 		Render(fmt.Sprintf("Status: %s Running", statusIcon))
 
+	if m.serverURL != "" {
+		status += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("   " + m.serverURL + " (o to open)")
+	}
+	if port := m.effectivePort(); port != "" {
+		status += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("   Port: " + port)
+	}
+
 	if m.state == StateDevServerStopping {
 		status = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("208")). // Orange
 			Bold(true).
 			Render("Status:  Stopping...")
+		if m.restarting {
+			status = lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Render("Status:  Restarting...")
+		}
 	}
 
 	// Filters
@@ -817,8 +960,9 @@ func (m DevServerDashboardModel) renderRunning() string {
 
 	var filterButtons []string
 	filters := []string{"All", "Errors", "Warnings"}
+	filterMode := *m.currentFilter()
 	for _, f := range filters {
-		if strings.ToLower(f) == m.filterMode {
+		if strings.ToLower(f) == filterMode {
 			filterButtons = append(filterButtons, activeFilterStyle.Render("[ "+f+" ]"))
 		} else {
 			filterButtons = append(filterButtons, filterStyle.Render("[ "+f+" ]"))
@@ -831,7 +975,12 @@ func (m DevServerDashboardModel) renderRunning() string {
 
 	// Server filter (only for fullstack)
 	var serverFilterLine string
-	if m.projectInfo.Type == devserver.TypeFullstack {
+	logView := m.logView.View()
+	if m.tabbed {
+		// The tab bar replaces the source filter
+		serverFilterLine = "Server:   " + m.renderServerTabBar()
+		logView = m.tabs[m.activeTab].view.View()
+	} else if m.projectInfo.Type == devserver.TypeFullstack {
 		var serverButtons []string
 		servers := []string{"All", "Backend", "Frontend"}
 		for _, s := range servers {
@@ -862,9 +1011,8 @@ func (m DevServerDashboardModel) renderRunning() string {
 
 	// Footer
 	footer := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
 		MarginTop(1).
-		Render("[s] Stop • [f] Filter • [b] Source • [/] Search • [a] Auto-scroll • [c] Clear • [?] Help • [Esc] Back")
+		Render(renderKeyFooter(m.width, devServerRunningKeyHints))
 
 	// Assemble
 	var content string
@@ -877,10 +1025,10 @@ func (m DevServerDashboardModel) renderRunning() string {
 			filterLine,
 			serverFilterLine,
 			searchLine,
-			scrollIndicator,
+			scrollIndicator+m.renderExportNote(),
 			"",
 			"",
-			m.logView.View(),
+			logView,
 			"",
 			footer,
 			"",
@@ -893,10 +1041,10 @@ func (m DevServerDashboardModel) renderRunning() string {
 			"",
 			filterLine,
 			searchLine,
-			scrollIndicator,
+			scrollIndicator+m.renderExportNote(),
 			"",
 			"",
-			m.logView.View(),
+			logView,
 			"",
 			footer,
 			"",
@@ -906,6 +1054,39 @@ func (m DevServerDashboardModel) renderRunning() string {
 	return docStyle.Render(content)
 }
 
+var devServerRunningKeyHints = []keyHint{
+	{"s", "Stop"},
+	{"R", "Restart"},
+	{"f", "Filter"},
+	{"b", "Source"},
+	{"t", "Tabs"},
+	{"Tab", "Next Server"},
+	{"o", "Open URL"},
+	{"/", "Search"},
+	{"a", "Auto-scroll"},
+	{"c", "Clear"},
+	{"w", "Save Logs"},
+	{"?", "Help"},
+	{"Esc", "Back"},
+}
+
+// renderMissingTools warns about server commands that are not installed,
+// with an install hint where one is known
+func (m DevServerDashboardModel) renderMissingTools() string {
+	var notes []string
+	for _, tool := range m.missingTools {
+		note := InstallHint(tool)
+		if note == "" {
+			note = fmt.Sprintf("%q was not found on PATH", tool)
+		}
+		notes = append(notes, note)
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render(strings.Join(notes, "\n"))
+}
+
 func (m DevServerDashboardModel) renderConfirmation() string {
 	// Create confirmation dialog overlay
 	confirmTitle := lipgloss.NewStyle().
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/devserver"
+)
+
+// Whether the dev server loads each server's .env (on unless set to false)
+const loadEnvFileKey = "devserver.load_env"
+
+func loadEnvFileEnabled() bool {
+	return config.GetString(loadEnvFileKey) != "false"
+}
+
+func toggleEnvFileLoading() {
+	config.SaveConfig(loadEnvFileKey, !loadEnvFileEnabled())
+}
+
+// renderEnvFiles lists the .env file each server will load, for the ready
+// screen
+func (m DevServerDashboardModel) renderEnvFiles() string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Render(".env:")
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if !loadEnvFileEnabled() {
+		return label + " " + dim.Render("loading disabled (press e to enable)")
+	}
+
+	var lines []string
+	for _, srv := range m.projectInfo.Servers {
+		path := devserver.EnvFile(srv.Dir)
+		if path == "" {
+			continue
+		}
+		desc := filepath.Join(filepath.Base(srv.Dir), devserver.EnvFileName)
+		if vars, err := devserver.ParseEnvFile(path); err != nil {
+			desc += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("("+err.Error()+")")
+		} else {
+			desc += dim.Render(fmt.Sprintf(" (%d vars)", len(vars)))
+		}
+		if len(m.projectInfo.Servers) > 1 {
+			desc = srv.Name + ": " + desc
+		}
+		lines = append(lines, "  "+desc)
+	}
+	if len(lines) == 0 {
+		return label + " " + dim.Render("none found")
+	}
+	return label + "\n" + strings.Join(lines, "\n")
+}
+
+// saveEnv sets the edited server's extra environment variables; an empty
+// line removes them
+func (m *DevServerDashboardModel) saveEnv(line string) error {
+	env, err := devserver.ParseEnvPairs(line)
+	if err != nil {
+		return err
+	}
+	srv := &m.projectInfo.Servers[m.commandServer]
+	if err := saveServerSetting(envOverridesKey, m.projectPath, srv.Name, devserver.FormatEnvPairs(env)); err != nil {
+		return err
+	}
+	srv.Env = env
+	return nil
+}
+
+// effectivePort is the port shown in the running view: the PORT given to a
+// server, else the port of the URL it announced
+func (m DevServerDashboardModel) effectivePort() string {
+	for _, srv := range m.projectInfo.Servers {
+		if port := srv.Port(); port != "" {
+			return port
+		}
+	}
+	if u, err := url.Parse(m.serverURL); err == nil {
+		return u.Port()
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// visibleLogs returns the log entries the current view shows: the active
+// tab's server and filter in tabbed mode, otherwise the source and
+// severity filters, narrowed by the search term either way
+func (m DevServerDashboardModel) visibleLogs() []logEntry {
+	searchTerm := strings.ToLower(m.searchInput.Value())
+	var entries []logEntry
+	for _, log := range m.logs {
+		if m.tabbed && m.activeTab < len(m.tabs) {
+			tab := m.tabs[m.activeTab]
+			if log.serverName != tab.name || !logMatches(log, tab.filterMode, searchTerm) {
+				continue
+			}
+		} else {
+			if m.serverFilter != "all" && !strings.Contains(strings.ToLower(log.serverName), m.serverFilter) {
+				continue
+			}
+			if !logMatches(log, m.filterMode, searchTerm) {
+				continue
+			}
+		}
+		entries = append(entries, log)
+	}
+	return entries
+}
+
+// exportLogs writes the visible log lines ("w") to a timestamped file in
+// the project folder and reports where they went
+func (m *DevServerDashboardModel) exportLogs() {
+	path, n, err := m.writeLogFile(time.Now())
+	if err != nil {
+		m.exportNote = fmt.Sprintf("Could not save logs: %v", err)
+		m.exportErr = true
+		return
+	}
+	m.exportNote = fmt.Sprintf("Saved %d lines to %s", n, path)
+	m.exportErr = false
+}
+
+func (m DevServerDashboardModel) writeLogFile(now time.Time) (string, int, error) {
+	entries := m.visibleLogs()
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s dev server log for %s, saved %s\n", m.projectInfo.Type, m.projectPath, now.Format("2006-01-02 15:04:05"))
+	if filters := m.exportFilters(); filters != "" {
+		fmt.Fprintf(&out, "# Filtered: %s\n", filters)
+	}
+	for _, log := range entries {
+		fmt.Fprintf(&out, "%s [%s] %s\n", log.timestamp, log.serverName, log.line)
+	}
+
+	path := filepath.Join(m.projectPath, "devserver-"+now.Format("2006-01-02-150405")+".log")
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return "", 0, err
+	}
+	return path, len(entries), nil
+}
+
+// exportFilters describes the filters applied to an export, "" for none
+func (m DevServerDashboardModel) exportFilters() string {
+	var parts []string
+	filterMode := m.filterMode
+	if m.tabbed && m.activeTab < len(m.tabs) {
+		parts = append(parts, "server "+m.tabs[m.activeTab].name)
+		filterMode = m.tabs[m.activeTab].filterMode
+	} else if m.serverFilter != "all" {
+		parts = append(parts, "source "+m.serverFilter)
+	}
+	if filterMode != "all" {
+		parts = append(parts, filterMode+" only")
+	}
+	if term := m.searchInput.Value(); term != "" {
+		parts = append(parts, fmt.Sprintf("search %q", term))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderExportNote is the running view's line about the last export
+func (m DevServerDashboardModel) renderExportNote() string {
+	if m.exportNote == "" {
+		return ""
+	}
+	color := lipgloss.Color("46")
+	if m.exportErr {
+		color = lipgloss.Color("196")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("   " + m.exportNote)
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/devserver"
+)
+
+// initMakeTarget highlights the Makefile target Detect chose, or none when
+// the Makefile has no conventional dev target
+func (m *DevServerDashboardModel) initMakeTarget() {
+	m.makeCursor = -1
+	target := devserver.DefaultMakeTarget(m.projectInfo.MakeTargets)
+	for i, t := range m.projectInfo.MakeTargets {
+		if t == target {
+			m.makeCursor = i
+		}
+	}
+}
+
+// moveMakeTarget picks the previous or next Makefile target as the server
+func (m *DevServerDashboardModel) moveMakeTarget(delta int) {
+	targets := m.projectInfo.MakeTargets
+	cursor := m.makeCursor + delta
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor >= len(targets) {
+		cursor = len(targets) - 1
+	}
+	m.makeCursor = cursor
+	m.projectInfo.Servers = []devserver.ServerConfig{devserver.MakeServer(m.projectPath, targets[cursor])}
+	m.applyServerSettings()
+}
+
+// renderMakeTargets lists the Makefile targets for the ready screen, with
+// the one that will run highlighted
+func (m DevServerDashboardModel) renderMakeTargets() string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Render("Makefile targets:")
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{label}
+	for i, target := range m.projectInfo.MakeTargets {
+		if i == m.makeCursor {
+			lines = append(lines, selected.Render("> make "+target))
+		} else {
+			lines = append(lines, dim.Render("  make "+target))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
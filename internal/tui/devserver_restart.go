@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/devserver"
+)
+
+// serverRestartedMsg reports the runner that replaced the stopped one
+type serverRestartedMsg struct {
+	runner *devserver.Runner
+	err    error
+}
+
+// restartServerCmd stops old and starts info's servers again ("R"). Stop
+// returns only once the old processes' output has ended, so their ports
+// are free before the new ones bind them.
+func restartServerCmd(old *devserver.Runner, info devserver.ProjectInfo) tea.Cmd {
+	return func() tea.Msg {
+		old.Stop()
+		runner := devserver.NewRunner()
+		runner.LoadEnvFile = loadEnvFileEnabled()
+		if err := runner.Start(info); err != nil {
+			return serverRestartedMsg{err: err}
+		}
+		return serverRestartedMsg{runner: runner}
+	}
+}
+
+// handleRestarted switches to the new runner and marks the restart in the
+// logs, once per server so each tab and source filter shows it
+func (m DevServerDashboardModel) handleRestarted(msg serverRestartedMsg) (tea.Model, tea.Cmd) {
+	m.restarting = false
+	marker := "--- Server restarted ---"
+	if msg.err != nil {
+		marker = fmt.Sprintf("--- Restart failed: %v ---", msg.err)
+	}
+	timestamp := time.Now().Format("15:04:05")
+	for _, srv := range m.projectInfo.Servers {
+		m.logs = append(m.logs, logEntry{timestamp: timestamp, serverName: srv.Name, line: marker, isError: msg.err != nil})
+	}
+	m.updateLogView()
+	if m.autoScroll {
+		m.logView.GotoBottom()
+		for i := range m.tabs {
+			m.tabs[i].view.GotoBottom()
+		}
+	}
+
+	if msg.err != nil {
+		m.runner = nil
+		m.state = StateDevServerReady
+		return m, notifyError("Restart failed: " + msg.err.Error())
+	}
+	m.runner = msg.runner
+	m.state = StateDevServerRunning
+	m.serverURL = "" // Announced again by the new process
+	m.exportNote = ""
+	return m, tea.Batch(waitForLogCmd(m.runner), notify("Server restarted"))
+}
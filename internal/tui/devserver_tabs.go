@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// serverTab is the per-server log view used in tabbed mode. Each tab keeps
+// its own scroll position and severity filter; the search term is shared.
+type serverTab struct {
+	name       string
+	view       viewport.Model
+	filterMode string // "all", "errors", "warnings"
+}
+
+// initServerTabs creates one tab per detected server, sized like the merged view
+func (m *DevServerDashboardModel) initServerTabs() {
+	m.tabs = nil
+	m.activeTab = 0
+	for _, srv := range m.projectInfo.Servers {
+		vp := viewport.New(m.logView.Width, m.logView.Height-1) // Tab bar takes a line
+		vp.Style = m.logView.Style
+		m.tabs = append(m.tabs, serverTab{name: srv.Name, view: vp, filterMode: "all"})
+	}
+}
+
+// currentFilter is the severity filter of whichever view is on screen
+func (m *DevServerDashboardModel) currentFilter() *string {
+	if m.tabbed && m.activeTab < len(m.tabs) {
+		return &m.tabs[m.activeTab].filterMode
+	}
+	return &m.filterMode
+}
+
+// activeView is the viewport that receives scroll keys and mouse events
+func (m *DevServerDashboardModel) activeView() *viewport.Model {
+	if m.tabbed && m.activeTab < len(m.tabs) {
+		return &m.tabs[m.activeTab].view
+	}
+	return &m.logView
+}
+
+// resizeServerTabs keeps the tab viewports in step with the merged view
+func (m *DevServerDashboardModel) resizeServerTabs() {
+	for i := range m.tabs {
+		m.tabs[i].view.Width = m.logView.Width
+		m.tabs[i].view.Height = m.logView.Height - 1
+	}
+}
+
+// renderServerTabBar draws the server tabs with their line counts
+func (m DevServerDashboardModel) renderServerTabBar() string {
+	counts := map[string]int{}
+	for _, log := range m.logs {
+		counts[log.serverName]++
+	}
+	var parts []string
+	for i, t := range m.tabs {
+		label := fmt.Sprintf("%s (%d)", t.name, counts[t.name])
+		if i == m.activeTab {
+			parts = append(parts, tabActiveStyle.Render(label))
+		} else {
+			parts = append(parts, tabInactiveStyle.Render(label))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(parts, subtleStyle.Render("│")))
+}
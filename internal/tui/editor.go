@@ -1,16 +1,20 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/alecthomas/chroma/v2/quick"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -18,14 +22,18 @@ import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/keymap"
+	"github.com/phravins/devcli/internal/procs"
+	"github.com/phravins/devcli/internal/runner"
 	"github.com/phravins/devcli/internal/web"
 	"github.com/phravins/devcli/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 var EditorCmd = &cobra.Command{
-	Use:   "editor [file]",
+	Use:   "editor [file[:line[:col]]]",
 	Short: "Launch the built-in multi-language IDE",
+	Long:  `Launch the built-in multi-language IDE, optionally with a file. "devcli editor main.go:42" opens main.go at line 42, "main.go:42:8" at column 8 of it; lines past the end go to the last line.`,
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		filename := ""
@@ -36,10 +44,32 @@ var EditorCmd = &cobra.Command{
 	},
 }
 
+// fileLocationPattern matches a file:line or file:line:col argument
+var fileLocationPattern = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+// splitFileLocation splits file:line[:col] into its parts; line is 0 when
+// arg has no position. A file whose name looks like a position is taken
+// as is.
+func splitFileLocation(arg string) (file string, line, col int) {
+	sub := fileLocationPattern.FindStringSubmatch(arg)
+	if sub == nil || utils.FileExists(arg) {
+		return arg, 0, 0
+	}
+	line, _ = strconv.Atoi(sub[2])
+	col, _ = strconv.Atoi(sub[3])
+	return sub[1], line, col
+}
+
 func RunEditor(filename string) {
-	p := tea.NewProgram(Wrap(initialModel(filename)), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	filename, line, col := splitFileLocation(filename)
+	m := initialModel(filename)
+	if line > 0 {
+		m.gotoLineCol(line, col)
+	}
+	p := tea.NewProgram(Wrap(m), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running editor: %v\n", err)
+		procs.KillAll()
 		os.Exit(1)
 	}
 }
@@ -60,6 +90,15 @@ const (
 	stateWebServer
 	stateSavePrompt
 	stateCommandPrompt
+	stateOpenPrompt
+	stateInsertPrompt
+	stateRenamePrompt
+	stateRecoverPrompt
+	stateRepl
+	stateStdinPrompt
+	stateDiscardPrompt
+	stateRecentFiles
+	stateGotoPrompt
 )
 
 const (
@@ -73,6 +112,7 @@ type editorModel struct {
 	cursor  int // Linear index
 	// We use the viewport for rendering
 	viewport viewport.Model
+	history  *undoHistory // Undo/redo of this buffer; replaced for a new buffer
 }
 
 type model struct {
@@ -83,13 +123,37 @@ type model struct {
 	language string // New: explicitly track language mode
 
 	// Custom Editor
-	editor editorModel
+	editor       editorModel
+	savedContent string // Active buffer content as last loaded/saved
+	readOnly     bool   // Oversized file shown as a hex preview
+	lineEnding   string // File's line ending style (LF/CRLF), restored on save
+	savedEOL     string // Line ending as last loaded/saved, for dirty tracking
+
+	// Tabs (active buffer is mirrored in editor/filename/language)
+	tabs      []editorTab
+	activeTab int
+
+	// Unsaved-changes prompt (Esc, Ctrl+N, Ctrl+W, another language's buffer, quit)
+	discard        discardAction
+	discardLang    string       // Language picked in the menu, for discardLanguage
+	discardFrom    sessionState // Where the prompt was asked, to return to
+	leaveConfirmed bool         // Leaving was confirmed; the menu does not ask again
+	quitConfirmed  bool         // Quitting with unsaved changes was confirmed
+
+	recent       []recentFile // Recent files screen (Ctrl+R in the menu)
+	recentCursor int
 
 	status         string
 	showHelp       bool
 	running        bool
+	resolving      bool               // Locating compilers before a run
+	resolveCancel  context.CancelFunc // Stops the compiler search (Esc)
+	runCancel      context.CancelFunc // Kills the running program (Ctrl+K)
+	spinner        spinner.Model
 	output         string
 	saveInput      textinput.Model
+	stdinInput     textarea.Model // Alt+S box for the program's input
+	stdin          string         // Piped to every run until changed
 	commandInput   string
 	width          int
 	height         int
@@ -100,7 +164,53 @@ type model struct {
 	outputView      viewport.Model
 	activeView      int // 0=Editor, 1=Output
 	outputMaximized bool
+	outputRatio     int    // Output pane share of the split, in percent
 	lastLanguage    string // Track for buffer clearing
+
+	// Run selection: region from mark to cursor (Ctrl+Space, Alt+R)
+	mark       int
+	markSet    bool
+	block      blockSelection // Column selection (Ctrl+B)
+	sel        textSelection  // Shift+arrow selection
+	register   string         // Last copy or cut, for when the OS clipboard is unavailable
+	runSnippet string         // Code for the pending run instead of the buffer
+	runNote    string         // How the snippet was derived, shown with its output
+
+	// Error locations in the output (Tab/Enter while it is focused)
+	errorLocs     []errorLocation
+	errorSel      int // Selected location, -1 for none
+	runLineOffset int // Buffer line before the run's first line; -1 when unmappable
+
+	// AI error explanation (Alt+X)
+	lastError   string // Raw output of the last failed run
+	explaining  bool
+	explainID   int // Identifies the in-flight request; bumped on abort
+	showExplain bool
+	explainView viewport.Model
+
+	// Hex viewer for the open file (Alt+H)
+	showHex bool
+	hexView hexViewer
+
+	// Auto-save (editor.autosave_interval); unnamed buffers go to a
+	// recovery file that is offered back on the next launch
+	swapContent string   // Unnamed buffer content last written to it
+	recovery    swapFile // Found at startup, awaiting the restore prompt
+
+	// Live HTML preview server (Ctrl+R on an HTML buffer)
+	preview *web.Preview
+
+	// Interactive interpreter (Alt+P), kept running while the editor has focus
+	repl *replSession
+
+	// .editorconfig properties of the open file and the name they were read for
+	editorConf      utils.EditorConfig
+	editorConfigFor string
+	indent          string // editor.indent_style/indent_size, read at start
+	indentSet       bool   // Whether either setting is set
+
+	// Active key bindings (keys.editor.* in the config)
+	keys keymap.Map
 }
 
 func initialModel(filename string) model {
@@ -109,10 +219,20 @@ func initialModel(filename string) model {
 	ti.CharLimit = 156
 	ti.Width = 50
 
-	initialContent := ""
+	// "devcli editor" skips the dashboard, which normally loads the config
+	config.LoadConfig()
+	keys, keysWarning := loadKeymap("editor")
+	indent, indentSet := configuredIndent()
+
+	initialContent, readOnly, notice, eol := "", false, "", eolLF
 	if filename != "" {
-		if content, err := os.ReadFile(filename); err == nil {
-			initialContent = string(content)
+		if content, ro, msg, err := loadFileForEditor(filename); err == nil {
+			initialContent, readOnly, notice = content, ro, msg
+			addRecentFile(filename)
+			var mixed bool
+			if initialContent, eol, mixed = splitLineEndings(content); mixed && notice == "" {
+				notice = mixedEOLNotice(eol)
+			}
 		}
 	}
 
@@ -133,9 +253,9 @@ func initialModel(filename string) model {
 		glamour.WithAutoStyle(),
 		glamour.WithWordWrap(80),
 	)
-	out, err := renderer.Render(EditorHelp)
+	out, err := renderer.Render(EditorHelp + keymapHelp(keys))
 	if err != nil {
-		out = EditorHelp
+		out = EditorHelp + keymapHelp(keys)
 	}
 	hv.SetContent(out)
 
@@ -143,67 +263,79 @@ func initialModel(filename string) model {
 	if filename != "" {
 		startState = stateEditor
 	}
+	recovery, recoverable := loadSwap()
+	if filename == "" && recoverable {
+		startState = stateRecoverPrompt
+	}
+
+	ev := viewport.New(80, 20)
+	ev.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#059669")).
+		Padding(0, 1)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
-	return model{
+	m := model{
 		state:           startState,
-		choices:         []string{"TUI Py (Python)", "TUI Java", "TUI C++", "TUI C", "TUI C#", "TUI Rust", "TUI Zig", "TUI G (Web Compiler)"},
+		spinner:         sp,
+		choices:         editorMenuChoices(),
 		cursor:          0,
 		filename:        filename,
 		language:        detectLanguage(filename),
-		editor:          editorModel{content: initialContent, cursor: 0, viewport: vp},
+		editor:          editorModel{content: initialContent, cursor: 0, viewport: vp, history: &undoHistory{}},
+		savedContent:    initialContent,
+		readOnly:        readOnly,
+		lineEnding:      eol,
+		savedEOL:        eol,
+		recovery:        recovery,
+		tabs:            []editorTab{{filename: filename, language: detectLanguage(filename), readOnly: readOnly, lineEnding: eol, savedEOL: eol}},
 		status:          "Select an editor mode to begin",
 		showHelp:        false,
 		helpView:        hv,
+		explainView:     ev,
 		running:         false,
 		output:          "",
 		saveInput:       ti,
+		stdinInput:      newStdinInput(),
 		width:           80,
 		height:          40,
 		outputView:      outVp,
 		activeView:      viewEditor,
-		outputMaximized: false,
+		outputMaximized: savedOutputMaximized(),
+		outputRatio:     savedOutputRatio(),
+		keys:            keys,
+		indent:          indent,
+		indentSet:       indentSet,
 	}
-}
-
-func (m *model) resolveExecutable(cmdName string, fallbacks []string) string {
-	cacheKey := "compilers." + cmdName
-	if cached := config.GetString(cacheKey); cached != "" {
-		if utils.FileExists(cached) {
-			return cached
-		}
+	if keysWarning != "" {
+		m.status = keysWarning
 	}
-	path := utils.FindExecutable(cmdName, fallbacks)
-	if path != "" {
-		config.SaveConfig(cacheKey, path)
-		return path
-	}
-	userHome, _ := os.UserHomeDir()
-	searchRoots := []string{
-		`C:\Program Files`,
-		`C:\Program Files (x86)`,
-		filepath.Join(userHome, "Downloads"),
-		`C:\`,
-		"/usr/bin",
-		"/usr/local/bin",
-		"/opt",
-		filepath.Join(userHome, ".local/bin"),
-	}
-
-	// Filter roots that exist
-	validRoots := []string{}
-	for _, r := range searchRoots {
-		if utils.DirExists(r) {
-			validRoots = append(validRoots, r)
-		}
+	if notice != "" {
+		m.status = notice
 	}
+	m.restoreOutput()
+	return m
+}
 
-	path = utils.DeepSearchExecutable(cmdName, validRoots)
-	if path != "" {
-		config.SaveConfig(cacheKey, path)
-		return path
+// restoreOutput shows the cached output for the active language
+func (m *model) restoreOutput() {
+	m.output = cachedRunOutput(m.language)
+	m.outputView.SetContent(m.output)
+	m.errorLocs, m.errorSel = nil, -1
+	m.outputView.GotoBottom()
+	if m.output == "" {
+		m.activeView = viewEditor
 	}
+}
 
-	return ""
+// clearOutput drops the output for the active language
+func (m *model) clearOutput() {
+	forgetRunOutput(m.language)
+	m.restoreOutput()
+	m.updateLayout()
 }
 
 func highlightCode(code, language string) string {
@@ -225,12 +357,15 @@ func highlightCode(code, language string) string {
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(textarea.Blink, blinkCmd())
+	return tea.Batch(textarea.Blink, blinkCmd(), autosaveCmd())
 }
 
 func (m *model) updateLayout() {
 	headerHeight := 4
-	statusHeight := 3
+	if len(m.tabs) > 1 {
+		headerHeight++ // Tab bar
+	}
+	statusHeight := 4 // Status bar + key footer
 	helpHeight := 0
 	if m.showHelp {
 		helpHeight = 7
@@ -245,15 +380,15 @@ func (m *model) updateLayout() {
 	}
 
 	// Calculate Heights
-	if m.outputMaximized {
+	if m.outputMaximized && m.output != "" {
 		// Output Maximized: Editor gets minimum, Output gets rest
 		m.editor.viewport.Height = 5
 		m.outputView.Height = availableHeight - 5
 	} else if m.output != "" {
-		// Split 50/50
-		half := availableHeight / 2
-		m.editor.viewport.Height = half
-		m.outputView.Height = availableHeight - half
+		// Split by the saved ratio (Ctrl+Up/Down)
+		outHeight := availableHeight * m.outputRatio / 100
+		m.editor.viewport.Height = availableHeight - outHeight
+		m.outputView.Height = outHeight
 	} else {
 		// Full Editor
 		m.editor.viewport.Height = availableHeight
@@ -289,6 +424,25 @@ func (m *model) syncEditorView() {
 	highlighted := highlightCode(codeWithCursor, m.language)
 
 	rawLines := strings.Split(highlighted, "\n")
+
+	// Bracket matching: highlight the bracket at the cursor and its partner
+	if at, match, ok := findMatchingBracket(val, cursorPos, m.language); ok {
+		for _, p := range []int{at, match} {
+			if p >= cursorPos {
+				p += len(cursorChar)
+			}
+			line, col := lineCol(codeWithCursor, p)
+			if line < len(rawLines) {
+				rawLines[line] = styleVisibleRune(rawLines[line], col, bracketMatchStyle)
+			}
+		}
+	}
+	if m.block.active {
+		m.highlightBlock(rawLines, val, codeWithCursor, cursorPos, len(cursorChar))
+	}
+	if m.sel.active {
+		m.highlightSelection(rawLines, val, cursorPos, utf8.RuneCountInString(cursorChar))
+	}
 	var finalOutput strings.Builder
 	lineNumStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6272A4")) // Muted purple from theme
 
@@ -350,17 +504,40 @@ func (m *model) syncEditorView() {
 	}
 }
 
+// Update records an undo step whenever handling msg edits the buffer, in
+// any state: prompts such as Alt+I (insert file) and Save edit it too
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := m.undoPoint()
+	next, cmd := m.update(msg)
+	if nm, ok := next.(model); ok {
+		nm.trackEdit(msg, before)
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	m.editorConfig() // Read once per file, for the status bar
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.updateLayout()
+		if m.showHex {
+			m.hexView.resize(m.width, m.height)
+		}
 
 	case tea.MouseMsg:
 		var cmd tea.Cmd
+		if m.showHex {
+			return m, nil
+		}
+		if m.showExplain {
+			m.explainView, cmd = m.explainView.Update(msg)
+			return m, cmd
+		}
 		if m.showHelp {
 			m.helpView, cmd = m.helpView.Update(msg)
 			return m, cmd
@@ -381,6 +558,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// A click on a compiler error line jumps to it
+		if m.state == stateEditor && msg.Type == tea.MouseLeft && m.clickErrorLocation(msg.Y) {
+			return m, nil
+		}
+
 		// Handle Output Scrolling if Focused
 		if m.activeView == viewOutput {
 			m.outputView, cmd = m.outputView.Update(msg)
@@ -395,8 +577,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		if m.showHex {
+			done, cmd := m.hexView.update(msg)
+			m.showHex = !done
+			return m, cmd
+		}
+		if m.showExplain {
+			switch msg.String() {
+			case "esc", "q", "alt+x":
+				m.showExplain = false
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.explainView, cmd = m.explainView.Update(msg)
+				return m, cmd
+			}
+		}
+
 		// Global Shortcuts (Always active in Editor state)
 		if m.state == stateEditor {
+			// Rebound keys arrive as their default, which the cases below match
+			var bound bool
+			if msg, bound = m.keys.Translate(msg); !bound {
+				return m, nil
+			}
+			if m.activeView == viewOutput && len(m.errorLocs) > 0 && m.updateErrorNav(msg) {
+				return m, nil
+			}
 			switch msg.String() {
 			case "ctrl+o":
 				m.activeView = viewOutput
@@ -410,9 +617,114 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "ctrl+m":
 				if m.output != "" {
-					m.outputMaximized = !m.outputMaximized
-					m.updateLayout()
+					m.toggleOutputMaximized()
+				}
+				return m, nil
+			case "ctrl+up", "ctrl+down":
+				delta := outputRatioStep
+				if msg.String() == "ctrl+down" {
+					delta = -delta
+				}
+				m.resizeOutput(delta)
+				return m, nil
+			case "ctrl+l":
+				m.clearOutput()
+				m.status = "Output cleared"
+				return m, nil
+			case "ctrl+t":
+				m.state = stateOpenPrompt
+				m.saveInput.SetValue("")
+				m.saveInput.Focus()
+				m.status = "Enter a file to open in a new tab (empty for a blank tab)..."
+				return m, nil
+			case "alt+g":
+				return m, m.copyImportPath()
+			case "alt+h":
+				m.openHexView()
+				return m, nil
+			case "alt+l":
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				m.toggleLineEnding()
+				return m, nil
+			case "alt+s":
+				m.openStdinPrompt()
+				return m, nil
+			case "ctrl+d", "alt+d":
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				m.sel.active = false
+				if msg.String() == "ctrl+d" {
+					m.deleteLine()
+				} else {
+					m.duplicateLine()
+				}
+				return m, nil
+			case "alt+i":
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				m.state = stateInsertPrompt
+				m.saveInput.SetValue("")
+				m.saveInput.Focus()
+				m.status = "Enter a file to insert at the cursor..."
+				return m, nil
+			case "alt+n":
+				m.startRename()
+				return m, nil
+			case "ctrl+g":
+				m.startGoto()
+				return m, nil
+			case "ctrl+_", "ctrl+/":
+				// Terminals send Ctrl+/ as Ctrl+_
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				m.toggleLineComment()
+				return m, nil
+			case "ctrl+w":
+				m.closeTab()
+				return m, nil
+			case "ctrl+]":
+				m.jumpToMatchingBracket()
+				return m, nil
+			case "alt+f":
+				return m, m.formatDocument()
+			case "alt+x":
+				return m, m.explainError()
+			case "ctrl+@":
+				m.toggleMark()
+				return m, nil
+			case "alt+r":
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				if m.running {
+					m.status = "Already running"
+					return m, nil
+				}
+				return m, m.runSelection()
+			case "alt+p":
+				return m, m.openRepl()
+			case "alt+c":
+				if m.guardReadOnly() {
+					return m, nil
+				}
+				m.status = fmt.Sprintf("Building the %s run command...", m.language)
+				return m, m.copyRunCommand()
+			case "alt+e":
+				if m.guardReadOnly() {
+					return m, nil
 				}
+				m.status = fmt.Sprintf("Opening %s code in an external terminal...", m.language)
+				return m, m.runExternal()
+			case "ctrl+tab", "alt+right":
+				m.switchTab(1)
+				return m, nil
+			case "ctrl+shift+tab", "alt+left":
+				m.switchTab(-1)
 				return m, nil
 			}
 		}
@@ -451,40 +763,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					go web.StartServer("8080")
 					utils.OpenBrowser("http://127.0.0.1:8080")
 				} else {
-					m.state = stateEditor
-					m.status = "Ready"
 					// Set Language Mode based on selection
 					newLang := ""
-					switch {
-					case strings.Contains(choice, "Py"):
-						newLang = "python"
-					case strings.Contains(choice, "Java"):
-						newLang = "java"
-					case strings.Contains(choice, "C++"):
-						newLang = "cpp"
-					case strings.Contains(choice, "C#"):
-						newLang = "csharp"
-					case strings.Contains(choice, "C"):
-						if !strings.Contains(choice, "C++") && !strings.Contains(choice, "C#") {
-							newLang = "c"
-						}
-					case strings.Contains(choice, "Rust"):
-						newLang = "rust"
-					case strings.Contains(choice, "Zig"):
-						newLang = "zig"
+					if langs := editorMenuRunners(); m.cursor < len(langs) {
+						newLang = langs[m.cursor].Name
 					}
-
-					// Buffer Isolation: Clear and inject boilerplate if switching languages on unsaved file
-					if newLang != m.language && m.filename == "" {
-						m.editor.content = getBoilerplate(newLang)
-						m.editor.cursor = len(m.editor.content)
+					if newLang != m.language && m.filename == "" && m.isDirty() && !m.leaveConfirmed {
+						m.confirmDiscard(discardLanguage, newLang)
+						return m, nil
 					}
-
-					m.language = newLang
-					m.updateLayout()
+					m.selectLanguage(newLang)
 				}
+			case "ctrl+r":
+				return m, m.openRecentList()
 			case "ctrl+c", "ctrl+q":
-				return m, tea.Quit
+				return m, m.requestQuit()
 			case "q", "esc":
 				return m, func() tea.Msg { return BackMsg{} }
 			case "?":
@@ -498,11 +791,56 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Always show cursor line on input
 			m.showCursorLine = true
 
+			// Esc while locating compilers cancels the run, not the editor
+			if m.resolving && msg.Type == tea.KeyEsc {
+				m.resolveCancel()
+				m.resolving = false
+				m.running = false
+				m.status = "Compiler search cancelled"
+				return m, nil
+			}
+
+			if msg.Type == tea.KeyCtrlK {
+				m.stopRun()
+				return m, nil
+			}
+
+			// Esc while waiting for the AI aborts the request
+			if m.explaining && msg.Type == tea.KeyEsc {
+				m.explaining = false
+				m.explainID++
+				m.status = "AI request aborted"
+				return m, nil
+			}
+
+			if m.readOnly && readOnlyKey(msg) {
+				m.status = "Read-only preview: file exceeds editor.max_open_bytes (Alt+H: hex view, Ctrl+N: new file)"
+				return m, nil
+			}
+
+			if msg.Type == tea.KeyCtrlB {
+				m.markSet = false
+				m.sel.active = false
+				m.toggleBlock()
+				return m, nil
+			}
+			if m.block.active && m.updateBlock(msg) {
+				return m, nil
+			}
+			if cmd, handled := m.updateSelection(msg); handled {
+				return m, cmd
+			}
+
 			switch msg.Type {
-			case tea.KeyCtrlC, tea.KeyCtrlQ:
-				return m, tea.Quit
+			case tea.KeyCtrlQ:
+				return m, m.requestQuit()
 			case tea.KeyEsc:
+				if m.isDirty() {
+					m.confirmDiscard(discardLeave, "")
+					return m, nil
+				}
 				// Go back to selection menu instead of exiting editor completely
+				m.stopRepl()
 				m.state = stateSelection
 				m.status = "Select an editor mode to begin"
 				m.updateLayout()
@@ -525,12 +863,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.status = "Enter filename (or full path) to save..."
 
 			case tea.KeyCtrlR:
-				if m.running {
-					m.status = "Already running"
-				} else {
-					m.running = true
-					m.status = fmt.Sprintf("Running %s code...", m.language)
-					return m, m.runCode()
+				if m.language == "html" {
+					m.previewHTML()
+					return m, nil
+				}
+				if !m.running {
+					m.runSnippet, m.runNote, m.runLineOffset = "", "", 0
+				}
+				if cmd := m.startRun(); cmd != nil {
+					return m, cmd
 				}
 
 			case tea.KeyCtrlH:
@@ -539,11 +880,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateLayout()
 
 			case tea.KeyCtrlN:
-				m.filename = ""
-				m.editor.content = ""
-				m.editor.cursor = 0
-				m.syncEditorView()
-				m.status = "New file created"
+				if m.isDirty() {
+					m.confirmDiscard(discardNew, "")
+					return m, nil
+				}
+				m.newFile()
+
+			case tea.KeyCtrlZ:
+				m.undo()
+				return m, nil
+			case tea.KeyCtrlY:
+				m.redo()
+				return m, nil
 
 			case tea.KeyCtrlP:
 				m.state = stateCommandPrompt
@@ -583,7 +931,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "'":
 					toInsert = "''"
 				default:
-					toInsert = char
+					toInsert, _, _ = splitLineEndings(char) // Pasted text may carry \r
 				}
 
 				m.editor.content = val[:pos] + toInsert + val[pos:]
@@ -600,6 +948,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.editor.cursor++
 				m.syncEditorView()
 
+			case tea.KeyTab:
+				// A tab or spaces, as the file's .editorconfig or the settings say
+				indent := m.indentUnit()
+				pos := min(m.editor.cursor, len(m.editor.content))
+				m.editor.content = m.editor.content[:pos] + indent + m.editor.content[pos:]
+				m.editor.cursor = pos + len(indent)
+				m.syncEditorView()
+
 			case tea.KeyEnter:
 				val := m.editor.content
 				pos := m.editor.cursor
@@ -607,28 +963,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					pos = len(val)
 				}
 
-				// Smart Enter: Check if between brackets e.g. "{" | "}"
-				isBetweenBraces := false
-				if pos > 0 && pos < len(val) {
-					prev := val[pos-1]
-					next := val[pos]
-					if prev == '{' && next == '}' {
-						isBetweenBraces = true
-					}
-				}
-
-				if isBetweenBraces {
-					// Insert: \n    \n
-					// Cursor: \n    | \n
-					// Basic 4-space indentation
-					indent := "    "
-					toInsert := "\n" + indent + "\n"
-					m.editor.content = val[:pos] + toInsert + val[pos:]
-					m.editor.cursor += 1 + len(indent) // Move to indent position
-				} else {
-					m.editor.content = val[:pos] + "\n" + val[pos:]
-					m.editor.cursor++
-				}
+				toInsert, cursor := m.newline(val, pos)
+				m.editor.content = val[:pos] + toInsert + val[pos:]
+				m.editor.cursor = pos + cursor
 				m.syncEditorView()
 
 			case tea.KeyBackspace:
@@ -670,6 +1007,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case tea.KeyUp, tea.KeyDown:
 				m.moveCursorVertical(msg.Type)
 				m.syncEditorView()
+
+			case tea.KeyHome, tea.KeyEnd, tea.KeyCtrlLeft, tea.KeyCtrlRight, tea.KeyCtrlHome, tea.KeyCtrlEnd:
+				m.moveCursorJump(msg.Type)
 			}
 
 		case stateSavePrompt:
@@ -678,10 +1018,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				filename := m.saveInput.Value()
 				if filename != "" {
 					m.filename = filename
-					if err := os.WriteFile(m.filename, []byte(m.editor.content), 0644); err != nil {
+					if err := m.writeBuffer(); err != nil {
 						m.status = fmt.Sprintf("Error saving: %v", err)
 					} else {
-						m.status = fmt.Sprintf("Saved: %s", m.filename)
+						m.status = ""
+						addRecentFile(m.filename)
+						cmds = append(cmds, notify(fmt.Sprintf("Saved: %s (%s)", m.filename, m.lineEnding)))
+						removeSwap() // The buffer has a name now
 					}
 					m.state = stateEditor
 				}
@@ -694,6 +1037,97 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.saveInput, cmd = m.saveInput.Update(msg)
 			cmds = append(cmds, cmd)
 
+		case stateOpenPrompt:
+			switch msg.Type {
+			case tea.KeyEnter:
+				path := strings.TrimSpace(m.saveInput.Value())
+				m.saveInput.Reset()
+				m.state = stateEditor
+				if notice, err := m.openTab(path); err != nil {
+					m.status = fmt.Sprintf("Error opening: %v", err)
+				} else if notice != "" {
+					m.status = fmt.Sprintf("Tab %d/%d opened. %s", m.activeTab+1, len(m.tabs), notice)
+				} else if m.readOnly {
+					m.status = fmt.Sprintf("Tab %d/%d opened as a read-only hex preview (file exceeds editor.max_open_bytes)", m.activeTab+1, len(m.tabs))
+				} else {
+					m.status = fmt.Sprintf("Tab %d/%d opened", m.activeTab+1, len(m.tabs))
+				}
+				return m, nil
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.saveInput.Reset()
+				m.status = "Open cancelled"
+				m.state = stateEditor
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.saveInput, cmd = m.saveInput.Update(msg)
+			cmds = append(cmds, cmd)
+
+		case stateInsertPrompt:
+			switch msg.Type {
+			case tea.KeyEnter:
+				path := strings.TrimSpace(m.saveInput.Value())
+				m.saveInput.Reset()
+				m.state = stateEditor
+				if path == "" {
+					m.status = "Insert cancelled"
+				} else if err := m.insertFile(path); err != nil {
+					m.status = fmt.Sprintf("Error inserting: %v", err)
+				}
+				return m, nil
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.saveInput.Reset()
+				m.status = "Insert cancelled"
+				m.state = stateEditor
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.saveInput, cmd = m.saveInput.Update(msg)
+			cmds = append(cmds, cmd)
+
+		case stateStdinPrompt:
+			run, cmd := m.updateStdinPrompt(msg)
+			if run {
+				key, _ := keymap.KeyMsg(m.keys.Key("run"))
+				return m.update(key)
+			}
+			return m, cmd
+
+		case stateDiscardPrompt:
+			return m, m.updateDiscard(msg)
+
+		case stateRecoverPrompt:
+			m.updateRecover(msg)
+			return m, nil
+
+		case stateRecentFiles:
+			return m, m.updateRecentList(msg)
+
+		case stateGotoPrompt:
+			return m, m.updateGotoPrompt(msg)
+
+		case stateRenamePrompt:
+			switch msg.Type {
+			case tea.KeyEnter:
+				path := strings.TrimSpace(m.saveInput.Value())
+				m.saveInput.Reset()
+				m.state = stateEditor
+				if path == "" || path == m.filename {
+					m.status = "Rename cancelled"
+				} else if err := m.renameFile(path); err != nil {
+					m.status = fmt.Sprintf("Error renaming: %v", err)
+				}
+				return m, nil
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.saveInput.Reset()
+				m.status = "Rename cancelled"
+				m.state = stateEditor
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.saveInput, cmd = m.saveInput.Update(msg)
+			cmds = append(cmds, cmd)
+
 		case stateCommandPrompt:
 			switch msg.Type {
 			case tea.KeyEnter:
@@ -702,6 +1136,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.commandInput = ""
 					m.status = "Running: " + cmdStr
 					m.state = stateEditor
+					m.runLineOffset = 0
 					return m, runShellCommand(cmdStr)
 				}
 			case tea.KeyEsc, tea.KeyCtrlC:
@@ -717,14 +1152,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.commandInput += msg.String()
 				}
 			}
+		case stateRepl:
+			return m, m.updateRepl(msg)
 		case stateWebServer:
 			// Allow quitting from web server mode
 			switch msg.String() {
 			case "ctrl+c", "q", "esc":
-				return m, tea.Quit
+				return m, m.requestQuit()
 			}
 		}
 
+	case autosaveTickMsg:
+		m.autosave()
+		return m, autosaveCmd()
+
 	case blinkMsg:
 		if m.state == stateEditor {
 			m.showCursorLine = !m.showCursorLine
@@ -732,17 +1173,124 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, blinkCmd()
 
+	case spinner.TickMsg:
+		if !m.resolving && !m.explaining {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case toolsResolvedMsg:
+		if !m.resolving {
+			return m, nil // Cancelled with Esc
+		}
+		m.resolving = false
+		m.resolveCancel()
+		m.resolveCancel = nil
+		if msg.canceled {
+			m.running = false
+			return m, nil
+		}
+		if msg.missing != "" {
+			res := missingToolResult(msg.missing)
+			return m, func() tea.Msg { return res }
+		}
+		m.status = fmt.Sprintf("Running %s code...", m.language)
+		return m, m.runCode()
+
+	case formatResultMsg:
+		switch {
+		case msg.err != nil && msg.tool == "":
+			res := missingToolResult("goimports")
+			m.output = res.output
+			m.outputView.SetContent(m.output)
+			m.errorLocs, m.errorSel = nil, -1
+			m.status = fmt.Sprintf("Format failed: %v", msg.err)
+			m.updateLayout()
+		case msg.err != nil:
+			m.status = fmt.Sprintf("%s: %v", msg.tool, msg.err)
+		case msg.original != m.editor.content:
+			m.status = "Buffer changed while formatting; result discarded"
+		case msg.content == m.editor.content:
+			m.status = fmt.Sprintf("Already formatted (%s)", msg.tool)
+		default:
+			m.applyFormatted(msg.content)
+			m.status = fmt.Sprintf("Formatted with %s", msg.tool)
+		}
+		return m, nil
+
+	case replStartedMsg:
+		return m, m.replStarted(msg)
+
+	case replOutputMsg:
+		return m, m.replOutput(msg)
+
+	case runCommandMsg:
+		switch {
+		case msg.err != nil && msg.command == "":
+			m.status = fmt.Sprintf("Copy run command failed: %v", msg.err)
+		case msg.err != nil:
+			m.status = fmt.Sprintf("%v; run: %s", msg.err, msg.command)
+		default:
+			return m, notify("Copied: " + msg.command)
+		}
+		return m, nil
+
+	case externalRunMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Run in terminal failed: %v", msg.err)
+		} else {
+			m.status = fmt.Sprintf("Running in %s (press Enter there to close it)", msg.terminal)
+		}
+		return m, nil
+
+	case explainResultMsg:
+		if !m.explaining || msg.id != m.explainID {
+			return m, nil // Aborted with Esc
+		}
+		m.explaining = false
+		if msg.err != nil {
+			m.status = fmt.Sprintf("AI request failed: %v", msg.err)
+			return m, nil
+		}
+		m.showExplanation(msg.text)
+		m.status = "AI explanation ready (Esc to close, Alt+X to reopen)"
+		return m, nil
+
 	case execResult:
 		m.running = false
+		m.runCancel = nil
+		m.refreshGitStatus() // Shell commands may have committed or staged
 		m.output = msg.output
+		m.lastError = ""
+		if msg.err != nil {
+			m.lastError = msg.output
+		}
+		if summary := msg.summary(); summary != "" {
+			if m.runNote != "" {
+				summary = m.runNote + "; " + summary
+			}
+			m.output = strings.TrimRight(m.output, "\n") + "\n\n" + subtleStyle.Render("── "+summary+" ──")
+		}
+		storeRunOutput(m.language, m.output, m.filename)
 		m.outputView.SetContent(m.output) // Update viewport content
 		m.activeView = viewOutput         // Auto-focus output
 		m.outputView.GotoBottom()         // Auto-scroll to bottom
-
-		if msg.err != nil {
+		m.findErrorLocations()
+
+		switch {
+		case msg.stage == stageCompile:
+			m.status = "Compilation failed: " + msg.summary()
+		case msg.err != nil && msg.stage == stageRun:
+			m.status = fmt.Sprintf("Runtime error: %v (%s)", msg.err, msg.summary())
+		case msg.err != nil:
 			m.status = fmt.Sprintf("Error: %v", msg.err)
-		} else {
-			m.status = "Execution completed"
+		default:
+			m.status = "Execution completed: " + msg.summary()
+		}
+		if n := len(m.errorLocs); n > 0 {
+			m.status += fmt.Sprintf(" | %d location(s) in output (Tab to select, Enter to jump)", n)
 		}
 		m.updateLayout()
 		return m, nil
@@ -890,6 +1438,20 @@ func (m model) View() string {
 		)
 	}
 
+	if m.showHex {
+		return m.hexView.View(m.width, m.height)
+	}
+
+	if m.showExplain {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			lipgloss.JoinVertical(lipgloss.Center,
+				lipgloss.NewStyle().Foreground(lipgloss.Color("#059669")).Bold(true).MarginBottom(1).Render("AI Explanation"),
+				m.explainView.View(),
+				lipgloss.NewStyle().Foreground(lipgloss.Color("240")).MarginTop(1).Render("↑/↓: Scroll • Esc: Back to editor"),
+			),
+		)
+	}
+
 	if m.state == stateSelection {
 		var choices strings.Builder
 
@@ -907,7 +1469,7 @@ func (m model) View() string {
 				title,
 				"\nChoose your development environment\n",
 				choices.String(),
-				helpStyle.Render("↑/↓: Navigate • Enter: Select • ?: Help • q: Back"),
+				helpStyle.Render("↑/↓: Navigate • Enter: Select • Ctrl+R: Recent Files • ?: Help • q: Back"),
 			),
 		)
 
@@ -929,8 +1491,60 @@ func (m model) View() string {
 			"Press Enter to save, Esc to cancel.", cwd, m.saveInput.View())
 	}
 
+	if m.state == stateOpenPrompt {
+		cwd, _ := os.Getwd()
+		return fmt.Sprintf("\n=== Open in New Tab ===\n\n"+
+			"Current Directory: %s\n"+
+			"Enter filename/path: %s\n\n"+
+			"Press Enter to open (empty for a blank tab), Esc to cancel.", cwd, m.saveInput.View())
+	}
+
+	if m.state == stateInsertPrompt {
+		cwd, _ := os.Getwd()
+		return fmt.Sprintf("\n=== Insert File at Cursor ===\n\n"+
+			"Current Directory: %s\n"+
+			"Enter filename/path: %s\n\n"+
+			"Press Enter to insert, Esc to cancel.", cwd, m.saveInput.View())
+	}
+
+	if m.state == stateStdinPrompt {
+		return m.stdinPromptView()
+	}
+
+	if m.state == stateDiscardPrompt {
+		return m.discardPromptView()
+	}
+
+	if m.state == stateRecentFiles {
+		return m.recentListView()
+	}
+
+	if m.state == stateGotoPrompt {
+		return m.gotoPromptView()
+	}
+
+	if m.state == stateRecoverPrompt {
+		lines := strings.Count(m.recovery.Content, "\n") + 1
+		return fmt.Sprintf("\n=== Recover Unsaved Buffer ===\n\n"+
+			"An unnamed %s buffer (%d lines) was auto-saved at %s\n"+
+			"but never saved to a file.\n\n"+
+			"Restore it? (y/Enter: restore, n/Esc: discard)", m.recovery.Language, lines, m.recovery.SavedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	if m.state == stateRenamePrompt {
+		return fmt.Sprintf("\n=== Rename File ===\n\n"+
+			"Current File: %s\n"+
+			"New name/path: %s\n\n"+
+			"A bare name keeps the file in its folder. The buffer is not saved.\n"+
+			"Press Enter to rename, Esc to cancel.", m.filename, m.saveInput.View())
+	}
+
 	var s strings.Builder
 
+	if tabBar := m.renderTabBar(); tabBar != "" {
+		s.WriteString(tabBar + "\n")
+	}
+
 	// Dynamic Header Config
 	var title string
 	var bgColor string
@@ -957,6 +1571,21 @@ func (m model) View() string {
 	case "zig":
 		title = "Zig IDE (TUI Zig)"
 		bgColor = "#a21caf" // Fuchsia
+	case "php":
+		title = "PHP IDE (TUI PHP)"
+		bgColor = "#4f5b93" // PHP Indigo
+	case "swift":
+		title = "Swift IDE (TUI Swift)"
+		bgColor = "#f05138" // Swift Orange
+	case "javascript":
+		title = "JavaScript IDE (TUI JS)"
+		bgColor = "#a16207" // JS Yellow, darkened for white text
+	case "typescript":
+		title = "TypeScript IDE (TUI TS)"
+		bgColor = "#3178c6" // TypeScript Blue
+	case "go":
+		title = "Go IDE (TUI Go)"
+		bgColor = "#00758d" // Go Blue, darkened
 	default:
 		title = "Code Editor (Multi-Lang)"
 		bgColor = "#44475a" // Muted Grey/Selection Color
@@ -967,7 +1596,11 @@ func (m model) View() string {
 		Background(lipgloss.Color(bgColor)).
 		Render(title)
 
-	fileInfo := fileStyle.Render(fmt.Sprintf("File: %s", m.filename))
+	fileLabel := m.filename
+	if m.isDirty() {
+		fileLabel += " [modified]"
+	}
+	fileInfo := fileStyle.Render(fmt.Sprintf("File: %s", fileLabel))
 
 	s.WriteString(header + "\n")
 	s.WriteString(fileInfo + "\n\n")
@@ -978,17 +1611,13 @@ func (m model) View() string {
 
 	// Output section (Styled)
 	if m.output != "" {
-		cwd, _ := os.Getwd()
-		title := fmt.Sprintf("Output (Executed in: %s) [Ctrl+E: Editor | Ctrl+M: Maximize]", cwd)
-
 		// Change border color based on focus
 		borderColor := "#0F9E99" // Teal (Default)
 		if m.activeView == viewOutput {
 			borderColor = "#FFFF00" // Yellow (Generic Focus)
-			title = " >> " + title + " << "
 		}
 
-		outTitle := outputTitleStyle.Render(title)
+		outTitle := outputTitleStyle.Render(m.outputTitle())
 
 		outView := m.outputView.View()
 		outBox := outputContentStyle.
@@ -1001,49 +1630,119 @@ func (m model) View() string {
 	}
 
 	// Status Bar
-	// Calculate line number manually
-	currentLine := strings.Count(m.editor.content[:m.editor.cursor], "\n") + 1
+	// Columns count runes, as compilers do, so "line 12 col 5" matches
+	line, col := lineCol(m.editor.content, min(m.editor.cursor, len(m.editor.content)))
+	total := strings.Count(m.editor.content, "\n") + 1
+	lines := "lines"
+	if total == 1 {
+		lines = "line"
+	}
 
-	statusText := fmt.Sprintf(" Status: %s | Line: %d ", m.status, currentLine)
+	statusText := fmt.Sprintf(" Status: %s | Ln %d, Col %d · %d %s · %s | %s ", m.status, line+1, col+1, total, lines, m.language, m.lineEnding)
+	if m.editorConfigFor == m.filename && !m.editorConf.Empty() {
+		statusText += "| .editorconfig "
+	}
+	statusText += m.gitStatusText()
+	if m.markSet {
+		markLine, _ := lineCol(m.editor.content, min(m.mark, len(m.editor.content)))
+		statusText += fmt.Sprintf("| Mark: %d ", markLine+1)
+	}
+	if m.block.active {
+		statusText += m.blockStatus()
+	}
+	if m.stdin != "" {
+		statusText += "| Stdin: " + m.stdinSummary() + " "
+	}
+	if m.sel.active {
+		statusText += m.selectionStatus()
+	}
+	if m.resolving || m.explaining {
+		statusText = " " + m.spinner.View() + statusText
+	}
 	bar := statusStyle.Width(m.width).Render(statusText)
 
 	s.WriteString("\n" + bar)
+	s.WriteString("\n" + renderKeyFooter(m.width, relabelHints(m.keys, editorKeyHints)))
 
 	return s.String()
 }
 
+var editorKeyHints = []keyHint{
+	{"Ctrl+R", "Run"},
+	{"Ctrl+K", "Stop Run"},
+	{"Ctrl+S", "Save"},
+	{"Ctrl+N", "New"},
+	{"Ctrl+Z", "Undo"},
+	{"Ctrl+Y", "Redo"},
+	{"Ctrl+D", "Delete Line"},
+	{"Alt+D", "Duplicate Line"},
+	{"Ctrl+G", "Go to Line"},
+	{"Shift+Arrows", "Select"},
+	{"Ctrl+C/X/V", "Copy/Cut/Paste"},
+	{"Ctrl+T", "Open Tab"},
+	{"Ctrl+W", "Close Tab"},
+	{"Alt+←/→", "Switch Tab"},
+	{"Ctrl+P", "Command"},
+	{"Ctrl+O", "Output"},
+	{"Ctrl+E", "Editor"},
+	{"Tab/Enter", "Error Locations"},
+	{"Ctrl+L", "Clear Output"},
+	{"Alt+F", "Format"},
+	{"Alt+R", "Run Line/Selection"},
+	{"Alt+E", "Run in Terminal"},
+	{"Alt+C", "Copy Run Command"},
+	{"Alt+P", "REPL"},
+	{"Alt+S", "Program Input"},
+	{"Ctrl+B", "Column Select"},
+	{"Ctrl+/", "Comment"},
+	{"Alt+X", "Explain Error"},
+	{"Alt+I", "Insert File"},
+	{"Alt+N", "Rename File"},
+	{"Alt+L", "LF/CRLF"},
+	{"Alt+G", "Copy Import Path"},
+	{"Alt+H", "Hex View"},
+	{"Ctrl+↑/↓", "Resize Output"},
+	{"Ctrl+H", "Help"},
+	{"Esc", "Menu"},
+}
+
+// editorMenuRunners lists the languages in the editor menu, in
+// registration order
+func editorMenuRunners() []*runner.Runner {
+	var langs []*runner.Runner
+	for _, r := range runner.All() {
+		if r.Label != "" {
+			langs = append(langs, r)
+		}
+	}
+	return langs
+}
+
+// editorMenuChoices is the editor menu: one entry per language, then the
+// web compiler
+func editorMenuChoices() []string {
+	var choices []string
+	for _, r := range editorMenuRunners() {
+		choices = append(choices, "TUI "+r.Label)
+	}
+	return append(choices, "TUI G (Web Compiler)")
+}
+
 // detectLanguage attempts to infer language from filename
 func detectLanguage(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
+	if r, ok := runner.ForExtension(ext); ok {
+		return r.Name
+	}
 	switch ext {
-	case ".py":
-		return "python"
-	case ".java":
-		return "java"
-	case ".cpp", ".cxx", ".cc":
-		return "cpp"
-	case ".c":
-		return "c"
-	case ".rs":
-		return "rust"
-	case ".zig":
-		return "zig"
-	case ".cs":
-		return "csharp"
-	case ".js":
-		return "javascript"
-	case ".ts":
-		return "typescript"
 	case ".html":
 		return "html"
-	case ".go":
-		return "go"
 	case ".json":
 		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
 	case ".md":
 		return "markdown"
-	case ".h":
-		return "c"
 	default:
 		return "text" // Default fallback
 	}
@@ -1052,9 +1751,16 @@ func detectLanguage(filename string) string {
 // runCode dispatches execution based on language mode
 func (m *model) runCode() tea.Cmd {
 	code := m.editor.content
+	if m.runSnippet != "" {
+		code = m.runSnippet
+	}
 	language := m.language
+	stdin := m.stdin
+	runCtx, stop := context.WithCancel(context.Background())
+	m.runCancel = stop // Ctrl+K
 
 	return func() tea.Msg {
+		defer stop()
 		// SANITIZATION
 		cleanCode := strings.Map(func(r rune) rune {
 			if r == '\n' || r == '\t' {
@@ -1066,228 +1772,58 @@ func (m *model) runCode() tea.Cmd {
 			return r
 		}, code)
 
+		// One deadline (editor.run_timeout) covers compile and run
+		timeout := editorRunTimeout()
+		ctx, cancel := procs.WithTimeout(runCtx, timeout)
+		defer cancel()
+
 		// Create a specific temp directory for this run to avoid collisions
 		tmpDir, err := os.MkdirTemp("", "devcli_run_*")
 		if err != nil {
-			return execResult{"", fmt.Errorf("failed to create temp dir: %v", err)}
+			return execResult{err: fmt.Errorf("failed to create temp dir: %v", err), stage: stageSetup}
 		}
 		defer os.RemoveAll(tmpDir) // Cleanup everything after run
 
-		var cmd *exec.Cmd
-
-		switch language {
-		case "python":
-			tmpFile := filepath.Join(tmpDir, "script.py")
-			if err := os.WriteFile(tmpFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-
-			pyFallbacks := []string{
-				`C:\Python*\python.exe`,
-				`C:\Program Files\Python*\python.exe`,
-			}
-			pyPath := m.resolveExecutable("python", pyFallbacks)
-			if pyPath == "" {
-				pyPath = m.resolveExecutable("python3", pyFallbacks)
-			}
-
-			if pyPath == "" {
-				return execResult{"", fmt.Errorf("python not found. Please install Python or add to PATH")}
-			}
-			cmd = exec.Command(pyPath, "-u", tmpFile)
-
-		case "java":
-			// Attempt to find class name to name file correctly
-			className := "Main"
-			// Simple regex to find "public class X"
-			lines := strings.Split(cleanCode, "\n")
-			for _, line := range lines {
-				if strings.Contains(line, "class ") {
-					parts := strings.Fields(line)
-					for i, p := range parts {
-						if p == "class" && i+1 < len(parts) {
-							// Strip braces if present
-							name := strings.Trim(parts[i+1], "{")
-							if name != "" {
-								className = name
-								break
-							}
-						}
-					}
-				}
-			}
-			srcFile := filepath.Join(tmpDir, className+".java")
-			if err := os.WriteFile(srcFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-
-			// Find Compiler
-			javaFallbacks := []string{
-				`C:\Program Files\Java\jdk*\bin\java.exe`,
-				`C:\Program Files\Eclipse Adoptium\jdk*\bin\java.exe`,
-			}
-			javacFallbacks := make([]string, len(javaFallbacks))
-			for i, p := range javaFallbacks {
-				javacFallbacks[i] = strings.Replace(p, "java.exe", "javac.exe", 1)
-			}
-
-			javaPath := m.resolveExecutable("java", javaFallbacks)
-			javacPath := m.resolveExecutable("javac", javacFallbacks)
-
-			if javacPath == "" || javaPath == "" {
-				return execResult{"", fmt.Errorf("Java/Javac not found. Please install JDK or add to PATH")}
-			}
-
-			// Compile
-			compileCmd := exec.Command(javacPath, "-d", ".", className+".java")
-			compileCmd.Dir = tmpDir
-			if out, err := compileCmd.CombinedOutput(); err != nil {
-				return execResult{string(out), fmt.Errorf("compilation failed: %v", err)}
-			}
-
-			// Run
-			cmd = exec.Command(javaPath, "-cp", ".", className)
-
-		case "cpp":
-			srcFile := filepath.Join(tmpDir, "main.cpp")
-			exeFile := filepath.Join(tmpDir, "main.exe")
-			if runtime.GOOS != "windows" {
-				exeFile = filepath.Join(tmpDir, "main")
-			}
-			if err := os.WriteFile(srcFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-
-			// Find Compiler
-			gppFallbacks := []string{
-				`C:\Program Files\CodeBlocks\MinGW\bin\g++.exe`,
-				`C:\Program Files (x86)\CodeBlocks\MinGW\bin\g++.exe`,
-				`C:\MinGW\bin\g++.exe`,
-				`C:\TDM-GCC-64\bin\g++.exe`,
-			}
-			gppPath := m.resolveExecutable("g++", gppFallbacks)
-			if gppPath == "" {
-				return execResult{"", fmt.Errorf("g++ compiler not found. Please install MinGW or add to PATH")}
-			}
-
-			// Compile
-			compileCmd := exec.Command(gppPath, "main.cpp", "-o", exeFile)
-			compileCmd.Dir = tmpDir
-			if out, err := compileCmd.CombinedOutput(); err != nil {
-				return execResult{string(out), fmt.Errorf("compilation failed: %v", err)}
-			}
-
-			// Run
-			cmd = exec.Command(exeFile)
-
-		case "c":
-			srcFile := filepath.Join(tmpDir, "main.c")
-			exeFile := filepath.Join(tmpDir, "main.exe")
-			if runtime.GOOS != "windows" {
-				exeFile = filepath.Join(tmpDir, "main")
-			}
-			if err := os.WriteFile(srcFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-
-			// Find Compiler
-			gccFallbacks := []string{
-				`C:\Program Files\CodeBlocks\MinGW\bin\gcc.exe`,
-				`C:\Program Files (x86)\CodeBlocks\MinGW\bin\gcc.exe`,
-				`C:\MinGW\bin\gcc.exe`,
-				`C:\TDM-GCC-64\bin\gcc.exe`,
-			}
-			gccPath := m.resolveExecutable("gcc", gccFallbacks)
-			if gccPath == "" {
-				return execResult{"", fmt.Errorf("gcc compiler not found. Please install MinGW or add to PATH")}
-			}
-
-			// Compile
-			compileCmd := exec.Command(gccPath, "main.c", "-o", exeFile)
-			compileCmd.Dir = tmpDir
-			if out, err := compileCmd.CombinedOutput(); err != nil {
-				return execResult{string(out), fmt.Errorf("compilation failed: %v", err)}
-			}
-
-			// Run
-			cmd = exec.Command(exeFile)
-
-		case "rust":
-			srcFile := filepath.Join(tmpDir, "main.rs")
-			exeFile := filepath.Join(tmpDir, "main.exe")
-			if runtime.GOOS != "windows" {
-				exeFile = filepath.Join(tmpDir, "main")
-			}
-			if err := os.WriteFile(srcFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-			// Find Compiler
-			userHome, _ := os.UserHomeDir()
-			rustFallbacks := []string{
-				filepath.Join(userHome, `.cargo\bin\rustc.exe`),
-			}
-			rustcPath := m.resolveExecutable("rustc", rustFallbacks)
-			if rustcPath == "" {
-				return execResult{"", fmt.Errorf("rustc not found. Please install Rust or add to PATH")}
-			}
-
-			// Compile
-			compileCmd := exec.Command(rustcPath, "main.rs", "-o", exeFile)
-			compileCmd.Dir = tmpDir
-			if out, err := compileCmd.CombinedOutput(); err != nil {
-				return execResult{string(out), fmt.Errorf("compilation failed: %v", err)}
-			}
-
-			// Run
-			cmd = exec.Command(exeFile)
-
-		case "zig":
-			srcFile := filepath.Join(tmpDir, "main.zig")
-			if err := os.WriteFile(srcFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
-			}
-			// Find Zig
-			zigFallbacks := []string{
-				`C:\Program Files\Zig*\zig.exe`,
-				`C:\zig*\zig.exe`,
-			}
-			zigPath := m.resolveExecutable("zig", zigFallbacks)
-			if zigPath == "" {
-				return execResult{"", fmt.Errorf("zig not found. Please install Zig or add to PATH")}
-			}
-
-			// zig run
-			cmd = exec.Command(zigPath, "run", srcFile)
+		r, ok := runner.Lookup(language)
+		if !ok {
+			return execResult{err: fmt.Errorf("no runner defined for language: %s", language), stage: stageSetup}
+		}
+		start := time.Now()
 
-		case "csharp":
-			// C# is tricky without a project. We will try to use 'dotnet-script' if available, or create a temp project.
-			// Simplest robust way: dotnet new console, replace Program.cs, dotnet run.
+		tools, err := r.Resolve(m.resolveExecutable)
+		if err != nil {
+			return runnerSetupResult(err)
+		}
+		b, err := r.NewBuild(cleanCode, tmpDir, tools)
+		if err != nil {
+			return execResult{err: err, stage: stageSetup}
+		}
 
-			// 1. dotnet new console
-			setupCmd := exec.Command("dotnet", "new", "console", "-o", tmpDir, "--force")
-			if out, err := setupCmd.CombinedOutput(); err != nil {
-				return execResult{string(out), fmt.Errorf("failed to init dotnet project: %v", err)}
+		if r.Setup != nil {
+			setupCmd := stepCommand(ctx, r.Setup(b), tmpDir)
+			if out, err := procs.CombinedOutput(setupCmd, "editor: setup"); err != nil {
+				err, note := stoppedRun(ctx, err, timeout)
+				return execResult{output: withNote(procs.DecodeOutput(out, language), note), err: fmt.Errorf("setup failed: %v", err), stage: stageSetup}
 			}
-
-			// 2. Overwrite Program.cs
-			mainFile := filepath.Join(tmpDir, "Program.cs")
-			if err := os.WriteFile(mainFile, []byte(cleanCode), 0644); err != nil {
-				return execResult{"", err}
+		}
+		if err := b.WriteSource(cleanCode); err != nil {
+			return execResult{err: err, stage: stageSetup}
+		}
+		if r.Compile != nil {
+			compileCmd := stepCommand(ctx, r.Compile(b), tmpDir)
+			if out, err := procs.CombinedOutput(compileCmd, "editor: compile"); err != nil {
+				err, note := stoppedRun(ctx, err, timeout)
+				return execResult{output: withNote(procs.DecodeOutput(out, language), note), err: fmt.Errorf("compilation failed: %v", err), stage: stageCompile, exitCode: exitCodeOf(compileCmd), duration: time.Since(start)}
 			}
-
-			// 3. dotnet run
-			cmd = exec.Command("dotnet", "run", "--project", tmpDir)
-
-		default:
-			return execResult{"", fmt.Errorf("no runner defined for language: %s", language)}
+		}
+		cmd := stepCommand(ctx, r.Run(b), tmpDir)
+		if in := stdinReader(stdin); in != nil {
+			cmd.Stdin = in
 		}
 
-		cmd.Dir = tmpDir
-		// If using 'cmd /C', we set dir to tmpDir so relative paths work?
-		// Actually for compiled languages we generated commands assuming we are in tmpDir.
-
-		output, err := cmd.CombinedOutput()
-		outStr := string(output)
+		output, err := procs.CombinedOutput(cmd, "editor: "+language)
+		err, note := stoppedRun(ctx, err, timeout)
+		outStr := withNote(procs.DecodeOutput(output, language), note) // Partial output when killed
 
 		if outStr == "" && err == nil {
 			outStr = "[Success] (No output)"
@@ -1295,40 +1831,50 @@ func (m *model) runCode() tea.Cmd {
 			outStr = fmt.Sprintf("[Error] %v", err)
 		}
 
-		return execResult{outStr, err}
+		return execResult{output: outStr, err: err, stage: stageRun, exitCode: exitCodeOf(cmd), duration: time.Since(start)}
+	}
+}
+
+// stepCommand builds a compile or run command of a runner in dir
+func stepCommand(ctx context.Context, argv []string, dir string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// runnerSetupResult reports a runner that could not be prepared, with
+// install guidance for a missing tool
+func runnerSetupResult(err error) execResult {
+	var missing *runner.MissingToolError
+	if errors.As(err, &missing) {
+		return missingToolResult(missing.Tool)
 	}
+	return execResult{err: err, stage: stageSetup}
 }
 
 func runShellCommand(command string) tea.Cmd {
 	return func() tea.Msg {
-		cmd := utils.GetShellCommand(command)
+		timeout := procs.Timeout()
+		ctx, cancel := procs.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		shell := utils.GetShellCommand(config.Shell(), command)
+		cmd := exec.CommandContext(ctx, shell.Args[0], shell.Args[1:]...)
 
 		if cwd, err := os.Getwd(); err == nil {
 			cmd.Dir = cwd
 		}
 
-		output, err := cmd.CombinedOutput()
-		return execResult{string(output), err}
+		start := time.Now()
+		output, err := procs.CombinedOutput(cmd, "editor: shell")
+		err = procs.TimeoutError(ctx, err, timeout)
+		return execResult{output: procs.DecodeOutput(output, "shell"), err: err, stage: stageRun, exitCode: exitCodeOf(cmd), duration: time.Since(start)}
 	}
 }
 
 func getBoilerplate(lang string) string {
-	switch lang {
-	case "python":
-		return "print(\"Hello from Python!\")\n"
-	case "java":
-		return "public class Main {\n    public static void main(String[] args) {\n        System.out.println(\"Hello from Java!\");\n    }\n}\n"
-	case "cpp":
-		return "#include <iostream>\n\nint main() {\n    std::cout << \"Hello from C++!\" << std::endl;\n    return 0;\n}\n"
-	case "c":
-		return "#include <stdio.h>\n\nint main() {\n    printf(\"Hello from C!\\n\");\n    return 0;\n}\n"
-	case "rust":
-		return "fn main() {\n    println!(\"Hello from Rust!\");\n}\n"
-	case "zig":
-		return "const std = @import(\"std\");\n\npub fn main() !void {\n    std.debug.print(\"Hello from Zig!\\n\", .{});\n}\n"
-	case "csharp":
-		return "using System;\n\nclass Program {\n    static void Main() {\n        Console.WriteLine(\"Hello from C#!\");\n    }\n}\n"
-	default:
-		return ""
+	if r, ok := runner.Lookup(lang); ok {
+		return r.Boilerplate
 	}
+	return ""
 }
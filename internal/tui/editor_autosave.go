@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
+)
+
+// autosaveTickMsg fires every editor.autosave_interval
+type autosaveTickMsg struct{}
+
+// autosaveIntervalKey is a duration ("30s", "2m") or plain seconds.
+// Empty, 0 or invalid disables auto-save.
+const autosaveIntervalKey = "editor.autosave_interval"
+
+func autosaveInterval() time.Duration {
+	d, _ := procs.ParseTimeout(config.GetString(autosaveIntervalKey))
+	return d
+}
+
+func autosaveCmd() tea.Cmd {
+	d := autosaveInterval()
+	if d <= 0 {
+		return nil
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg { return autosaveTickMsg{} })
+}
+
+// swapFile holds an unnamed buffer so it survives a crash
+type swapFile struct {
+	Language string    `json:"language"`
+	Content  string    `json:"content"`
+	SavedAt  time.Time `json:"saved_at"`
+}
+
+func swapPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "editor-recovery.json"), nil
+}
+
+// loadSwap returns the recovery file left by an earlier session, if any
+func loadSwap() (swapFile, bool) {
+	path, err := swapPath()
+	if err != nil {
+		return swapFile{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return swapFile{}, false
+	}
+	var swap swapFile
+	if err := json.Unmarshal(data, &swap); err != nil || swap.Content == "" {
+		return swapFile{}, false
+	}
+	return swap, true
+}
+
+func removeSwap() {
+	if path, err := swapPath(); err == nil {
+		os.Remove(path)
+	}
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it
+// over path, so an interrupted write never leaves a truncated file
+func writeFileAtomic(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// writeBuffer saves the buffer to m.filename as Ctrl+S and auto-save both
+// do: cleaned up per .editorconfig, in its line endings, written atomically
+func (m *model) writeBuffer() error {
+	m.cleanupOnSave()
+	if err := writeFileAtomic(m.filename, []byte(withLineEnding(m.editor.content, m.lineEnding))); err != nil {
+		return err
+	}
+	m.savedContent = m.editor.content
+	m.savedEOL = m.lineEnding
+	m.refreshGitStatus()
+	m.pushPreview()
+	return nil
+}
+
+// autosave writes a named, modified buffer to its file, or an unnamed one
+// to the recovery file. Read-only previews are never written.
+func (m *model) autosave() {
+	if m.state == stateSelection || m.readOnly {
+		return
+	}
+	if m.filename == "" {
+		if m.editor.content == "" || m.editor.content == m.swapContent {
+			return
+		}
+		path, err := swapPath()
+		if err != nil {
+			return
+		}
+		data, _ := json.Marshal(swapFile{Language: m.language, Content: m.editor.content, SavedAt: time.Now()})
+		if writeFileAtomic(path, data) == nil {
+			m.swapContent = m.editor.content
+		}
+		return
+	}
+	if !m.isDirty() {
+		return
+	}
+	if err := m.writeBuffer(); err != nil {
+		m.status = fmt.Sprintf("Auto-save failed: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("Auto-saved %s at %s", filepath.Base(m.filename), time.Now().Format("15:04:05"))
+}
+
+// updateRecover answers the restore prompt shown when a recovery file exists
+func (m *model) updateRecover(msg tea.KeyMsg) {
+	switch strings.ToLower(msg.String()) {
+	case "y", "enter":
+		m.language = m.recovery.Language
+		m.editor.content = m.recovery.Content
+		m.editor.cursor = 0
+		m.resetUndo() // A recovered buffer starts a new history
+		m.savedContent = ""
+		m.swapContent = m.recovery.Content
+		m.state = stateEditor
+		m.status = "Recovered unsaved buffer (Ctrl+S to save it)"
+		m.updateLayout()
+	case "n", "esc":
+		removeSwap()
+		m.state = stateSelection
+		m.status = "Recovery discarded. Select an editor mode to begin"
+	}
+}
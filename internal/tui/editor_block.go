@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var blockSelectStyle = lipgloss.NewStyle().Background(lipgloss.Color("#44475A")).Foreground(lipgloss.Color("#F8F8F2"))
+
+// blockSelection is a rectangular (column) selection. Columns count runes
+// and may lie past the end of short lines, so the rectangle keeps its shape
+// while the cursor moves across them.
+type blockSelection struct {
+	active                bool
+	anchorLine, anchorCol int
+	line, col             int // Corner that moves with the arrow keys
+}
+
+// bounds returns the selected lines [l1, l2] and columns [c1, c2)
+func (b blockSelection) bounds() (l1, l2, c1, c2 int) {
+	return min(b.anchorLine, b.line), max(b.anchorLine, b.line), min(b.anchorCol, b.col), max(b.anchorCol, b.col)
+}
+
+// runeOffset is the byte offset of rune column col in s, clamped to len(s)
+func runeOffset(s string, col int) int {
+	for i := range s {
+		if col == 0 {
+			return i
+		}
+		col--
+	}
+	return len(s)
+}
+
+// toggleBlock starts or ends column selection at the cursor
+func (m *model) toggleBlock() {
+	if m.block.active {
+		m.block.active = false
+		m.status = "Column selection off"
+		m.syncEditorView()
+		return
+	}
+	line, col := lineCol(m.editor.content, m.editor.cursor)
+	m.block = blockSelection{active: true, anchorLine: line, anchorCol: col, line: line, col: col}
+	m.status = "Column selection: arrows extend, typing edits every line (Esc/Ctrl+B to end)"
+	m.syncEditorView()
+}
+
+// updateBlock handles a key while column selection is active. It reports
+// false for keys it does not own, which end the selection first.
+func (m *model) updateBlock(msg tea.KeyMsg) bool {
+	lines := strings.Split(m.editor.content, "\n")
+	b := &m.block
+	l1, l2, c1, c2 := b.bounds()
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlB:
+		m.toggleBlock()
+		return true
+	case tea.KeyUp:
+		b.line = max(b.line-1, 0)
+	case tea.KeyDown:
+		b.line = min(b.line+1, len(lines)-1)
+	case tea.KeyLeft:
+		b.col = max(b.col-1, 0)
+	case tea.KeyRight:
+		b.col++
+	case tea.KeyRunes, tea.KeySpace:
+		text := string(msg.Runes)
+		if msg.Type == tea.KeySpace {
+			text = " "
+		}
+		m.replaceBlock(lines, l1, l2, c1, c2, text)
+	case tea.KeyBackspace:
+		if c1 == c2 {
+			if c1 == 0 {
+				return true
+			}
+			c1--
+		}
+		m.replaceBlock(lines, l1, l2, c1, c2, "")
+	case tea.KeyDelete:
+		if c1 == c2 {
+			c2++
+		}
+		m.replaceBlock(lines, l1, l2, c1, c2, "")
+	default:
+		b.active = false
+		return false
+	}
+
+	// Park the real cursor on the moving corner (clamped to its line)
+	lines = strings.Split(m.editor.content, "\n")
+	offset := 0
+	for _, l := range lines[:b.line] {
+		offset += len(l) + 1
+	}
+	m.editor.cursor = offset + runeOffset(lines[b.line], b.col)
+	m.syncEditorView()
+	return true
+}
+
+// replaceBlock replaces columns [c1, c2) of lines l1..l2 with text, padding
+// short lines with spaces when inserting, and collapses the selection to a
+// zero-width column after the inserted text
+func (m *model) replaceBlock(lines []string, l1, l2, c1, c2 int, text string) {
+	for i := l1; i <= l2; i++ {
+		line := lines[i]
+		width := utf8.RuneCountInString(line)
+		if width < c1 {
+			if text == "" {
+				continue // Nothing to delete on this line
+			}
+			line += strings.Repeat(" ", c1-width)
+		}
+		lines[i] = line[:runeOffset(line, c1)] + text + line[runeOffset(line, c2):]
+	}
+	m.editor.content = strings.Join(lines, "\n")
+
+	col := c1 + utf8.RuneCountInString(text)
+	m.block.anchorCol, m.block.col = col, col
+}
+
+// highlightBlock marks the selected cells on the rendered lines. Positions
+// at or after cursorPos shift by cursorLen, as the "|" cursor is spliced in
+// there.
+func (m *model) highlightBlock(rawLines []string, val, codeWithCursor string, cursorPos, cursorLen int) {
+	l1, l2, c1, c2 := m.block.bounds()
+	c2 = max(c2, c1+1) // A zero-width column still shows where typing goes
+	lines := strings.Split(val, "\n")
+	offset := 0
+	for i, line := range lines {
+		if i > l2 {
+			break
+		}
+		if i >= l1 {
+			for col := c1; col < c2 && col < utf8.RuneCountInString(line); col++ {
+				p := offset + runeOffset(line, col)
+				if p >= cursorPos {
+					p += cursorLen
+				}
+				if l, c := lineCol(codeWithCursor, p); l < len(rawLines) {
+					rawLines[l] = styleVisibleRune(rawLines[l], c, blockSelectStyle)
+				}
+			}
+		}
+		offset += len(line) + 1
+	}
+}
+
+// blockStatus describes the selection for the status bar
+func (m *model) blockStatus() string {
+	l1, l2, c1, c2 := m.block.bounds()
+	return fmt.Sprintf("| Block: %d lines x %d cols ", l2-l1+1, c2-c1)
+}
@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Matching bracket highlight
+var bracketMatchStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#282A36")).
+	Background(lipgloss.Color("#50FA7B")). // Dracula Green
+	Bold(true)
+
+var bracketPairs = map[byte]byte{
+	'(': ')', '[': ']', '{': '}',
+	')': '(', ']': '[', '}': '{',
+}
+
+func isOpenBracket(c byte) bool {
+	return c == '(' || c == '[' || c == '{'
+}
+
+// codeMask marks which bytes of content are code, i.e. not inside a
+// string literal or comment, using the comment syntax of language.
+func codeMask(content, language string) []bool {
+	mask := make([]bool, len(content))
+
+	lineComment := "//"
+	blockComments := true
+	backtickStrings := false
+	switch language {
+	case "python":
+		lineComment = "#"
+		blockComments = false
+	case "zig":
+		blockComments = false
+	case "go", "javascript", "typescript":
+		backtickStrings = true
+	}
+
+	for i := 0; i < len(content); {
+		rest := content[i:]
+		switch {
+		case strings.HasPrefix(rest, lineComment):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return mask
+			}
+			i += end
+
+		case blockComments && strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return mask
+			}
+			i += end + 4
+
+		case language == "python" && (strings.HasPrefix(rest, `"""`) || strings.HasPrefix(rest, "'''")):
+			end := strings.Index(rest[3:], rest[:3])
+			if end < 0 {
+				return mask
+			}
+			i += end + 6
+
+		case rest[0] == '`' && backtickStrings:
+			end := strings.IndexByte(rest[1:], '`')
+			if end < 0 {
+				return mask
+			}
+			i += end + 2
+
+		case rest[0] == '"' || (rest[0] == '\'' && isQuoteString(rest, language)):
+			i += quotedLen(rest)
+
+		default:
+			mask[i] = true
+			i++
+		}
+	}
+	return mask
+}
+
+// isQuoteString reports whether a single quote starts a string/char literal.
+// Outside Python it only counts if it closes shortly after (a char literal),
+// so Rust lifetimes like 'a are not treated as strings.
+func isQuoteString(rest, language string) bool {
+	if language == "python" {
+		return true
+	}
+	if len(rest) > 1 && rest[1] == '\\' {
+		return true
+	}
+	end := strings.IndexByte(rest[1:], '\'')
+	return end >= 0 && end <= 4 && !strings.Contains(rest[1:end+1], "\n")
+}
+
+// quotedLen returns the length of the quoted literal at the start of rest,
+// honouring backslash escapes and stopping at end of line.
+func quotedLen(rest string) int {
+	quote := rest[0]
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(rest)
+}
+
+// findMatchingBracket looks for a bracket at the cursor (or just before it)
+// and returns its offset and the offset of its match.
+func findMatchingBracket(content string, cursor int, language string) (at, match int, ok bool) {
+	if cursor > len(content) {
+		cursor = len(content)
+	}
+	mask := codeMask(content, language)
+
+	candidates := []int{cursor, cursor - 1}
+	for _, pos := range candidates {
+		if pos < 0 || pos >= len(content) || !mask[pos] {
+			continue
+		}
+		if _, isBracket := bracketPairs[content[pos]]; !isBracket {
+			continue
+		}
+		if m := scanForMatch(content, mask, pos); m >= 0 {
+			return pos, m, true
+		}
+	}
+	return 0, 0, false
+}
+
+func scanForMatch(content string, mask []bool, pos int) int {
+	open := content[pos]
+	closeCh := bracketPairs[open]
+	step := 1
+	if !isOpenBracket(open) {
+		step = -1
+	}
+
+	depth := 0
+	for i := pos; i >= 0 && i < len(content); i += step {
+		if !mask[i] {
+			continue
+		}
+		switch content[i] {
+		case open:
+			depth++
+		case closeCh:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// jumpToMatchingBracket moves the cursor onto the bracket matching the
+// one under (or just before) the cursor.
+func (m *model) jumpToMatchingBracket() {
+	_, match, ok := findMatchingBracket(m.editor.content, m.editor.cursor, m.language)
+	if !ok {
+		m.status = "No matching bracket at cursor"
+		return
+	}
+	m.editor.cursor = match
+	m.status = "Jumped to matching bracket"
+	m.syncEditorView()
+}
+
+// lineCol converts a byte offset into a line index and rune column
+func lineCol(s string, offset int) (int, int) {
+	head := s[:offset]
+	line := strings.Count(head, "\n")
+	lineStart := strings.LastIndexByte(head, '\n') + 1
+	return line, utf8.RuneCountInString(head[lineStart:])
+}
+
+// styleVisibleRune restyles the rune at visible column col of an
+// ANSI-highlighted line, then restores the surrounding highlight.
+func styleVisibleRune(line string, col int, style lipgloss.Style) string {
+	var b strings.Builder
+	lastSGR := ""
+	visible := 0
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' && i+1 < len(line) && line[i+1] == '[' {
+			j := i + 2
+			for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+				j++
+			}
+			if j < len(line) {
+				j++
+			}
+			lastSGR = line[i:j]
+			b.WriteString(lastSGR)
+			i = j
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if visible == col {
+			b.WriteString(style.Render(string(r)))
+			b.WriteString(lastSGR)
+		} else {
+			b.WriteString(line[i : i+size])
+		}
+		visible++
+		i += size
+	}
+	return b.String()
+}
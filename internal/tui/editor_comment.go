@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lineCommentPrefixes maps editor languages to their line comment token
+var lineCommentPrefixes = map[string]string{
+	"go":         "//",
+	"c":          "//",
+	"cpp":        "//",
+	"csharp":     "//",
+	"java":       "//",
+	"rust":       "//",
+	"zig":        "//",
+	"javascript": "//",
+	"typescript": "//",
+	"php":        "//",
+	"swift":      "//",
+	"python":     "#",
+	"shell":      "#",
+	"yaml":       "#",
+	"sql":        "--",
+	"lua":        "--",
+}
+
+// toggleLineComment comments out the marked lines (or the cursor line), or
+// uncomments them when every non-blank line is already commented. The
+// prefix goes at the shallowest indentation so the block stays aligned.
+func (m *model) toggleLineComment() {
+	prefix, ok := lineCommentPrefixes[m.language]
+	if !ok {
+		m.status = fmt.Sprintf("No line comment syntax for %s", m.language)
+		return
+	}
+
+	content := m.editor.content
+	from, to := min(m.editor.cursor, len(content)), min(m.editor.cursor, len(content))
+	if m.markSet {
+		from = min(m.mark, len(content))
+		if from > to {
+			from, to = to, from
+		}
+	}
+	first, _ := lineCol(content, from)
+	last, _ := lineCol(content, to)
+	lines := strings.Split(content, "\n")
+
+	uncomment := true
+	indent := -1
+	for _, l := range lines[first : last+1] {
+		body := strings.TrimLeft(l, " \t")
+		if body == "" {
+			continue
+		}
+		if !strings.HasPrefix(body, prefix) {
+			uncomment = false
+		}
+		if lead := len(l) - len(body); indent < 0 || lead < indent {
+			indent = lead
+		}
+	}
+	if indent < 0 {
+		m.status = "Nothing to comment: the selected lines are empty"
+		return
+	}
+
+	// Each edited line changes at col by delta bytes; offsets after the
+	// edit move with it, offsets inside removed text snap to col
+	type lineEdit struct{ col, delta int }
+	edits := make([]lineEdit, len(lines))
+	for i := first; i <= last; i++ {
+		l := lines[i]
+		body := strings.TrimLeft(l, " \t")
+		if body == "" {
+			continue
+		}
+		if uncomment {
+			col := len(l) - len(body)
+			n := len(prefix)
+			if strings.HasPrefix(body[n:], " ") {
+				n++
+			}
+			lines[i] = l[:col] + body[n:]
+			edits[i] = lineEdit{col, -n}
+		} else {
+			lines[i] = l[:indent] + prefix + " " + l[indent:]
+			edits[i] = lineEdit{indent, len(prefix) + 1}
+		}
+	}
+
+	shift := func(offset int) int {
+		start, moved := 0, 0
+		for i, l := range strings.Split(content, "\n") {
+			end := start + len(l)
+			if offset <= end {
+				e := edits[i]
+				col := offset - start
+				switch {
+				case e.delta == 0 || col < e.col:
+				case e.delta < 0 && col < e.col-e.delta:
+					col = e.col
+				default:
+					col += e.delta
+				}
+				return start + moved + col
+			}
+			moved += edits[i].delta
+			start = end + 1
+		}
+		return offset + moved
+	}
+	m.editor.cursor = shift(min(m.editor.cursor, len(content)))
+	if m.markSet {
+		m.mark = shift(min(m.mark, len(content)))
+	}
+	m.editor.content = strings.Join(lines, "\n")
+	m.syncEditorView()
+
+	action := "Commented"
+	if uncomment {
+		action = "Uncommented"
+	}
+	if first == last {
+		m.status = fmt.Sprintf("%s line %d", action, first+1)
+	} else {
+		m.status = fmt.Sprintf("%s lines %d-%d", action, first+1, last+1)
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// discardAction is what the unsaved-changes prompt is asking about
+type discardAction int
+
+const (
+	discardLeave    discardAction = iota // Esc back to the language menu
+	discardNew                           // Ctrl+N
+	discardLanguage                      // A menu language that replaces the buffer
+	discardQuit                          // Ctrl+Q, or Ctrl+C in the menu
+	discardClose                         // Ctrl+W on a tab
+)
+
+// confirmDiscard asks before an action that would leave or replace a
+// buffer with unsaved changes
+func (m *model) confirmDiscard(action discardAction, lang string) {
+	m.discard, m.discardLang, m.discardFrom = action, lang, m.state
+	m.state = stateDiscardPrompt
+	m.status = "Discard unsaved changes? [y/N]"
+}
+
+// requestQuit quits the editor, asking first while any tab has unsaved
+// changes
+func (m *model) requestQuit() tea.Cmd {
+	if m.dirtyTabs() > 0 && !m.quitConfirmed {
+		m.confirmDiscard(discardQuit, "")
+		return nil
+	}
+	return tea.Quit
+}
+
+// updateDiscard answers the prompt: y carries the action out, any other
+// key returns to where it was asked
+func (m *model) updateDiscard(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() != "y" && msg.String() != "Y" {
+		m.state = m.discardFrom
+		m.status = "Kept unsaved changes (Ctrl+S to save)"
+		return nil
+	}
+
+	switch m.discard {
+	case discardLeave:
+		m.stopRepl()
+		m.leaveConfirmed = true
+		m.state = stateSelection
+		m.status = "Select an editor mode to begin"
+		m.updateLayout()
+	case discardNew:
+		m.state = stateEditor
+		m.newFile()
+	case discardLanguage:
+		m.selectLanguage(m.discardLang)
+	case discardClose:
+		m.state = m.discardFrom
+		m.dropTab()
+	case discardQuit:
+		m.stopRepl()
+		m.quitConfirmed = true
+		m.state = m.discardFrom
+		return tea.Quit
+	}
+	return nil
+}
+
+func (m model) discardPromptView() string {
+	name := "The untitled buffer"
+	if m.filename != "" {
+		name = filepath.Base(m.filename)
+	}
+	what := map[discardAction]string{
+		discardLeave:    "Leaving the editor",
+		discardNew:      "Starting a new file",
+		discardLanguage: "Switching to " + m.discardLang,
+		discardQuit:     "Quitting",
+		discardClose:    "Closing the tab",
+	}[m.discard]
+	return "\n=== Unsaved Changes ===\n\n" +
+		name + " has changes that were not saved.\n" +
+		what + " may lose them.\n\n" +
+		"Discard unsaved changes? [y/N] (Ctrl+S in the editor saves)"
+}
+
+// newFile replaces the buffer with an empty untitled one (Ctrl+N)
+func (m *model) newFile() {
+	m.filename = ""
+	m.editor.content = ""
+	m.editor.cursor = 0
+	m.savedContent = ""
+	m.readOnly = false
+	m.lineEnding, m.savedEOL = eolLF, eolLF
+	m.markSet = false
+	m.sel.active = false
+	m.resetUndo()
+	m.clearOutput()
+	removeSwap()
+	m.swapContent = ""
+	m.status = "New file created"
+}
+
+// selectLanguage opens the editor in lang, chosen in the menu. An untitled
+// buffer of another language is replaced by lang's starting code.
+func (m *model) selectLanguage(lang string) {
+	m.state = stateEditor
+	m.status = "Ready"
+	m.leaveConfirmed = false
+
+	// Buffer Isolation: Clear and inject boilerplate if switching languages on unsaved file
+	if lang != m.language && m.filename == "" {
+		m.editor.content = m.boilerplate(lang)
+		m.editor.cursor = len(m.editor.content)
+		m.savedContent = m.editor.content
+		m.resetUndo()
+	}
+
+	m.language = lang
+	m.restoreOutput()
+	m.updateLayout()
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// defaultIndent is inserted by Tab and smart Enter when neither an
+// .editorconfig nor editor.indent_style/indent_size set the indentation
+const defaultIndent = "    "
+
+// The DevCLI indentation settings: indent_style is "spaces" or "tabs",
+// indent_size the number of spaces (1-16)
+const (
+	indentStyleKey = "editor.indent_style"
+	indentSizeKey  = "editor.indent_size"
+)
+
+// configuredIndent reads the indentation settings; set is false when both
+// are empty, leaving the 4-space default
+func configuredIndent() (indent string, set bool) {
+	var c utils.EditorConfig
+	switch strings.ToLower(strings.TrimSpace(config.GetString(indentStyleKey))) {
+	case "tabs", "tab":
+		c.IndentStyle = "tab"
+	case "spaces", "space":
+		c.IndentStyle = "space"
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(config.GetString(indentSizeKey))); err == nil && n >= 1 && n <= 16 {
+		c.IndentSize = n
+	}
+	return c.Indent(defaultIndent), !c.Empty()
+}
+
+// editorConfig returns the .editorconfig properties of the open file. They
+// are read again when the file changes (tabs, Save As) and on every save.
+func (m *model) editorConfig() utils.EditorConfig {
+	if m.filename == "" {
+		return utils.EditorConfig{}
+	}
+	if m.editorConfigFor != m.filename {
+		m.editorConf, m.editorConfigFor = utils.FindEditorConfig(m.filename), m.filename
+	}
+	return m.editorConf
+}
+
+// indentUnit is one level of indentation for the open file. Makefile
+// recipes must start with a tab, whatever the settings say.
+func (m *model) indentUnit() string {
+	if isMakefile(m.filename) {
+		return "\t"
+	}
+	return m.editorConfig().Indent(m.indent)
+}
+
+// newline is what Enter inserts at pos, and where the cursor goes within
+// it. The new line keeps the current line's indentation, one level deeper
+// after an opening brace (or a colon in Python). Between braces, e.g.
+// "{|}", the closing brace moves to its own line at the outer level.
+func (m *model) newline(val string, pos int) (text string, cursor int) {
+	lineStart := strings.LastIndexByte(val[:pos], '\n') + 1
+	before := val[lineStart:pos]
+	lead := before[:len(before)-len(strings.TrimLeft(before, " \t"))]
+
+	inner := lead
+	trimmed := strings.TrimRight(before, " \t")
+	if strings.HasSuffix(trimmed, "{") || (m.language == "python" && strings.HasSuffix(trimmed, ":")) {
+		inner += m.indentUnit()
+	}
+	if pos < len(val) && val[pos] == '}' && strings.HasSuffix(trimmed, "{") {
+		return "\n" + inner + "\n" + lead, 1 + len(inner)
+	}
+	return "\n" + inner, 1 + len(inner)
+}
+
+func isMakefile(name string) bool {
+	base := strings.ToLower(filepath.Base(name))
+	return base == "makefile" || base == "gnumakefile" || strings.HasSuffix(base, ".mk")
+}
+
+// boilerplate is the starting code of lang, re-indented with the
+// configured indentation when editor.indent_style or indent_size is set.
+// The templates indent with tabs or 4 spaces per level.
+func (m *model) boilerplate(lang string) string {
+	code := getBoilerplate(lang)
+	if !m.indentSet {
+		return code
+	}
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		rest := strings.TrimLeft(line, " \t")
+		lead := line[:len(line)-len(rest)]
+		levels := strings.Count(lead, "\t") + strings.Count(lead, " ")/4
+		lines[i] = strings.Repeat(m.indent, levels) + rest
+	}
+	return strings.Join(lines, "\n")
+}
+
+// editorConfigFlag reads an .editorconfig boolean, falling back to the
+// DevCLI setting under key when the file leaves it unset
+func editorConfigFlag(value, key string) bool {
+	if value != "" {
+		return value == "true"
+	}
+	return strings.EqualFold(config.GetString(key), "true")
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// Config keys for the whitespace cleanup done when saving with Ctrl+S.
+// Both are off unless set to "true".
+const (
+	trimWhitespaceKey = "editor.trim_trailing_whitespace"
+	finalNewlineKey   = "editor.final_newline"
+)
+
+// Line ending styles. The buffer always holds "\n"; the file's style is
+// restored on save.
+const (
+	eolLF   = "LF"
+	eolCRLF = "CRLF"
+)
+
+// splitLineEndings normalizes content to "\n" and reports the file's
+// dominant line ending and whether it mixed both styles
+func splitLineEndings(content string) (normalized, eol string, mixed bool) {
+	crlf := strings.Count(content, "\r\n")
+	lf := strings.Count(content, "\n") - crlf
+
+	eol = eolLF
+	if crlf > lf {
+		eol = eolCRLF
+	}
+	normalized = strings.ReplaceAll(content, "\r\n", "\n")
+	normalized = strings.ReplaceAll(normalized, "\r", "") // Stray carriage returns
+	return normalized, eol, crlf > 0 && lf > 0
+}
+
+// withLineEnding converts a "\n" buffer to eol for writing
+func withLineEnding(content, eol string) string {
+	if eol == eolCRLF {
+		return strings.ReplaceAll(content, "\n", "\r\n")
+	}
+	return content
+}
+
+// mixedEOLNotice explains what happens to a file that mixed line endings
+func mixedEOLNotice(eol string) string {
+	return fmt.Sprintf("Mixed line endings: normalized, will save as %s (Alt+L to switch)", eol)
+}
+
+// toggleLineEnding switches the active buffer between LF and CRLF. The
+// change is written on the next save.
+func (m *model) toggleLineEnding() {
+	if m.lineEnding == eolCRLF {
+		m.lineEnding = eolLF
+	} else {
+		m.lineEnding = eolCRLF
+	}
+	m.status = fmt.Sprintf("Line endings: %s (Ctrl+S to save)", m.lineEnding)
+}
+
+// cleanupOnSave strips trailing whitespace and fixes the final newline as
+// the file's .editorconfig or the DevCLI settings ask, keeping the cursor
+// and mark on the same text
+func (m *model) cleanupOnSave() {
+	m.editorConfigFor = "" // Pick up edits to .editorconfig
+	ec := m.editorConfig()
+	trim := editorConfigFlag(ec.TrimTrailingWhitespace, trimWhitespaceKey)
+	finalNewline := editorConfigFlag(ec.InsertFinalNewline, finalNewlineKey)
+	if !trim && !finalNewline {
+		return
+	}
+	content, cursor := utils.CleanWhitespace(m.editor.content, m.editor.cursor, trim, finalNewline)
+	if content == m.editor.content {
+		return
+	}
+	if m.markSet {
+		_, m.mark = utils.CleanWhitespace(m.editor.content, m.mark, trim, finalNewline)
+	}
+	m.editor.content, m.editor.cursor = content, cursor
+	m.syncEditorView()
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/runner"
+)
+
+var errorLocationStyle = lipgloss.NewStyle().Background(lipgloss.Color("#44475A")).Foreground(lipgloss.Color("#F1FA8C"))
+
+// errorLocationPatterns match the file positions compilers and runtimes
+// print. Each has three groups: file, line and an optional column.
+var errorLocationPatterns = []*regexp.Regexp{
+	// Python tracebacks: File "script.py", line 3
+	regexp.MustCompile(`File "([^"]+)", line (\d+)()`),
+	// csc/MSBuild and tsc: Program.cs(12,5): error CS1002
+	regexp.MustCompile(`((?:[A-Za-z]:)?[\w./\\-]*\.\w+)\((\d+),(\d+)\)`),
+	// gcc, clang, go, rustc, zig, javac and stack traces: main.c:12:5:
+	regexp.MustCompile(`((?:[A-Za-z]:)?[\w./\\-]*\.\w+):(\d+)(?::(\d+))?`),
+}
+
+// errorLocation is a position in the buffer reported by the last run
+type errorLocation struct {
+	outLine   int // Line of the output it was printed on
+	line, col int // 1-based; col is 0 when only a line was given
+}
+
+// findErrorLocations collects the positions in the output that refer to
+// the active buffer, shifted by m.runLineOffset for selection runs
+func (m *model) findErrorLocations() {
+	m.errorLocs, m.errorSel = nil, -1
+	if m.runLineOffset < 0 {
+		return // Wrapped selection: lines refer to the generated main()
+	}
+
+	names := map[string]bool{}
+	if r, ok := runner.Lookup(m.language); ok {
+		names[r.SourceName(m.editor.content)] = true // The file runCode wrote
+	}
+	if m.filename != "" {
+		names[baseName(m.filename)] = true
+	}
+
+	lines := strings.Split(m.output, "\n")
+	for i, l := range lines {
+		for _, re := range errorLocationPatterns {
+			sub := re.FindStringSubmatch(l)
+			if sub == nil || !names[baseName(sub[1])] {
+				continue
+			}
+			line, _ := strconv.Atoi(sub[2])
+			col, _ := strconv.Atoi(sub[3])
+			m.errorLocs = append(m.errorLocs, errorLocation{outLine: i, line: line + m.runLineOffset, col: col})
+			break
+		}
+	}
+}
+
+// baseName strips a path using either separator, since compilers report
+// Windows paths on Windows only
+func baseName(path string) string {
+	return path[strings.LastIndexAny(path, `/\`)+1:]
+}
+
+// updateErrorNav handles Tab, Shift+Tab and Enter while the output pane
+// is focused and shows error locations. It reports false for other keys.
+func (m *model) updateErrorNav(msg tea.KeyMsg) bool {
+	n := len(m.errorLocs)
+	switch msg.String() {
+	case "tab":
+		m.selectErrorLocation((m.errorSel + 1) % n)
+	case "shift+tab":
+		m.selectErrorLocation((m.errorSel - 1 + n) % n)
+	case "enter":
+		if m.errorSel < 0 {
+			m.selectErrorLocation(0)
+		}
+		m.jumpToErrorLocation()
+	default:
+		return false
+	}
+	return true
+}
+
+// selectErrorLocation highlights location i in the output and scrolls it
+// into view
+func (m *model) selectErrorLocation(i int) {
+	m.errorSel = i
+	loc := m.errorLocs[i]
+
+	lines := strings.Split(m.output, "\n")
+	lines[loc.outLine] = errorLocationStyle.Render(lines[loc.outLine])
+	m.outputView.SetContent(strings.Join(lines, "\n"))
+	m.outputView.SetYOffset(loc.outLine - m.outputView.Height/2)
+
+	m.status = fmt.Sprintf("Location %d/%d: %s (Enter to jump, Tab/Shift+Tab for others)", i+1, len(m.errorLocs), loc)
+}
+
+// clickErrorLocation jumps to the location printed on the output line at
+// screen row y. It reports false when that row holds none.
+func (m *model) clickErrorLocation(y int) bool {
+	if len(m.errorLocs) == 0 {
+		return false
+	}
+	rows := strings.Split(m.View(), "\n")
+	if over := len(rows) - m.height; m.height > 0 && over > 0 {
+		y += over // A view taller than the terminal loses its top rows
+	}
+	title := outputTitleStyle.Render(m.outputTitle())
+	for i, row := range rows {
+		if row != title {
+			continue
+		}
+		top := i + 2 // Below the title and the box's top border
+		if y < top || y >= top+m.outputView.Height {
+			return false
+		}
+		outLine := y - top + m.outputView.YOffset
+		for j, loc := range m.errorLocs {
+			if loc.outLine == outLine {
+				m.selectErrorLocation(j)
+				m.jumpToErrorLocation()
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// outputTitle is the line above the output pane
+func (m *model) outputTitle() string {
+	if repl := m.replTitle(); repl != "" {
+		return repl
+	}
+	cwd, _ := os.Getwd()
+	title := fmt.Sprintf("Output (Executed in: %s) [Ctrl+E: Editor | Ctrl+M: Maximize | Ctrl+↑/↓: Resize]", cwd)
+	if m.activeView == viewOutput {
+		title = " >> " + title + " << "
+	}
+	return title
+}
+
+// jumpToErrorLocation moves the cursor to the selected location and
+// focuses the editor. Positions past the end of the buffer are clamped.
+func (m *model) jumpToErrorLocation() {
+	loc := m.errorLocs[m.errorSel]
+	m.gotoLineCol(loc.line, loc.col)
+	m.status = fmt.Sprintf("Jumped to %s (Ctrl+O: back to output)", loc)
+}
+
+// gotoLineCol moves the cursor to a 1-based line and column (0 for the
+// start of the line) and focuses the editor
+func (m *model) gotoLineCol(line, col int) {
+	lines := strings.Split(m.editor.content, "\n")
+	line = min(max(line, 1), len(lines)) - 1
+
+	offset := 0
+	for _, l := range lines[:line] {
+		offset += len(l) + 1
+	}
+	offset += runeOffset(lines[line], max(col-1, 0))
+
+	m.editor.cursor = offset
+	m.activeView = viewEditor
+	m.syncEditorView()
+}
+
+func (l errorLocation) String() string {
+	if l.col > 0 {
+		return fmt.Sprintf("line %d, column %d", l.line, l.col)
+	}
+	return fmt.Sprintf("line %d", l.line)
+}
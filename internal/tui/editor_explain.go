@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/phravins/devcli/internal/ai"
+	"github.com/phravins/devcli/internal/ai/providers"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Limits on what is attached to an "explain this error" request
+const (
+	explainMaxOutput = 8 << 10
+	explainMaxCode   = 16 << 10
+)
+
+// explainResultMsg carries the AI's answer; id ties it to the request so a
+// reply to an aborted request is dropped
+type explainResultMsg struct {
+	id   int
+	text string
+	err  error
+}
+
+// truncateMiddle keeps the head and tail of s within max bytes. Compilers
+// report the first error at the top, runtimes print tracebacks at the end.
+func truncateMiddle(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	half := max / 2
+	return s[:half] + fmt.Sprintf("\n... [%d bytes omitted] ...\n", len(s)-2*half) + s[len(s)-half:]
+}
+
+// explainError sends the buffer and the failed run's output to the
+// configured AI provider using the Debugger agent prompt
+func (m *model) explainError() tea.Cmd {
+	if m.explaining {
+		m.status = "Already asking the AI (Esc to abort)"
+		return nil
+	}
+	if m.lastError == "" {
+		m.status = "Nothing to explain: run the code (Ctrl+R) and hit an error first"
+		return nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		m.status = fmt.Sprintf("Config error: %v", err)
+		return nil
+	}
+	provider, err := providers.GetProvider(cfg)
+	if err != nil {
+		m.status = fmt.Sprintf("AI unavailable: %v (configure it in Settings)", err)
+		return nil
+	}
+
+	prompt := fmt.Sprintf("This %s program failed. Explain the error in plain terms and suggest a concrete fix, "+
+		"quoting only the lines that need to change.\n\n## Code\n```%s\n%s\n```\n\n## Output\n```\n%s\n```",
+		m.language, m.language, truncateMiddle(m.editor.content, explainMaxCode), truncateMiddle(m.lastError, explainMaxOutput))
+	messages := []ai.Message{
+		{Role: "system", Content: agentSystemPrompts[debuggerAgent]},
+		{Role: "user", Content: prompt},
+	}
+
+	m.explainID++
+	id := m.explainID
+	m.explaining = true
+	m.status = fmt.Sprintf("Asking %s to explain the error... (Esc to abort)", provider.Name())
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		resp, err := provider.Send(messages)
+		return explainResultMsg{id: id, text: resp, err: err}
+	})
+}
+
+// showExplanation renders the AI answer into the explanation panel
+func (m *model) showExplanation(text string) {
+	width := m.width - 10
+	if width < 40 {
+		width = 40
+	}
+	m.explainView.Width = width
+	m.explainView.Height = m.height - 8
+
+	out := text
+	if renderer, err := glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(width-4)); err == nil {
+		if rendered, err := renderer.Render(text); err == nil {
+			out = rendered
+		}
+	}
+	m.explainView.SetContent(out)
+	m.explainView.GotoTop()
+	m.showExplain = true
+}
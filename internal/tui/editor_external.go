@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/procs"
+	"github.com/phravins/devcli/internal/runner"
+)
+
+// externalRunMsg reports whether the buffer was handed to a terminal window
+type externalRunMsg struct {
+	terminal string
+	err      error
+}
+
+// linuxTerminals are tried in order when $TERMINAL is unset, each with the
+// flag that makes it run a command
+var linuxTerminals = [][]string{
+	{"x-terminal-emulator", "-e"},
+	{"gnome-terminal", "--"},
+	{"konsole", "-e"},
+	{"xfce4-terminal", "-x"},
+	{"kitty"},
+	{"alacritty", "-e"},
+	{"wezterm", "start", "--"},
+	{"foot"},
+	{"xterm", "-e"},
+}
+
+// terminalCommand returns the command that opens script in a new terminal
+// window and the terminal's name, or an error when none can be found
+func terminalCommand(script string) ([]string, string, error) {
+	if runtime.GOOS == "windows" {
+		if wt, err := exec.LookPath("wt.exe"); err == nil {
+			return []string{wt, "-d", filepath.Dir(script), "cmd", "/c", script}, "Windows Terminal", nil
+		}
+		return []string{"cmd", "/c", "start", "DevCLI", "cmd", "/c", script}, "cmd", nil
+	}
+
+	if term := strings.Fields(os.Getenv("TERMINAL")); len(term) > 0 {
+		if _, err := exec.LookPath(term[0]); err == nil {
+			return append(term, "-e", "sh", script), filepath.Base(term[0]), nil
+		}
+	}
+	if runtime.GOOS == "darwin" {
+		// Terminal.app runs .command files it is asked to open
+		return []string{"open", "-a", "Terminal", script}, "Terminal.app", nil
+	}
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return nil, "", fmt.Errorf("no graphical session to open a terminal in")
+	}
+	for _, t := range linuxTerminals {
+		if _, err := exec.LookPath(t[0]); err == nil {
+			return append(append([]string{}, t...), "sh", script), t[0], nil
+		}
+	}
+	return nil, "", fmt.Errorf("no terminal emulator found (set $TERMINAL)")
+}
+
+// quoteArg quotes one argument for the platform shell
+func quoteArg(arg string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + arg + `"`
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// joinSteps quotes each command and chains them with &&
+func joinSteps(steps [][]string) string {
+	var lines []string
+	for _, step := range steps {
+		quoted := make([]string, len(step))
+		for i, arg := range step {
+			quoted[i] = quoteArg(arg)
+		}
+		lines = append(lines, strings.Join(quoted, " "))
+	}
+	return strings.Join(lines, " && ")
+}
+
+// runScript chains steps with && in dir, waits for Enter so the output
+// stays readable, then deletes dir
+func runScript(dir string, steps [][]string) string {
+	chain := joinSteps(steps)
+
+	if runtime.GOOS == "windows" {
+		return strings.Join([]string{
+			"@echo off",
+			"cd /d " + quoteArg(dir),
+			chain,
+			"echo.",
+			"echo [Exited with code %errorlevel%]",
+			"pause",
+			"cd \\ & rmdir /s /q " + quoteArg(dir) + " & exit",
+		}, "\r\n") + "\r\n"
+	}
+	return strings.Join([]string{
+		"#!/bin/sh",
+		"cd " + quoteArg(dir) + " || exit 1",
+		chain,
+		"status=$?",
+		"echo",
+		`printf '[Exited with code %d] Press Enter to close... ' "$status"`,
+		"read _",
+		"cd / && rm -rf " + quoteArg(dir),
+	}, "\n") + "\n"
+}
+
+// externalSteps writes code into dir and returns the compile and run
+// commands for language, mirroring runCode
+func (m *model) externalSteps(language, code, dir string) ([][]string, error) {
+	r, ok := runner.Lookup(language)
+	if !ok {
+		return nil, fmt.Errorf("no runner defined for language: %s", language)
+	}
+	tools, err := r.Resolve(m.resolveExecutable)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.NewBuild(code, dir, tools)
+	if err != nil {
+		return nil, err
+	}
+	if r.Setup != nil {
+		// Run here rather than in the script: a project template would
+		// overwrite the source written below
+		argv := r.Setup(b)
+		setup := exec.CommandContext(context.Background(), argv[0], argv[1:]...)
+		setup.Dir = dir
+		if out, err := procs.CombinedOutput(setup, "editor: setup"); err != nil {
+			return nil, fmt.Errorf("setup failed: %v\n%s", err, procs.DecodeOutput(out, language))
+		}
+	}
+	if err := b.WriteSource(code); err != nil {
+		return nil, err
+	}
+
+	var steps [][]string
+	if r.Compile != nil {
+		steps = append(steps, r.Compile(b))
+	}
+	return append(steps, r.Run(b)), nil
+}
+
+// runExternal handles Alt+E: the buffer is built and run in a new terminal
+// window with a real TTY, for interactive programs the output pane can't
+// drive. The TUI is not blocked and the run has no timeout.
+func (m *model) runExternal() tea.Cmd {
+	code, language := m.editor.content, m.language
+	return func() tea.Msg {
+		dir, err := os.MkdirTemp("", "devcli_term_*")
+		if err != nil {
+			return externalRunMsg{err: fmt.Errorf("failed to create temp dir: %v", err)}
+		}
+		fail := func(err error) tea.Msg {
+			os.RemoveAll(dir)
+			return externalRunMsg{err: err}
+		}
+
+		steps, err := m.externalSteps(language, code, dir)
+		if err != nil {
+			return fail(err)
+		}
+		name := "run.sh"
+		switch runtime.GOOS {
+		case "windows":
+			name = "run.bat"
+		case "darwin":
+			name = "run.command"
+		}
+		script := filepath.Join(dir, name)
+		if err := os.WriteFile(script, []byte(runScript(dir, steps)), 0755); err != nil {
+			return fail(err)
+		}
+
+		argv, terminal, err := terminalCommand(script)
+		if err != nil {
+			return fail(err)
+		}
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Dir = dir
+		if err := cmd.Start(); err != nil {
+			return fail(fmt.Errorf("failed to start %s: %v", terminal, err))
+		}
+		go cmd.Wait() // Reap the launcher; the window outlives it
+		return externalRunMsg{terminal: terminal}
+	}
+}
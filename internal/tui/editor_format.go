@@ -0,0 +1,223 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v2"
+)
+
+// formatResultMsg carries the reformatted buffer back to the editor
+type formatResultMsg struct {
+	original string // Buffer the formatter ran on
+	content  string
+	tool     string
+	err      error
+}
+
+// formatDocument reformats the buffer according to m.language
+func (m *model) formatDocument() tea.Cmd {
+	if m.guardReadOnly() {
+		return nil
+	}
+	switch m.language {
+	case "go":
+		m.status = "Formatting Go code..."
+		return m.formatGoCmd()
+	case "json", "yaml":
+		m.formatDataDocument()
+		return nil
+	default:
+		m.status = fmt.Sprintf("No formatter available for %s", m.language)
+		return nil
+	}
+}
+
+// formatGoCmd pipes the buffer through goimports (format + fix imports),
+// falling back to gofmt when goimports is not installed.
+func (m *model) formatGoCmd() tea.Cmd {
+	original := m.editor.content
+	return func() tea.Msg {
+		userHome, _ := os.UserHomeDir()
+		goBin := filepath.Join(userHome, "go", "bin")
+
+		tool := "goimports"
+		path := m.resolveExecutable("goimports", []string{
+			filepath.Join(goBin, "goimports"),
+			filepath.Join(goBin, "goimports.exe"),
+		})
+		if path == "" {
+			tool = "gofmt"
+			path = m.resolveExecutable("gofmt", []string{
+				`C:\Program Files\Go\bin\gofmt.exe`,
+				`C:\Go\bin\gofmt.exe`,
+			})
+		}
+		if path == "" {
+			return formatResultMsg{original: original, err: fmt.Errorf("neither goimports nor gofmt found")}
+		}
+
+		cmd := exec.Command(path)
+		cmd.Stdin = strings.NewReader(original)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			msg := strings.TrimSpace(strings.ReplaceAll(stderr.String(), "<standard input>", "line"))
+			if msg == "" {
+				msg = err.Error()
+			}
+			return formatResultMsg{original: original, tool: tool, err: fmt.Errorf("%s", msg)}
+		}
+		return formatResultMsg{original: original, content: stdout.String(), tool: tool}
+	}
+}
+
+// formatDataDocument validates a JSON/YAML buffer and pretty-prints it.
+// On a syntax error the cursor is moved to the reported position.
+func (m *model) formatDataDocument() {
+	var (
+		formatted string
+		line, col int
+		err       error
+	)
+	if m.language == "json" {
+		formatted, line, col, err = formatJSON(m.editor.content)
+	} else {
+		formatted, line, col, err = formatYAML(m.editor.content)
+	}
+
+	kind := strings.ToUpper(m.language)
+	switch {
+	case err != nil && line > 0:
+		m.editor.cursor = cursorOffset(m.editor.content, line-1, col-1)
+		m.syncEditorView()
+		m.status = fmt.Sprintf("Invalid %s at line %d, col %d: %v", kind, line, col, err)
+	case err != nil:
+		m.status = fmt.Sprintf("Invalid %s: %v", kind, err)
+	case formatted == "":
+		m.status = fmt.Sprintf("Valid %s (not reformatted: comments would be lost)", kind)
+	case formatted == m.editor.content:
+		m.status = fmt.Sprintf("Valid %s, already formatted", kind)
+	default:
+		m.applyFormatted(formatted)
+		m.status = fmt.Sprintf("Valid %s, reformatted", kind)
+	}
+}
+
+// formatJSON re-indents JSON, preserving key order and number literals.
+// Error positions are 1-based.
+func formatJSON(content string) (string, int, int, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(content), "", "  "); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset := int(syntaxErr.Offset)
+			if offset > 0 {
+				offset-- // Offset points just past the bad byte
+			}
+			if offset > len(content) {
+				offset = len(content)
+			}
+			line, col := lineCol(content, offset)
+			return "", line + 1, col + 1, err
+		}
+		return "", 0, 0, err
+	}
+	return buf.String() + "\n", 0, 0, nil
+}
+
+var yamlErrLine = regexp.MustCompile(`line (\d+)`)
+
+// formatYAML validates every document and re-emits them in canonical
+// form. Documents with comments are validated only (formatted == "")
+// because the YAML round trip would drop the comments.
+func formatYAML(content string) (string, int, int, error) {
+	var docs []yaml.MapSlice
+	dec := yaml.NewDecoder(strings.NewReader(content))
+	for {
+		var doc yaml.MapSlice
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line := 0
+			if m := yamlErrLine.FindStringSubmatch(err.Error()); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+			return "", line, 1, err
+		}
+		docs = append(docs, doc)
+	}
+
+	if hasYAMLComments(content) {
+		return "", 0, 0, nil
+	}
+
+	var out strings.Builder
+	for i, doc := range docs {
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		b, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", 0, 0, err
+		}
+		out.Write(b)
+	}
+	return out.String(), 0, 0, nil
+}
+
+func hasYAMLComments(content string) bool {
+	for _, l := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(l)
+		if strings.HasPrefix(trimmed, "#") || strings.Contains(l, " #") {
+			return true
+		}
+	}
+	return false
+}
+
+// cursorOffset converts a 0-based line/column into a byte offset, clamped to the content
+func cursorOffset(content string, line, col int) int {
+	lines := strings.Split(content, "\n")
+	if line >= len(lines) {
+		line = len(lines) - 1
+	}
+	if line < 0 {
+		line = 0
+	}
+	if col > len(lines[line]) {
+		col = len(lines[line])
+	}
+	if col < 0 {
+		col = 0
+	}
+	offset := 0
+	for _, l := range lines[:line] {
+		offset += len(l) + 1
+	}
+	return offset + col
+}
+
+// applyFormatted replaces the buffer, keeping the cursor on the same line
+func (m *model) applyFormatted(content string) {
+	head := m.editor.content[:m.editor.cursor]
+	line := strings.Count(head, "\n")
+	col := len(head) - (strings.LastIndexByte(head, '\n') + 1)
+
+	m.editor.content = content
+	m.editor.cursor = cursorOffset(content, line, col)
+	m.syncEditorView()
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/phravins/devcli/internal/project"
+)
+
+// gitStatusText is the status bar segment for the open file's git state,
+// e.g. "| git: main modified ". It is empty outside a repository and until
+// the first background read of the folder finishes.
+func (m *model) gitStatusText() string {
+	if m.filename == "" {
+		return ""
+	}
+	st, ok := project.CachedGit(filepath.Dir(m.filename))
+	if !ok {
+		return ""
+	}
+	state, ok := st.FileState(m.filename)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("| git: %s %s ", st.Branch, state)
+}
+
+// refreshGitStatus re-reads the file's git state on the next render, after
+// a save, rename or shell command may have changed it
+func (m *model) refreshGitStatus() {
+	if m.filename != "" {
+		project.RefreshGit(filepath.Dir(m.filename))
+	}
+}
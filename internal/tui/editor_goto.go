@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startGoto prompts for a line to jump to (Ctrl+G)
+func (m *model) startGoto() {
+	m.state = stateGotoPrompt
+	m.saveInput.Reset()
+	m.saveInput.Focus()
+	total := strings.Count(m.editor.content, "\n") + 1
+	m.status = fmt.Sprintf("Go to line (1-%d), optionally line:column...", total)
+}
+
+// updateGotoPrompt handles a key in the jump-to-line prompt
+func (m *model) updateGotoPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		input := strings.TrimSpace(m.saveInput.Value())
+		m.saveInput.Reset()
+		m.state = stateEditor
+		line, col, ok := parseGotoTarget(input)
+		if !ok {
+			m.status = fmt.Sprintf("Not a line number: %q", input)
+			return nil
+		}
+		m.gotoLineCol(line, col)
+		current, _ := lineCol(m.editor.content, m.editor.cursor)
+		m.status = fmt.Sprintf("Line %d", current+1)
+		return nil
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.saveInput.Reset()
+		m.state = stateEditor
+		m.status = "Go to line cancelled"
+		return nil
+	}
+	var cmd tea.Cmd
+	m.saveInput, cmd = m.saveInput.Update(msg)
+	return cmd
+}
+
+// parseGotoTarget reads "12" or "12:5" (also "12,5"); out-of-range values
+// are clamped by gotoLineCol
+func parseGotoTarget(input string) (line, col int, ok bool) {
+	lineStr, colStr, hasCol := strings.Cut(strings.ReplaceAll(input, ",", ":"), ":")
+	line, err := strconv.Atoi(strings.TrimSpace(lineStr))
+	if err != nil {
+		return 0, 0, false
+	}
+	if hasCol {
+		if col, err = strconv.Atoi(strings.TrimSpace(colStr)); err != nil {
+			return 0, 0, false
+		}
+	}
+	return line, col, true
+}
+
+func (m model) gotoPromptView() string {
+	total := strings.Count(m.editor.content, "\n") + 1
+	return fmt.Sprintf("\n=== Go to Line ===\n\n"+
+		"The buffer has %d lines.\n"+
+		"Line (or line:column): %s\n\n"+
+		"Press Enter to jump, Esc to cancel.", total, m.saveInput.View())
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readInsertable reads path for insertion into the buffer, applying the
+// same size limit as opening a file and refusing binary content
+func readInsertable(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+	if limit := maxOpenBytes(); info.Size() > limit {
+		return "", fmt.Errorf("%s is %s, over the %s limit (editor.max_open_bytes)",
+			filepath.Base(path), formatBytes(info.Size()), formatBytes(limit))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if looksBinary(data) {
+		return "", fmt.Errorf("%s looks like a binary file", filepath.Base(path))
+	}
+	return strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+}
+
+// insertFile inserts the contents of path at the cursor and moves the
+// cursor past the inserted text
+func (m *model) insertFile(path string) error {
+	text, err := readInsertable(path)
+	if err != nil {
+		return err
+	}
+
+	pos := min(m.editor.cursor, len(m.editor.content))
+	m.editor.content = m.editor.content[:pos] + text + m.editor.content[pos:]
+	m.editor.cursor = pos + len(text)
+	m.syncEditorView()
+	m.status = fmt.Sprintf("Inserted %s (%d lines)", filepath.Base(path), strings.Count(strings.TrimSuffix(text, "\n"), "\n")+1)
+	return nil
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Config keys for the editor's output pane layout
+const (
+	outputRatioKey     = "editor.output_ratio"
+	outputMaximizedKey = "editor.output_maximized"
+
+	defaultOutputRatio = 50
+	minOutputRatio     = 10
+	maxOutputRatio     = 90
+	outputRatioStep    = 5
+)
+
+// savedOutputRatio returns the persisted output pane share (percent)
+func savedOutputRatio() int {
+	ratio, err := strconv.Atoi(strings.TrimSpace(config.GetString(outputRatioKey)))
+	if err != nil || ratio < minOutputRatio || ratio > maxOutputRatio {
+		return defaultOutputRatio
+	}
+	return ratio
+}
+
+func savedOutputMaximized() bool {
+	return config.GetString(outputMaximizedKey) == "true"
+}
+
+// toggleOutputMaximized flips Ctrl+M and remembers the choice
+func (m *model) toggleOutputMaximized() {
+	m.outputMaximized = !m.outputMaximized
+	if err := config.SaveConfig(outputMaximizedKey, m.outputMaximized); err != nil {
+		m.status = fmt.Sprintf("Output pane layout not saved: %v", err)
+	}
+	m.updateLayout()
+}
+
+// resizeOutput grows (delta > 0) or shrinks the output pane and saves the
+// new ratio. Resizing leaves maximized mode.
+func (m *model) resizeOutput(delta int) {
+	if m.outputMaximized {
+		m.outputMaximized = false
+		config.Set(outputMaximizedKey, false)
+	}
+	m.outputRatio = max(minOutputRatio, min(maxOutputRatio, m.outputRatio+delta))
+	if err := config.SaveConfig(outputRatioKey, m.outputRatio); err != nil {
+		m.status = fmt.Sprintf("Output pane: %d%% (not saved: %v)", m.outputRatio, err)
+	} else {
+		m.status = fmt.Sprintf("Output pane: %d%%", m.outputRatio)
+	}
+	m.updateLayout()
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+)
+
+const (
+	defaultMaxOpenBytes = 4 << 20  // Default for editor.max_open_bytes
+	hexPreviewBytes     = 64 << 10 // How much of an oversized file the preview shows
+)
+
+// maxOpenBytes is the largest file the editor loads for editing
+func maxOpenBytes() int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(config.GetString("editor.max_open_bytes")), 10, 64)
+	if err != nil || n <= 0 {
+		return defaultMaxOpenBytes
+	}
+	return n
+}
+
+// formatBytes renders a size as B/KB/MB/GB for status messages
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// loadFileForEditor reads path for editing. Files over editor.max_open_bytes
+// are not loaded; instead a read-only hexdump of their start is returned
+// together with a status message explaining why.
+func loadFileForEditor(path string) (content string, readOnly bool, notice string, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, "", err
+	}
+	if limit := maxOpenBytes(); info.Size() > limit {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", false, "", err
+		}
+		defer f.Close()
+
+		head := make([]byte, hexPreviewBytes)
+		n, err := io.ReadFull(f, head)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return "", false, "", err
+		}
+		notice = fmt.Sprintf("%s is %s, over the %s limit (editor.max_open_bytes): read-only hex preview, Alt+H pages through all of it",
+			filepath.Base(path), formatBytes(info.Size()), formatBytes(limit))
+		content = fmt.Sprintf("# %s\n# First %s of %s\n\n%s", notice, formatBytes(int64(n)), formatBytes(info.Size()), hex.Dump(head[:n]))
+		return content, true, notice, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, "", err
+	}
+	return string(data), false, "", nil
+}
+
+// opensInEditor decides whether opening a file in the File Manager (Enter
+// or a click, see openSelected) uses the editor or hands it to the OS.
+// editor.external_extensions always go to the OS; if editor.open_extensions
+// is set, only those open in the editor, otherwise everything but documents
+// and media (systemExtensions) does.
+func opensInEditor(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	if newExtSet(config.GetStringSlice("editor.external_extensions"))[ext] {
+		return false
+	}
+	if allow := newExtSet(config.GetStringSlice("editor.open_extensions")); len(allow) > 0 {
+		return allow[ext]
+	}
+	return !systemExtensions[ext]
+}
+
+// guardReadOnly reports whether the buffer is a read-only preview, telling
+// the user why the command was refused
+func (m *model) guardReadOnly() bool {
+	if !m.readOnly {
+		return false
+	}
+	m.status = "Read-only preview: file exceeds editor.max_open_bytes"
+	return true
+}
+
+// readOnlyKey reports whether a key would modify a read-only buffer
+func readOnlyKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		return msg.String() != "?" // Still opens help
+	case tea.KeySpace, tea.KeyEnter, tea.KeyBackspace, tea.KeyDelete, tea.KeyTab, tea.KeyCtrlS, tea.KeyCtrlR, tea.KeyCtrlX, tea.KeyCtrlV:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package tui
+
+import "strings"
+
+// lineBounds returns the byte range [start, end) of the line holding pos,
+// without its newline
+func lineBounds(val string, pos int) (start, end int) {
+	pos = min(pos, len(val))
+	start = strings.LastIndexByte(val[:pos], '\n') + 1
+	end = len(val)
+	if i := strings.IndexByte(val[pos:], '\n'); i >= 0 {
+		end = pos + i
+	}
+	return start, end
+}
+
+// deleteLine removes the cursor's line with its newline (Ctrl+D). The
+// cursor keeps its column on the line that moves up, or on the line above
+// when the last line was deleted.
+func (m *model) deleteLine() {
+	val := m.editor.content
+	start, end := lineBounds(val, m.editor.cursor)
+	col := m.editor.cursor - start
+	switch {
+	case end < len(val):
+		val = val[:start] + val[end+1:] // The newline after it
+	case start > 0:
+		val = val[:start-1] // The last line, and the newline before it
+		start, _ = lineBounds(val, start-1)
+	default:
+		val = "" // The only line
+	}
+	m.editor.content = val
+	_, lineEnd := lineBounds(val, start)
+	m.editor.cursor = min(start+col, lineEnd)
+	m.markSet = false
+	m.syncEditorView()
+	m.status = "Line deleted (Ctrl+Z to undo)"
+}
+
+// duplicateLine copies the cursor's line below itself (Alt+D) and moves
+// the cursor to the same column of the copy
+func (m *model) duplicateLine() {
+	val := m.editor.content
+	start, end := lineBounds(val, m.editor.cursor)
+	line := val[start:end]
+	m.editor.content = val[:end] + "\n" + line + val[end:]
+	m.editor.cursor += len(line) + 1
+	m.syncEditorView()
+	m.status = "Line duplicated"
+}
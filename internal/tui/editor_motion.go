@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// moveCursorJump handles the keys that move the cursor further than one
+// rune: Home/End, Ctrl+Left/Right and Ctrl+Home/End. It reports false for
+// any other key.
+func (m *model) moveCursorJump(key tea.KeyType) bool {
+	val := m.editor.content
+	pos := min(m.editor.cursor, len(val))
+	lineStart := strings.LastIndexByte(val[:pos], '\n') + 1
+
+	switch key {
+	case tea.KeyHome:
+		// First non-blank column, then column 0 on a second press
+		line := val[lineStart:]
+		if end := strings.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end]
+		}
+		first := lineStart + len(line) - len(strings.TrimLeft(line, " \t"))
+		if pos == first {
+			first = lineStart
+		}
+		m.editor.cursor = first
+	case tea.KeyEnd:
+		if end := strings.IndexByte(val[pos:], '\n'); end >= 0 {
+			m.editor.cursor = pos + end
+		} else {
+			m.editor.cursor = len(val)
+		}
+	case tea.KeyCtrlLeft:
+		m.editor.cursor = prevWord(val, pos)
+	case tea.KeyCtrlRight:
+		m.editor.cursor = nextWord(val, pos)
+	case tea.KeyCtrlHome:
+		m.editor.cursor = 0
+	case tea.KeyCtrlEnd:
+		m.editor.cursor = len(val)
+	default:
+		return false
+	}
+	m.syncEditorView()
+	return true
+}
+
+// runeClass groups runes for word movement: 0 blank, 1 word (letters,
+// digits, underscore), 2 punctuation
+func runeClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return 0
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return 1
+	}
+	return 2
+}
+
+// nextWord is the offset after the word or punctuation run at pos, past
+// the blanks that follow it
+func nextWord(val string, pos int) int {
+	if pos >= len(val) {
+		return len(val)
+	}
+	r, size := utf8.DecodeRuneInString(val[pos:])
+	class := runeClass(r)
+	for pos < len(val) {
+		r, size = utf8.DecodeRuneInString(val[pos:])
+		if runeClass(r) != class {
+			break
+		}
+		pos += size
+	}
+	for pos < len(val) {
+		r, size = utf8.DecodeRuneInString(val[pos:])
+		if runeClass(r) != 0 || r == '\n' {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
+
+// prevWord is the start of the word or punctuation run before pos,
+// skipping the blanks in between
+func prevWord(val string, pos int) int {
+	for pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(val[:pos])
+		if runeClass(r) != 0 {
+			break
+		}
+		pos -= size
+	}
+	if pos == 0 {
+		return 0
+	}
+	r, _ := utf8.DecodeLastRuneInString(val[:pos])
+	class := runeClass(r)
+	for pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(val[:pos])
+		if runeClass(r) != class {
+			break
+		}
+		pos -= size
+	}
+	return pos
+}
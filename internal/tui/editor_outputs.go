@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// runOutput is the last run output of a language. Path and ModTime name
+// the file that produced it; once that file changes on disk the output
+// is stale and no longer restored. Unsaved buffers have no Path.
+type runOutput struct {
+	Output  string    `json:"output"`
+	Path    string    `json:"path,omitempty"`
+	ModTime time.Time `json:"mod_time,omitempty"`
+}
+
+// lastRunOutputs keeps the most recent run output per language. It is
+// loaded from run_outputs.json on first use and written back on every
+// change, so outputs survive switching modes and restarting the editor.
+var lastRunOutputs map[string]runOutput
+
+func runOutputsPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "run_outputs.json"), nil
+}
+
+func loadRunOutputs() {
+	if lastRunOutputs != nil {
+		return
+	}
+	lastRunOutputs = map[string]runOutput{}
+	path, err := runOutputsPath()
+	if err != nil {
+		return
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &lastRunOutputs)
+	}
+}
+
+func saveRunOutputs() {
+	path, err := runOutputsPath()
+	if err != nil {
+		return
+	}
+	if data, err := json.Marshal(lastRunOutputs); err == nil {
+		writeFileAtomic(path, data)
+	}
+}
+
+// cachedRunOutput returns the saved output for language, dropping it when
+// the file it came from was modified or removed since the run
+func cachedRunOutput(language string) string {
+	loadRunOutputs()
+	out, ok := lastRunOutputs[language]
+	if !ok {
+		return ""
+	}
+	if out.Path != "" {
+		info, err := os.Stat(out.Path)
+		if err != nil || !info.ModTime().Equal(out.ModTime) {
+			delete(lastRunOutputs, language)
+			saveRunOutputs()
+			return ""
+		}
+	}
+	return out.Output
+}
+
+// storeRunOutput records output as the last run of language, keyed to
+// the current state of file (if it has one)
+func storeRunOutput(language, output, file string) {
+	loadRunOutputs()
+	out := runOutput{Output: output}
+	if file != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		if info, err := os.Stat(file); err == nil {
+			out.Path, out.ModTime = file, info.ModTime()
+		}
+	}
+	lastRunOutputs[language] = out
+	saveRunOutputs()
+}
+
+// forgetRunOutput drops the saved output for language
+func forgetRunOutput(language string) {
+	loadRunOutputs()
+	if _, ok := lastRunOutputs[language]; ok {
+		delete(lastRunOutputs, language)
+		saveRunOutputs()
+	}
+}
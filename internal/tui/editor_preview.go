@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phravins/devcli/internal/web"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// previewDir is the folder relative links in the buffer resolve against
+func (m *model) previewDir() string {
+	if m.filename == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(m.filename)
+	if err != nil {
+		return ""
+	}
+	return filepath.Dir(abs)
+}
+
+// previewHTML handles Ctrl+R on an HTML buffer: the buffer is served on a
+// local port and opened in the browser. Later runs and saves push the
+// buffer to the open page, which reloads itself.
+func (m *model) previewHTML() {
+	if m.preview != nil {
+		m.preview.Update(m.previewDir(), m.editor.content)
+		if m.preview.Watching() > 0 {
+			m.status = fmt.Sprintf("Preview reloaded: %s", m.preview.URL())
+			return
+		}
+		m.openPreview(m.preview.URL())
+		return
+	}
+
+	p, err := web.StartPreview(m.previewDir(), m.editor.content)
+	if err != nil {
+		m.previewFile(err)
+		return
+	}
+	m.preview = p
+	m.openPreview(p.URL())
+}
+
+func (m *model) openPreview(url string) {
+	if err := utils.OpenBrowser(url); err != nil {
+		m.status = fmt.Sprintf("Preview at %s (could not open a browser: %v)", url, err)
+		return
+	}
+	m.status = fmt.Sprintf("Previewing at %s (reloads on save and Ctrl+R)", url)
+}
+
+// previewFile is the fallback when no port can be opened: the buffer is
+// written to a temp file and opened once, without live reload
+func (m *model) previewFile(serveErr error) {
+	f, err := os.CreateTemp("", "devcli-preview-*.html")
+	if err != nil {
+		m.status = fmt.Sprintf("Preview failed: %v", serveErr)
+		return
+	}
+	_, err = f.WriteString(m.editor.content)
+	f.Close()
+	if err != nil {
+		m.status = fmt.Sprintf("Preview failed: %v", err)
+		return
+	}
+	url := "file://" + filepath.ToSlash(f.Name())
+	if !strings.HasPrefix(filepath.ToSlash(f.Name()), "/") {
+		url = "file:///" + filepath.ToSlash(f.Name()) // Windows drive path
+	}
+	if err := utils.OpenBrowser(url); err != nil {
+		m.status = fmt.Sprintf("Preview written to %s (could not open a browser: %v)", f.Name(), err)
+		return
+	}
+	m.status = fmt.Sprintf("Live preview unavailable (%v); opened a static copy", serveErr)
+}
+
+// pushPreview sends the saved buffer to an open HTML preview
+func (m *model) pushPreview() {
+	if m.preview != nil && m.language == "html" {
+		m.preview.Update(m.previewDir(), m.editor.content)
+	}
+}
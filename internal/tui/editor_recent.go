@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Config key for the editor's recently edited files, newest first
+const (
+	recentFilesKey = "editor.recent_files"
+	maxRecentFiles = 15
+)
+
+// recentFile is an entry of the recent files screen
+type recentFile struct {
+	path    string
+	modTime time.Time
+}
+
+// addRecentFile moves path to the front of the recent files list, dropping
+// any earlier copy and trimming the list to maxRecentFiles entries
+func addRecentFile(path string) {
+	if path == "" {
+		return
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	recent := []string{path}
+	for _, p := range config.GetStringSlice(recentFilesKey) {
+		if p != path && len(recent) < maxRecentFiles {
+			recent = append(recent, p)
+		}
+	}
+	config.SaveConfig(recentFilesKey, recent)
+}
+
+// recentFiles returns the recent files that still exist on disk
+func recentFiles() []recentFile {
+	var files []recentFile
+	for _, p := range config.GetStringSlice(recentFilesKey) {
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			files = append(files, recentFile{path: p, modTime: info.ModTime()})
+		}
+	}
+	return files
+}
+
+// openRecentList shows the recent files screen (Ctrl+R in the menu)
+func (m *model) openRecentList() tea.Cmd {
+	m.recent = recentFiles()
+	m.recentCursor = 0
+	if len(m.recent) == 0 {
+		return notify("No recent files yet (files saved or opened in the editor are listed here)")
+	}
+	m.state = stateRecentFiles
+	return nil
+}
+
+// updateRecentList handles a key on the recent files screen
+func (m *model) updateRecentList(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if m.recentCursor > 0 {
+			m.recentCursor--
+		}
+	case "down", "j":
+		if m.recentCursor < len(m.recent)-1 {
+			m.recentCursor++
+		}
+	case "enter":
+		return m.openRecent(m.recent[m.recentCursor].path)
+	case "esc", "q":
+		m.state = stateSelection
+	}
+	return nil
+}
+
+// openRecent opens path in the editor. An untouched blank buffer is
+// replaced rather than kept as an extra tab.
+func (m *model) openRecent(path string) tea.Cmd {
+	blank := len(m.tabs) == 1 && m.filename == "" && m.editor.content == ""
+	notice, err := m.openTab(path)
+	if err != nil {
+		m.state = stateSelection
+		return notifyError(fmt.Sprintf("Error opening: %v", err))
+	}
+	if blank && len(m.tabs) > 1 {
+		m.tabs = m.tabs[1:]
+		m.activeTab = len(m.tabs) - 1
+	}
+	addRecentFile(path)
+	m.state = stateEditor
+	m.status = "Opened " + path
+	if notice != "" {
+		m.status = notice
+	}
+	m.refreshGitStatus()
+	m.updateLayout()
+	return nil
+}
+
+func (m model) recentListView() string {
+	var s strings.Builder
+	s.WriteString("\n=== Recent Files ===\n\n")
+	for i, f := range m.recent {
+		line := fmt.Sprintf("%-60s  %s", f.path, f.modTime.Format("2006-01-02 15:04"))
+		if i == m.recentCursor {
+			s.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			s.WriteString(unselectedItemStyle.Render(line) + "\n")
+		}
+	}
+	s.WriteString("\nEnter: open, Esc: back to the menu.")
+	return s.String()
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phravins/devcli/internal/project"
+)
+
+// startRename prompts for a new name for the open file. Unlike Save As it
+// moves the file on disk instead of writing a second copy.
+func (m *model) startRename() {
+	if m.filename == "" {
+		m.status = "Rename needs a saved file (Ctrl+S to save first)"
+		return
+	}
+	m.state = stateRenamePrompt
+	m.saveInput.SetValue(m.filename)
+	m.saveInput.CursorEnd()
+	m.saveInput.Focus()
+	m.status = "Enter the new name or path..."
+}
+
+// renameTarget resolves the rename input: a bare name stays in the file's
+// folder, an existing directory receives the file under its current name
+func renameTarget(current, input string) string {
+	if !strings.ContainsAny(input, `/\`) {
+		input = filepath.Join(filepath.Dir(current), input)
+	}
+	if info, err := os.Stat(input); err == nil && info.IsDir() {
+		input = filepath.Join(input, filepath.Base(current))
+	}
+	return input
+}
+
+// renameFile moves the open file to dst and points the buffer at it. The
+// buffer itself, including unsaved edits, is left as it is.
+func (m *model) renameFile(input string) error {
+	src := m.filename
+	dst := renameTarget(src, input)
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	// A case-only rename reports the file itself on case-insensitive systems
+	if dstInfo, err := os.Stat(dst); err == nil && !os.SameFile(srcInfo, dstInfo) {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	if err := moveFile(src, dst); err != nil {
+		return err
+	}
+
+	project.RefreshGit(filepath.Dir(src))
+	m.filename = dst
+	m.language = detectLanguage(dst)
+	m.refreshGitStatus()
+	m.syncEditorView()
+	m.status = fmt.Sprintf("Renamed %s → %s", filepath.Base(src), dst)
+	return nil
+}
+
+// moveFile renames src to dst, falling back to copy+delete only when dst
+// is on another volume. Any other failure is returned as is.
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !crossDevice(err) {
+		return err
+	}
+	if err := copyFile(src, dst); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package tui
+
+import (
+	"errors"
+	"syscall"
+)
+
+// crossDevice reports whether a rename failed because src and dst are on
+// different file systems
+func crossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
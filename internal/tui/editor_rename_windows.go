@@ -0,0 +1,18 @@
+//go:build windows
+
+package tui
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorNotSameDevice is ERROR_NOT_SAME_DEVICE, returned by MoveFileEx when
+// the target is on another volume
+const errorNotSameDevice syscall.Errno = 17
+
+// crossDevice reports whether a rename failed because src and dst are on
+// different volumes
+func crossDevice(err error) bool {
+	return errors.Is(err, errorNotSameDevice) || errors.Is(err, syscall.EXDEV)
+}
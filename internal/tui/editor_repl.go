@@ -0,0 +1,354 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/procs"
+	"github.com/phravins/devcli/internal/runner"
+)
+
+// maxReplTranscript bounds the REPL text kept for the output pane
+const maxReplTranscript = 256 << 10
+
+// replSession is an interpreter started with Alt+P. It keeps running while
+// the editor has focus; its stdout and stderr arrive as chunks on out and
+// done gets the exit error once it has ended.
+type replSession struct {
+	cmd        *exec.Cmd
+	stdin      io.WriteCloser
+	out        chan []byte
+	done       chan error
+	language   string
+	transcript string
+	input      string   // Line being typed
+	pending    string   // Marked lines to send once the interpreter prints its prompt
+	history    []string // Sent lines, for Up/Down
+	histIdx    int
+}
+
+// replStartedMsg reports the interpreter start; pending is code to send
+// once it runs (the marked lines when Alt+P started it)
+type replStartedMsg struct {
+	session *replSession
+	pending string
+	err     error
+}
+
+// replOutputMsg carries a batch of output; exited is set once the
+// interpreter has ended
+type replOutputMsg struct {
+	session *replSession
+	chunks  [][]byte
+	exited  bool
+	err     error
+}
+
+// chanWriter hands every write to a channel
+type chanWriter chan []byte
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	w <- append([]byte(nil), p...)
+	return len(p), nil
+}
+
+func waitForRepl(s *replSession) tea.Cmd {
+	return func() tea.Msg {
+		chunks, open := collectBatch(s.out)
+		msg := replOutputMsg{session: s, chunks: chunks}
+		if !open {
+			msg.exited, msg.err = true, <-s.done
+		}
+		return msg
+	}
+}
+
+// replLanguages lists the languages that have a REPL, for messages
+func replLanguages() string {
+	var names []string
+	for _, r := range runner.All() {
+		if r.Repl != nil {
+			names = append(names, r.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// openRepl handles Alt+P: it focuses the running REPL, or starts one for
+// the buffer's language. With a mark set, the marked lines are sent to it.
+func (m *model) openRepl() tea.Cmd {
+	pending := ""
+	if m.markSet {
+		pending = m.replSelection()
+	}
+	if m.repl != nil {
+		m.focusRepl()
+		if pending != "" {
+			m.sendToRepl(pending)
+		}
+		return nil
+	}
+
+	r, ok := runner.Lookup(m.language)
+	if !ok || r.Repl == nil {
+		m.status = fmt.Sprintf("No REPL for %s (available for %s)", m.language, replLanguages())
+		return nil
+	}
+	dir, _ := os.Getwd()
+	if m.filename != "" {
+		if abs, err := filepath.Abs(m.filename); err == nil {
+			dir = filepath.Dir(abs)
+		}
+	}
+	m.status = fmt.Sprintf("Starting %s REPL...", r.Name)
+	return func() tea.Msg {
+		tools, err := r.Resolve(m.resolveExecutable)
+		if err != nil {
+			return replStartedMsg{err: err}
+		}
+		b, err := r.NewBuild("", dir, tools)
+		if err != nil {
+			return replStartedMsg{err: err}
+		}
+		argv := r.Repl(b)
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Dir = dir
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return replStartedMsg{err: err}
+		}
+		s := &replSession{cmd: cmd, stdin: stdin, out: make(chan []byte, 256), done: make(chan error, 1), language: r.Name}
+		cmd.Stdout = chanWriter(s.out)
+		cmd.Stderr = cmd.Stdout
+		if err := procs.Start(cmd, "editor: "+r.Name+" REPL"); err != nil {
+			return replStartedMsg{err: err}
+		}
+		go func() {
+			err := cmd.Wait()
+			procs.Unregister(cmd)
+			close(s.out)
+			s.done <- err
+		}()
+		return replStartedMsg{session: s, pending: pending}
+	}
+}
+
+// replStarted shows a new REPL, or why it could not start
+func (m *model) replStarted(msg replStartedMsg) tea.Cmd {
+	if msg.err != nil {
+		res := runnerSetupResult(msg.err)
+		m.status = fmt.Sprintf("REPL failed to start: %v", msg.err)
+		if res.output == "" {
+			return nil
+		}
+		return func() tea.Msg { return res }
+	}
+	m.repl = msg.session
+	m.repl.transcript = subtleStyle.Render("── "+m.repl.language+" REPL ──") + "\n"
+	m.repl.pending = msg.pending
+	m.focusRepl()
+	return waitForRepl(m.repl)
+}
+
+// replOutput appends interpreter output to the transcript
+func (m *model) replOutput(msg replOutputMsg) tea.Cmd {
+	s := msg.session
+	if s != m.repl {
+		// A stopped session is drained so its process can be reaped
+		if !msg.exited {
+			return waitForRepl(s)
+		}
+		return nil
+	}
+	if len(msg.chunks) > 0 {
+		s.transcript += procs.DecodeOutput(joinChunks(msg.chunks), s.language)
+	}
+	if !msg.exited {
+		if s.pending != "" {
+			code := s.pending
+			s.pending = ""
+			m.sendToRepl(code)
+		}
+		m.renderRepl()
+		return waitForRepl(s)
+	}
+
+	summary := fmt.Sprintf("REPL exited %d", exitCodeOf(s.cmd))
+	s.transcript = strings.TrimRight(s.transcript, "\n") + "\n\n" + subtleStyle.Render("── "+summary+" ──")
+	m.leaveRepl()
+	m.status = summary
+	return nil
+}
+
+func joinChunks(chunks [][]byte) []byte {
+	var data []byte
+	for _, c := range chunks {
+		data = append(data, c...)
+	}
+	return data
+}
+
+// stopRepl kills the interpreter (Ctrl+C in the REPL, Esc to the menu)
+func (m *model) stopRepl() {
+	s := m.repl
+	if s == nil {
+		return
+	}
+	s.stdin.Close()
+	procs.Kill(s.cmd)
+	s.transcript = strings.TrimRight(s.transcript, "\n") + "\n\n" + subtleStyle.Render("── REPL stopped ──")
+	m.leaveRepl()
+}
+
+// leaveRepl drops the session and keeps its transcript as the output
+func (m *model) leaveRepl() {
+	s := m.repl
+	m.repl = nil
+	if m.state == stateRepl {
+		m.state = stateEditor
+	}
+	if s.language == m.language {
+		m.output = s.transcript
+		storeRunOutput(m.language, m.output, m.filename)
+		m.outputView.SetContent(m.output)
+		m.outputView.GotoBottom()
+		m.updateLayout()
+	}
+}
+
+func (m *model) focusRepl() {
+	m.state = stateRepl
+	m.activeView = viewOutput
+	m.status = fmt.Sprintf("%s REPL: Enter sends the line, Esc returns to the editor", m.repl.language)
+	m.renderRepl()
+}
+
+// renderRepl shows the transcript, with the line being typed after the
+// interpreter's prompt while the REPL has focus
+func (m *model) renderRepl() {
+	s := m.repl
+	if s.language != m.language {
+		return // Another tab is shown; the transcript is kept for later
+	}
+	if len(s.transcript) > maxReplTranscript {
+		cut := s.transcript[len(s.transcript)-maxReplTranscript:]
+		if i := strings.IndexByte(cut, '\n'); i >= 0 {
+			cut = cut[i+1:] // Start at a whole line
+		}
+		s.transcript = cut
+	}
+	m.output = s.transcript
+	content := m.output
+	if m.state == stateRepl {
+		content += s.input + "█"
+	}
+	m.outputView.SetContent(content)
+	m.outputView.GotoBottom()
+	m.updateLayout()
+}
+
+// replSelection returns the marked lines for the REPL. A selection ending
+// inside an indented Python block gets the blank line that ends the block.
+func (m *model) replSelection() string {
+	code, _, _ := m.selectedLines()
+	code = strings.TrimRight(code, "\n")
+	lines := strings.Split(code, "\n")
+	if last := lines[len(lines)-1]; m.language == "python" && strings.TrimLeft(last, " \t") != last {
+		code += "\n"
+	}
+	return code
+}
+
+// sendToRepl writes code and a newline to the interpreter, echoing it since
+// a pipe does not
+func (m *model) sendToRepl(code string) {
+	s := m.repl
+	s.transcript += code + "\n"
+	if _, err := io.WriteString(s.stdin, code+"\n"); err != nil {
+		m.status = fmt.Sprintf("REPL input failed: %v", err)
+	}
+	m.renderRepl()
+}
+
+// updateRepl handles keys while the REPL has focus
+func (m *model) updateRepl(msg tea.KeyMsg) tea.Cmd {
+	s := m.repl
+	switch msg.Type {
+	case tea.KeyEnter:
+		line := s.input
+		s.input = ""
+		if strings.TrimSpace(line) != "" {
+			s.history = append(s.history, line)
+		}
+		s.histIdx = len(s.history)
+		m.sendToRepl(line)
+		return nil
+	case tea.KeyEsc:
+		m.state = stateEditor
+		m.activeView = viewEditor
+		m.status = "REPL keeps running (Alt+P to return to it)"
+		m.renderRepl()
+		return nil
+	case tea.KeyCtrlD:
+		// End of input lets the interpreter exit on its own
+		s.stdin.Close()
+		m.status = "Ending REPL..."
+		return nil
+	case tea.KeyCtrlC:
+		m.stopRepl()
+		m.status = "REPL stopped"
+		return nil
+	case tea.KeyUp, tea.KeyDown:
+		if msg.Type == tea.KeyUp && s.histIdx > 0 {
+			s.histIdx--
+		} else if msg.Type == tea.KeyDown && s.histIdx < len(s.history) {
+			s.histIdx++
+		}
+		s.input = ""
+		if s.histIdx < len(s.history) {
+			s.input = s.history[s.histIdx]
+		}
+	case tea.KeyPgUp, tea.KeyPgDown:
+		var cmd tea.Cmd
+		m.outputView, cmd = m.outputView.Update(msg)
+		return cmd
+	case tea.KeyBackspace:
+		if r := []rune(s.input); len(r) > 0 {
+			s.input = string(r[:len(r)-1])
+		}
+	case tea.KeyTab:
+		s.input += "    "
+	case tea.KeySpace:
+		s.input += " "
+	case tea.KeyRunes:
+		// A paste may hold several lines; complete ones are sent
+		text := strings.ReplaceAll(string(msg.Runes), "\r\n", "\n")
+		for {
+			i := strings.IndexByte(text, '\n')
+			if i < 0 {
+				break
+			}
+			m.sendToRepl(s.input + text[:i])
+			s.input, text = "", text[i+1:]
+		}
+		s.input += text
+	}
+	m.renderRepl()
+	return nil
+}
+
+// replTitle is the output pane title while it shows the REPL
+func (m *model) replTitle() string {
+	if m.repl == nil || m.repl.language != m.language {
+		return ""
+	}
+	if m.state == stateRepl {
+		return fmt.Sprintf(" >> %s REPL [Enter: Send | Esc: Editor | Ctrl+D: End | Ctrl+C: Stop] << ", m.repl.language)
+	}
+	return fmt.Sprintf("%s REPL (running) [Alt+P: Focus | Mark + Alt+P: Send Lines]", m.repl.language)
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/runner"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// toolsResolvedMsg reports the outcome of the pre-run tool lookup
+type toolsResolvedMsg struct {
+	missing  string // First tool that could not be found
+	canceled bool
+}
+
+// resolveToolsCmd locates (and caches) every tool the language needs, so the
+// potentially slow deep search runs as its own cancellable step.
+func (m *model) resolveToolsCmd(ctx context.Context, language string) tea.Cmd {
+	return func() tea.Msg {
+		r, ok := runner.Lookup(language)
+		if !ok {
+			return toolsResolvedMsg{}
+		}
+		_, err := r.Resolve(func(tool string, fallbacks []string) string {
+			return m.resolveExecutableContext(ctx, tool, fallbacks)
+		})
+		if ctx.Err() != nil {
+			return toolsResolvedMsg{canceled: true}
+		}
+		var missing *runner.MissingToolError
+		if errors.As(err, &missing) {
+			return toolsResolvedMsg{missing: missing.Tool}
+		}
+		return toolsResolvedMsg{} // Other errors are reported by the run
+	}
+}
+
+// toolNames formats the tools a language needs for the status bar
+func toolNames(language string) string {
+	if r, ok := runner.Lookup(language); ok {
+		return strings.Join(r.ToolNames(), ", ")
+	}
+	return ""
+}
+
+func (m *model) resolveExecutable(cmdName string, fallbacks []string) string {
+	return m.resolveExecutableContext(context.Background(), cmdName, fallbacks)
+}
+
+// resolveExecutableContext finds cmdName via the config cache, PATH, the
+// fallback globs and finally a deep search, which stops when ctx is done.
+func (m *model) resolveExecutableContext(ctx context.Context, cmdName string, fallbacks []string) string {
+	cacheKey := "compilers." + cmdName
+	if cached := config.GetString(cacheKey); cached != "" {
+		if utils.FileExists(cached) {
+			return cached
+		}
+	}
+	path := utils.FindExecutable(cmdName, fallbacks)
+	if path != "" {
+		config.SaveConfig(cacheKey, path)
+		return path
+	}
+	userHome, _ := os.UserHomeDir()
+	searchRoots := []string{
+		`C:\Program Files`,
+		`C:\Program Files (x86)`,
+		filepath.Join(userHome, "Downloads"),
+		`C:\`,
+		"/usr/bin",
+		"/usr/local/bin",
+		"/opt",
+		filepath.Join(userHome, ".local/bin"),
+	}
+
+	// Filter roots that exist
+	validRoots := []string{}
+	for _, r := range searchRoots {
+		if utils.DirExists(r) {
+			validRoots = append(validRoots, r)
+		}
+	}
+
+	path = utils.DeepSearchExecutableContext(ctx, cmdName, validRoots)
+	if path != "" {
+		config.SaveConfig(cacheKey, path)
+		return path
+	}
+
+	return ""
+}
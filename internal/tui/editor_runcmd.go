@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/runner"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// runCommandMsg carries the command line built for Alt+C
+type runCommandMsg struct {
+	command string
+	err     error
+}
+
+// copyRunCommand handles Alt+C: it builds the shell command that compiles
+// and runs the buffer the way Ctrl+R does and copies it to the clipboard.
+// Tools are resolved in the background since that may search the disk.
+func (m *model) copyRunCommand() tea.Cmd {
+	code, language, filename := m.editor.content, m.language, m.filename
+	inPlace := filename != "" && !m.isDirty()
+	return func() tea.Msg {
+		var (
+			dir   string
+			steps [][]string
+			err   error
+		)
+		if inPlace {
+			dir, steps, err = m.fileSteps(language, code, filename)
+		} else {
+			// The buffer is written to a folder that is left for the command
+			if dir, err = os.MkdirTemp("", "devcli_cmd_*"); err == nil {
+				if steps, err = m.externalSteps(language, code, dir); err != nil {
+					os.RemoveAll(dir)
+				}
+			}
+		}
+		if err != nil {
+			return runCommandMsg{err: err}
+		}
+		command := shellCommandLine(dir, steps)
+		if err := clipboard.WriteAll(command); err != nil {
+			return runCommandMsg{command: command, err: fmt.Errorf("clipboard unavailable (%v)", err)}
+		}
+		return runCommandMsg{command: command}
+	}
+}
+
+// fileSteps returns the commands that build and run a saved file where it
+// is; a compiled program is written next to it. Languages that need a
+// project set up around the source fall back to a temp copy.
+func (m *model) fileSteps(language, code, filename string) (string, [][]string, error) {
+	r, ok := runner.Lookup(language)
+	if !ok {
+		return "", nil, fmt.Errorf("no runner defined for language: %s", language)
+	}
+	if r.Setup != nil {
+		dir, err := os.MkdirTemp("", "devcli_cmd_*")
+		if err != nil {
+			return "", nil, err
+		}
+		steps, err := m.externalSteps(language, code, dir)
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+		return dir, steps, err
+	}
+
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return "", nil, err
+	}
+	tools, err := r.Resolve(m.resolveExecutable)
+	if err != nil {
+		return "", nil, err
+	}
+	b, err := r.NewBuild(code, filepath.Dir(path), tools)
+	if err != nil {
+		return "", nil, err
+	}
+	b.Source = filepath.Base(path)
+	b.Exe = filepath.Join(b.Dir, utils.StripExt(b.Source))
+	if runtime.GOOS == "windows" {
+		b.Exe += ".exe"
+	}
+
+	var steps [][]string
+	if r.Compile != nil {
+		steps = append(steps, r.Compile(b))
+	}
+	return b.Dir, append(steps, r.Run(b)), nil
+}
+
+// shellCommandLine turns steps into one line for the platform shell that
+// runs them in dir, stopping at the first failure
+func shellCommandLine(dir string, steps [][]string) string {
+	cd := "cd " + quoteArg(dir)
+	if runtime.GOOS == "windows" {
+		cd = "cd /d " + quoteArg(dir)
+	}
+	return cd + " && " + joinSteps(steps)
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// textSelection is a stream selection from anchor to the cursor, extended
+// with Shift+arrows
+type textSelection struct {
+	active bool
+	anchor int
+}
+
+// selectionRange returns the selected bytes [start, end); ok is false when
+// nothing is selected
+func (m *model) selectionRange() (start, end int, ok bool) {
+	if !m.sel.active {
+		return 0, 0, false
+	}
+	anchor := min(m.sel.anchor, len(m.editor.content))
+	start, end = min(anchor, m.editor.cursor), max(anchor, m.editor.cursor)
+	return start, end, start < end
+}
+
+// updateSelection handles the selection and clipboard keys. It reports
+// false for keys the editor should still handle; typing over a selection
+// deletes it first.
+func (m *model) updateSelection(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyShiftLeft, tea.KeyShiftRight, tea.KeyShiftUp, tea.KeyShiftDown, tea.KeyShiftHome, tea.KeyShiftEnd:
+		if !m.sel.active {
+			m.sel = textSelection{active: true, anchor: m.editor.cursor}
+		}
+		m.extendSelection(msg.Type)
+		m.syncEditorView()
+		return nil, true
+	case tea.KeyCtrlC:
+		if !m.sel.active {
+			// Not a quit: a second Ctrl+C after a copy must not close the editor
+			m.status = "Nothing selected to copy (Shift+arrows select, Ctrl+Q quits)"
+			return nil, true
+		}
+		return m.copySelection(false), true
+	case tea.KeyCtrlX:
+		return m.copySelection(true), true
+	case tea.KeyCtrlV:
+		return m.paste(), true
+	}
+
+	if !m.sel.active {
+		return nil, false
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearSelection()
+		return nil, true
+	case tea.KeyBackspace, tea.KeyDelete:
+		m.replaceSelection("")
+		return nil, true
+	case tea.KeyRunes, tea.KeySpace, tea.KeyEnter, tea.KeyTab:
+		m.replaceSelection("")
+	default:
+		m.clearSelection()
+	}
+	return nil, false
+}
+
+// extendSelection moves the cursor for a Shift+arrow key
+func (m *model) extendSelection(key tea.KeyType) {
+	val := m.editor.content
+	pos := m.editor.cursor
+	switch key {
+	case tea.KeyShiftLeft:
+		if pos > 0 {
+			_, size := utf8.DecodeLastRuneInString(val[:pos])
+			m.editor.cursor -= size
+		}
+	case tea.KeyShiftRight:
+		if pos < len(val) {
+			_, size := utf8.DecodeRuneInString(val[pos:])
+			m.editor.cursor += size
+		}
+	case tea.KeyShiftUp:
+		m.moveCursorVertical(tea.KeyUp)
+	case tea.KeyShiftDown:
+		m.moveCursorVertical(tea.KeyDown)
+	case tea.KeyShiftHome:
+		m.editor.cursor = strings.LastIndexByte(val[:pos], '\n') + 1
+	case tea.KeyShiftEnd:
+		if i := strings.IndexByte(val[pos:], '\n'); i >= 0 {
+			m.editor.cursor = pos + i
+		} else {
+			m.editor.cursor = len(val)
+		}
+	}
+}
+
+func (m *model) clearSelection() {
+	m.sel.active = false
+	m.syncEditorView()
+}
+
+// replaceSelection replaces the selected text with text and ends the
+// selection, leaving the cursor after the inserted text
+func (m *model) replaceSelection(text string) {
+	start, end, _ := m.selectionRange()
+	if !m.sel.active {
+		start, end = m.editor.cursor, m.editor.cursor
+	}
+	m.editor.content = m.editor.content[:start] + text + m.editor.content[end:]
+	m.editor.cursor = start + len(text)
+	m.sel.active = false
+	m.syncEditorView()
+}
+
+// copySelection puts the selection on the clipboard (Ctrl+C), removing it
+// from the buffer when cut is set (Ctrl+X). The internal register keeps a
+// copy for Ctrl+V when the system clipboard is unavailable.
+func (m *model) copySelection(cut bool) tea.Cmd {
+	start, end, ok := m.selectionRange()
+	if !ok {
+		m.status = "Nothing selected (Shift+arrows select text)"
+		return nil
+	}
+	text := m.editor.content[start:end]
+	m.register = text
+
+	verb := "Copied"
+	if cut {
+		verb = "Cut"
+		m.replaceSelection("")
+	}
+	summary := fmt.Sprintf("%s %s", verb, describeText(text))
+	if err := clipboard.WriteAll(text); err != nil {
+		return notify(summary + " (system clipboard unavailable, kept for Ctrl+V)")
+	}
+	return notify(summary)
+}
+
+// paste inserts the clipboard at the cursor (Ctrl+V), replacing the
+// selection; the internal register stands in when the system clipboard
+// cannot be read
+func (m *model) paste() tea.Cmd {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		text = m.register
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		m.status = "Clipboard is empty"
+		return nil
+	}
+	m.replaceSelection(text)
+	m.status = "Pasted " + describeText(text)
+	return nil
+}
+
+// describeText sizes text for the status bar: "12 characters" or "3 lines"
+func describeText(text string) string {
+	if lines := strings.Count(text, "\n") + 1; lines > 1 {
+		return fmt.Sprintf("%d lines", lines)
+	}
+	return fmt.Sprintf("%d characters", utf8.RuneCountInString(text))
+}
+
+// highlightSelection marks the selected text on the rendered lines, the
+// way highlightBlock does for a column selection. Columns are counted as it
+// goes, as a selection can span the whole buffer.
+func (m *model) highlightSelection(rawLines []string, val string, cursorPos, cursorLen int) {
+	start, end, ok := m.selectionRange()
+	if !ok {
+		return
+	}
+	cursorLine, _ := lineCol(val, cursorPos)
+	line, col := lineCol(val, start)
+	for p := start; p < end && line < len(rawLines); {
+		r, size := utf8.DecodeRuneInString(val[p:])
+		if r == '\n' {
+			line, col = line+1, 0
+		} else {
+			c := col
+			if line == cursorLine && p >= cursorPos {
+				c += cursorLen // The "|" cursor sits before p
+			}
+			rawLines[line] = styleVisibleRune(rawLines[line], c, blockSelectStyle)
+			col++
+		}
+		p += size
+	}
+}
+
+// selectionStatus describes the selection for the status bar
+func (m *model) selectionStatus() string {
+	start, end, _ := m.selectionRange()
+	return "| Sel: " + describeText(m.editor.content[start:end]) + " "
+}
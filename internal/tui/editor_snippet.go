@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Entry points that mean a snippet can run as-is
+var snippetMainPatterns = map[string]*regexp.Regexp{
+	"java": regexp.MustCompile(`static\s+void\s+main\s*\(`),
+	"cpp":  regexp.MustCompile(`\bmain\s*\(`),
+	"c":    regexp.MustCompile(`\bmain\s*\(`),
+	"rust": regexp.MustCompile(`\bfn\s+main\s*\(`),
+	"zig":  regexp.MustCompile(`\bfn\s+main\s*\(`),
+	"go":   regexp.MustCompile(`(?m)\bfunc\s+main\s*\(|^package\s`),
+	"php":  regexp.MustCompile(`<\?(php|=)`),
+}
+
+// goSnippetImports are the standard packages a wrapped Go fragment gets
+// imported, by the name it refers to them with. Only the ones it uses are
+// imported, since Go rejects unused imports.
+var goSnippetImports = map[string]string{
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"errors":   "errors",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"json":     "encoding/json",
+	"math":     "math",
+	"os":       "os",
+	"rand":     "math/rand",
+	"regexp":   "regexp",
+	"slices":   "slices",
+	"sort":     "sort",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"time":     "time",
+	"unicode":  "unicode",
+}
+
+// wrapSnippet makes a code fragment runnable on its own. Interpreted
+// languages (and C# top-level statements) only need dedenting; compiled
+// ones get a minimal main unless the fragment already has one, and PHP
+// gets its opening tag.
+func wrapSnippet(code, language string) (string, bool) {
+	code = dedent(code)
+	if re, ok := snippetMainPatterns[language]; !ok || re.MatchString(code) {
+		return code, false
+	}
+
+	body := indent(code, "    ")
+	switch language {
+	case "java":
+		return "public class Main {\n    public static void main(String[] args) throws Exception {\n" +
+			indent(code, "        ") + "\n    }\n}\n", true
+	case "cpp":
+		return "#include <bits/stdc++.h>\nusing namespace std;\n\nint main() {\n" + body + "\n    return 0;\n}\n", true
+	case "c":
+		return "#include <stdio.h>\n#include <stdlib.h>\n#include <string.h>\n#include <math.h>\n\nint main(void) {\n" + body + "\n    return 0;\n}\n", true
+	case "rust":
+		return "fn main() {\n" + body + "\n}\n", true
+	case "zig":
+		return "const std = @import(\"std\");\n\npub fn main() !void {\n" + body + "\n}\n", true
+	case "go":
+		return "package main\n\n" + goImports(code) + "func main() {\n" + indent(code, "\t") + "\n}\n", true
+	case "php":
+		return "<?php\n" + code + "\n", true
+	}
+	return code, false
+}
+
+// goImports is the import block for the standard packages code refers to
+func goImports(code string) string {
+	var paths []string
+	for name, path := range goSnippetImports {
+		if regexp.MustCompile(`\b` + name + `\.`).MatchString(code) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	sort.Strings(paths)
+	return "import (\n\t\"" + strings.Join(paths, "\"\n\t\"") + "\"\n)\n\n"
+}
+
+// dedent strips the indentation shared by all non-blank lines
+func dedent(code string) string {
+	lines := strings.Split(code, "\n")
+	prefix := ""
+	first := true
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lead := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if first {
+			prefix, first = lead, false
+			continue
+		}
+		for !strings.HasPrefix(lead, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(l, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func indent(code, prefix string) string {
+	lines := strings.Split(code, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toggleMark sets or clears the region mark at the cursor line
+func (m *model) toggleMark() {
+	if m.markSet {
+		m.markSet = false
+		m.status = "Mark cleared"
+		return
+	}
+	m.markSet = true
+	m.mark = m.editor.cursor
+	line, _ := lineCol(m.editor.content, m.editor.cursor)
+	m.status = fmt.Sprintf("Mark set at line %d (Alt+R runs the lines up to the cursor)", line+1)
+}
+
+// selectedLines returns the full lines between the mark and the cursor,
+// or just the cursor line when no mark is set, plus 1-based line numbers
+func (m *model) selectedLines() (string, int, int) {
+	content := m.editor.content
+	from, to := m.editor.cursor, m.editor.cursor
+	if m.markSet {
+		from = min(m.mark, len(content))
+		if from > to {
+			from, to = to, from
+		}
+	}
+	first, _ := lineCol(content, from)
+	last, _ := lineCol(content, to)
+	lines := strings.Split(content, "\n")
+	return strings.Join(lines[first:last+1], "\n"), first + 1, last + 1
+}
+
+// runSelection runs the marked lines (or the current line) as a standalone
+// program, wrapping them in a main for compiled languages
+func (m *model) runSelection() tea.Cmd {
+	code, first, last := m.selectedLines()
+	if strings.TrimSpace(code) == "" {
+		m.status = "Nothing to run: the selected lines are empty"
+		return nil
+	}
+
+	where := fmt.Sprintf("line %d", first)
+	if last > first {
+		where = fmt.Sprintf("lines %d-%d", first, last)
+	}
+	snippet, wrapped := wrapSnippet(code, m.language)
+	m.runSnippet = snippet
+	m.runNote = "ran " + where
+	m.runLineOffset = first - 1
+	if wrapped {
+		if m.language == "php" {
+			m.runNote += ", with an added <?php tag"
+		} else {
+			m.runNote += ", wrapped in a generated main()"
+		}
+		m.runLineOffset = -1
+	}
+	return m.startRun()
+}
+
+// startRun locates the language's tools, then runs the buffer (or
+// m.runSnippet when set)
+func (m *model) startRun() tea.Cmd {
+	if m.running {
+		m.status = "Already running"
+		return nil
+	}
+	m.running = true
+	what := "code"
+	if m.runSnippet != "" {
+		what = "selection (" + m.runNote + ")"
+	}
+	if tools := toolNames(m.language); tools != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		m.resolving = true
+		m.resolveCancel = cancel
+		m.status = fmt.Sprintf("Locating %s... (Esc to cancel)", tools)
+		return tea.Batch(m.spinner.Tick, m.resolveToolsCmd(ctx, m.language))
+	}
+	m.status = fmt.Sprintf("Running %s %s...", m.language, what)
+	return m.runCode()
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newStdinInput is the box for the text piped to programs run with Ctrl+R
+func newStdinInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Input for the program, e.g. one number per line..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetHeight(10)
+	return ta
+}
+
+// openStdinPrompt shows the stdin box (Alt+S) with the current input
+func (m *model) openStdinPrompt() {
+	m.stdinInput.SetWidth(max(m.width-8, 20))
+	m.stdinInput.SetValue(m.stdin)
+	m.stdinInput.Focus()
+	m.state = stateStdinPrompt
+	m.status = "Enter the program's input (Esc: keep, Ctrl+R: keep and run)"
+}
+
+// updateStdinPrompt handles a key in the stdin box; run is set when the
+// input should be kept and the program run right away
+func (m *model) updateStdinPrompt(msg tea.KeyMsg) (run bool, cmd tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlR:
+		m.stdin = m.stdinInput.Value()
+		m.stdinInput.Blur()
+		m.state = stateEditor
+		m.status = "Program input: " + m.stdinSummary()
+		return msg.Type == tea.KeyCtrlR, nil
+	case tea.KeyCtrlL:
+		m.stdinInput.Reset()
+		return false, nil
+	}
+	m.stdinInput, cmd = m.stdinInput.Update(msg)
+	return false, cmd
+}
+
+// stdinSummary describes the stored input for the status bar
+func (m *model) stdinSummary() string {
+	if m.stdin == "" {
+		return "none (programs read EOF)"
+	}
+	return describeText(strings.TrimSuffix(m.stdin, "\n"))
+}
+
+// stdinReader returns the input for a run, ending in a newline so the last
+// line is read by line-based calls such as input() or Scanner; nil when
+// there is none
+func stdinReader(text string) io.Reader {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return strings.NewReader(text)
+}
+
+func (m model) stdinPromptView() string {
+	return fmt.Sprintf("\n=== Program Input (stdin) ===\n\n"+
+		"This text is piped to the program's standard input on every run\n"+
+		"(Ctrl+R, Alt+R) until you change it. Leave it empty for no input.\n\n"+
+		"%s\n\n"+
+		"Esc: keep and return, Ctrl+R: keep and run, Ctrl+L: clear.", m.stdinInput.View())
+}
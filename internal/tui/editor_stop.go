@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
+)
+
+// runTimeoutKey bounds editor runs (compile included), as a duration or
+// plain seconds; "0" means no limit. Unset falls back to
+// exec.default_timeout, then to defaultEditorRunTimeout.
+const runTimeoutKey = "editor.run_timeout"
+
+// defaultEditorRunTimeout stops a forgotten infinite loop
+const defaultEditorRunTimeout = 30 * time.Second
+
+func editorRunTimeout() time.Duration {
+	if raw := strings.TrimSpace(config.GetString(runTimeoutKey)); raw != "" {
+		if d, err := procs.ParseTimeout(raw); err == nil {
+			return d
+		}
+	}
+	if d := procs.Timeout(); d > 0 {
+		return d
+	}
+	return defaultEditorRunTimeout
+}
+
+// stopRun handles Ctrl+K: it cancels the compiler search or kills the
+// running program. The run's execResult then resets m.running.
+func (m *model) stopRun() {
+	switch {
+	case m.resolving:
+		m.resolveCancel()
+		m.resolving = false
+		m.running = false
+		m.status = "Compiler search cancelled"
+	case m.runCancel != nil:
+		m.runCancel()
+		m.runCancel = nil
+		m.status = "Stopping the program..."
+	default:
+		m.status = "Nothing is running"
+	}
+}
+
+// stoppedRun explains a step that ended because ctx was done: killed with
+// Ctrl+K or over the time limit. The note goes under the partial output;
+// it is empty (and err unchanged) when the step ended on its own.
+func stoppedRun(ctx context.Context, err error, timeout time.Duration) (error, string) {
+	if err == nil {
+		return nil, ""
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return procs.TimeoutError(ctx, err, timeout), "[Killed: exceeded timeout]"
+	case errors.Is(ctx.Err(), context.Canceled):
+		return errors.New("stopped with Ctrl+K"), "[Killed: stopped by user]"
+	}
+	return err, ""
+}
+
+// withNote appends note on its own line to a step's output
+func withNote(output, note string) string {
+	if note == "" {
+		return output
+	}
+	if output = strings.TrimRight(output, "\n"); output != "" {
+		output += "\n"
+	}
+	return output + note
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editorTab is one open buffer. The active tab's state lives in the model's
+// editor/filename/language fields while it is focused and is copied back
+// into the slice when switching away.
+type editorTab struct {
+	filename   string
+	language   string
+	content    string
+	cursor     int
+	saved      string // Content as last loaded or saved, for dirty tracking
+	readOnly   bool
+	lineEnding string
+	savedEOL   string
+	history    *undoHistory
+}
+
+var (
+	tabActiveStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(colorPurple).Bold(true).Padding(0, 1)
+	tabInactiveStyle = lipgloss.NewStyle().Foreground(colorGray).Padding(0, 1)
+)
+
+// title is the label shown in the tab bar
+func (t editorTab) title() string {
+	name := "untitled"
+	if t.filename != "" {
+		name = filepath.Base(t.filename)
+	}
+	if t.content != t.saved || t.lineEnding != t.savedEOL {
+		name += " *"
+	}
+	return name
+}
+
+// isDirty reports whether the active buffer has unsaved changes
+func (m *model) isDirty() bool {
+	return m.editor.content != m.savedContent || m.lineEnding != m.savedEOL
+}
+
+// dirtyTabs counts the open buffers with unsaved changes
+func (m *model) dirtyTabs() int {
+	n := 0
+	if m.isDirty() {
+		n++
+	}
+	for i, t := range m.tabs {
+		if i != m.activeTab && (t.content != t.saved || t.lineEnding != t.savedEOL) {
+			n++
+		}
+	}
+	return n
+}
+
+// currentTab is the active buffer's state as a tab
+func (m model) currentTab() editorTab {
+	return editorTab{
+		filename:   m.filename,
+		language:   m.language,
+		content:    m.editor.content,
+		cursor:     m.editor.cursor,
+		saved:      m.savedContent,
+		readOnly:   m.readOnly,
+		lineEnding: m.lineEnding,
+		savedEOL:   m.savedEOL,
+		history:    m.editor.history,
+	}
+}
+
+// stashTab copies the active buffer back into the tab list
+func (m *model) stashTab() {
+	if m.activeTab < 0 || m.activeTab >= len(m.tabs) {
+		return
+	}
+	m.tabs[m.activeTab] = m.currentTab()
+}
+
+// loadTab makes tab i the active buffer
+func (m *model) loadTab(i int) {
+	t := m.tabs[i]
+	m.activeTab = i
+	m.filename = t.filename
+	m.language = t.language
+	m.editor.content = t.content
+	m.editor.cursor = t.cursor
+	m.editor.history = t.history
+	if m.editor.history == nil {
+		m.resetUndo()
+	}
+	m.savedContent = t.saved
+	m.readOnly = t.readOnly
+	m.lineEnding, m.savedEOL = t.lineEnding, t.savedEOL
+	m.markSet = false
+	m.sel.active = false
+	m.editor.viewport.GotoTop()
+	m.restoreOutput()
+	m.updateLayout()
+}
+
+// switchTab moves focus by delta tabs, wrapping around
+func (m *model) switchTab(delta int) {
+	if len(m.tabs) < 2 {
+		m.status = "Only one tab open (Ctrl+T to open another)"
+		return
+	}
+	m.stashTab()
+	m.loadTab((m.activeTab + delta + len(m.tabs)) % len(m.tabs))
+	m.status = fmt.Sprintf("Tab %d/%d: %s", m.activeTab+1, len(m.tabs), m.tabs[m.activeTab].title())
+}
+
+// openTab opens path in a new tab, or focuses it if it is already open.
+// An empty path opens a blank buffer in the current language. The returned
+// notice is non-empty when the file needed attention (mixed line endings).
+func (m *model) openTab(path string) (string, error) {
+	m.stashTab()
+	tab := editorTab{language: m.language, lineEnding: eolLF, savedEOL: eolLF}
+	notice := ""
+	if path != "" {
+		absPath, _ := filepath.Abs(path)
+		for i, t := range m.tabs {
+			if existing, _ := filepath.Abs(t.filename); t.filename != "" && existing == absPath {
+				m.loadTab(i)
+				return "", nil
+			}
+		}
+
+		content, readOnly, _, err := loadFileForEditor(path)
+		if err != nil {
+			return "", err
+		}
+		content, eol, mixed := splitLineEndings(content)
+		if mixed {
+			notice = mixedEOLNotice(eol)
+		}
+		tab = editorTab{
+			filename:   path,
+			language:   detectLanguage(path),
+			content:    content,
+			saved:      content,
+			readOnly:   readOnly,
+			lineEnding: eol,
+			savedEOL:   eol,
+		}
+	}
+
+	m.tabs = append(m.tabs, tab)
+	m.loadTab(len(m.tabs) - 1)
+	return notice, nil
+}
+
+// closeTab closes the active tab, asking first if it has unsaved changes;
+// closing the last tab leaves an empty buffer behind
+func (m *model) closeTab() {
+	if m.isDirty() {
+		m.confirmDiscard(discardClose, "")
+		return
+	}
+	m.dropTab()
+}
+
+// dropTab removes the active tab and focuses its neighbour
+func (m *model) dropTab() {
+	closed := "untitled"
+	if m.filename != "" {
+		closed = filepath.Base(m.filename)
+	}
+
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	if len(m.tabs) == 0 {
+		m.tabs = []editorTab{{language: m.language, lineEnding: eolLF, savedEOL: eolLF}}
+	}
+	idx := m.activeTab
+	if idx >= len(m.tabs) {
+		idx = len(m.tabs) - 1
+	}
+	m.loadTab(idx)
+	m.status = "Closed " + closed
+}
+
+// renderTabBar draws the open tabs; empty when only one buffer is open
+func (m model) renderTabBar() string {
+	if len(m.tabs) < 2 {
+		return ""
+	}
+	var parts []string
+	for i, t := range m.tabs {
+		if i == m.activeTab {
+			t = m.currentTab()
+			parts = append(parts, tabActiveStyle.Render(fmt.Sprintf("%d:%s", i+1, t.title())))
+		} else {
+			parts = append(parts, tabInactiveStyle.Render(fmt.Sprintf("%d:%s", i+1, t.title())))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(parts, subtleStyle.Render("│")))
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// maxUndo bounds the undo stack; maxUndoBytes also bounds it for big
+	// files, where every entry is a copy of the buffer
+	maxUndo      = 500
+	maxUndoBytes = 64 << 20
+
+	// undoGroupPause ends a group of typed characters
+	undoGroupPause = 500 * time.Millisecond
+)
+
+// editSnapshot is a buffer state undo can return to
+type editSnapshot struct {
+	content string
+	cursor  int
+}
+
+// undoHistory is the undo/redo stack of one buffer. Typed characters are
+// grouped so a word is undone at once; Enter, a cursor move or a pause
+// starts a new group.
+type undoHistory struct {
+	undo, redo []editSnapshot
+	undoBytes  int
+
+	typing     bool // The top entry is an open group of typed characters
+	lastEdit   time.Time
+	lastCursor int  // Cursor after the last typed character
+	restored   bool // The last change was an undo or redo, not an edit
+}
+
+// undoPoint is the buffer state before a message is handled
+type undoPoint struct {
+	history  *undoHistory
+	tab      int
+	language string
+	editSnapshot
+}
+
+func (m *model) undoPoint() undoPoint {
+	return undoPoint{
+		history:      m.editor.history,
+		tab:          m.activeTab,
+		language:     m.language,
+		editSnapshot: editSnapshot{m.editor.content, m.editor.cursor},
+	}
+}
+
+// resetUndo gives the buffer an empty history (new file, other language)
+func (m *model) resetUndo() {
+	m.editor.history = &undoHistory{}
+}
+
+// typedKey reports whether msg types a single character
+func typedKey(msg tea.Msg) bool {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || key.Alt || key.Paste {
+		return false
+	}
+	return key.Type == tea.KeySpace || (key.Type == tea.KeyRunes && len(key.Runes) == 1)
+}
+
+// trackEdit records the state before msg when handling it changed the
+// buffer. Switching tabs or languages swaps the buffer and is not an edit.
+func (m *model) trackEdit(msg tea.Msg, before undoPoint) {
+	h := m.editor.history
+	if h == nil || h != before.history || m.activeTab != before.tab || m.language != before.language {
+		return
+	}
+	if h.restored {
+		h.restored = false
+		return
+	}
+	if m.editor.content == before.content {
+		if m.editor.cursor != before.cursor {
+			h.typing = false // A cursor move ends the typing group
+		}
+		return
+	}
+	h.record(before.editSnapshot, typedKey(msg), m.editor.cursor, time.Now())
+}
+
+func (h *undoHistory) record(before editSnapshot, typed bool, cursor int, now time.Time) {
+	h.redo = nil
+	coalesce := typed && h.typing && now.Sub(h.lastEdit) < undoGroupPause && before.cursor == h.lastCursor
+	h.typing, h.lastEdit, h.lastCursor = typed, now, cursor
+	if coalesce {
+		return
+	}
+	h.undo = append(h.undo, before)
+	h.undoBytes += len(before.content)
+	for len(h.undo) > 1 && (len(h.undo) > maxUndo || h.undoBytes > maxUndoBytes) {
+		h.undoBytes -= len(h.undo[0].content)
+		h.undo = h.undo[1:]
+	}
+}
+
+// undo handles Ctrl+Z
+func (m *model) undo() {
+	m.restoreEdit(true)
+}
+
+// redo handles Ctrl+Y
+func (m *model) redo() {
+	m.restoreEdit(false)
+}
+
+// restoreEdit moves one step back (undo) or forward (redo)
+func (m *model) restoreEdit(back bool) {
+	name := map[bool]string{true: "undo", false: "redo"}[back]
+	h := m.editor.history
+	if h == nil {
+		m.status = "Nothing to " + name
+		return
+	}
+	from, to := &h.redo, &h.undo
+	if back {
+		from, to = &h.undo, &h.redo
+	}
+	if len(*from) == 0 {
+		m.status = "Nothing to " + name
+		return
+	}
+	if m.guardReadOnly() {
+		return
+	}
+
+	snap := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, editSnapshot{m.editor.content, m.editor.cursor})
+	h.undoBytes = 0
+	for _, s := range h.undo {
+		h.undoBytes += len(s.content)
+	}
+	h.typing, h.restored = false, true
+
+	m.editor.content = snap.content
+	m.editor.cursor = min(snap.cursor, len(snap.content))
+	m.markSet = false
+	m.syncEditorView()
+	m.status = fmt.Sprintf("%s (%d undo, %d redo left)", map[bool]string{true: "Undone", false: "Redone"}[back], len(h.undo), len(h.redo))
+}
@@ -1,6 +1,47 @@
 package tui
 
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// Execution stages reported in execResult
+const (
+	stageSetup   = "setup"
+	stageCompile = "compile"
+	stageRun     = "run"
+)
+
 type execResult struct {
-	output string
-	err    error
+	output   string
+	err      error
+	stage    string // Stage that produced the result (empty if nothing ran)
+	exitCode int
+	duration time.Duration
+}
+
+// exitCodeOf returns the process exit code, or -1 if it never ran
+func exitCodeOf(cmd *exec.Cmd) int {
+	if cmd == nil || cmd.ProcessState == nil {
+		return -1
+	}
+	return cmd.ProcessState.ExitCode()
+}
+
+// summary renders the footer line shown under the program output,
+// e.g. "exited 1 in 0.42s" or "compilation failed (exit 1) in 1.03s".
+func (r execResult) summary() string {
+	secs := r.duration.Seconds()
+	switch r.stage {
+	case stageCompile:
+		return fmt.Sprintf("compilation failed (exit %d) in %.2fs", r.exitCode, secs)
+	case stageRun:
+		if r.exitCode < 0 {
+			return fmt.Sprintf("terminated after %.2fs", secs)
+		}
+		return fmt.Sprintf("exited %d in %.2fs", r.exitCode, secs)
+	default:
+		return ""
+	}
 }
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// fileCategory restricts File Manager listings to a set of extensions.
+// A nil extension set means "everything".
+type fileCategory struct {
+	name string
+	exts map[string]bool
+}
+
+// Built-in categories, cycled in this order. Users can extend or override
+// them with the `file_categories` config map (name -> list of extensions).
+var defaultFileCategories = []struct {
+	name string
+	exts []string
+}{
+	{"Code", []string{".go", ".py", ".js", ".ts", ".jsx", ".tsx", ".java", ".c", ".h", ".cpp", ".hpp", ".cc", ".cs", ".rs", ".zig", ".rb", ".php", ".swift", ".kt", ".sh", ".ps1", ".html", ".css", ".scss", ".sql", ".json", ".yaml", ".yml", ".toml"}},
+	{"Images", []string{".png", ".jpg", ".jpeg", ".gif", ".bmp", ".svg", ".webp", ".ico", ".tiff"}},
+	{"Docs", []string{".md", ".txt", ".pdf", ".doc", ".docx", ".odt", ".rtf", ".csv", ".xls", ".xlsx", ".ppt", ".pptx"}},
+	{"Archives", []string{".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".7z", ".rar"}},
+	{"Media", []string{".mp3", ".wav", ".flac", ".ogg", ".mp4", ".mkv", ".mov", ".avi", ".webm"}},
+}
+
+func newExtSet(exts []string) map[string]bool {
+	set := make(map[string]bool, len(exts))
+	for _, e := range exts {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if e == "" {
+			continue
+		}
+		if !strings.HasPrefix(e, ".") {
+			e = "." + e
+		}
+		set[e] = true
+	}
+	return set
+}
+
+// loadFileCategories returns "All" followed by the built-in categories,
+// with any config-defined categories merged in (same name overrides).
+func loadFileCategories() []fileCategory {
+	categories := []fileCategory{{name: "All"}}
+	index := map[string]int{}
+	for _, c := range defaultFileCategories {
+		index[strings.ToLower(c.name)] = len(categories)
+		categories = append(categories, fileCategory{name: c.name, exts: newExtSet(c.exts)})
+	}
+
+	custom := config.GetStringMapStringSlice("file_categories")
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		cat := fileCategory{name: strings.ToUpper(name[:1]) + name[1:], exts: newExtSet(custom[name])}
+		if i, ok := index[strings.ToLower(name)]; ok {
+			categories[i].exts = cat.exts
+			continue
+		}
+		categories = append(categories, cat)
+	}
+	return categories
+}
+
+// matches reports whether path belongs to the category
+func (c fileCategory) matches(path string) bool {
+	if c.exts == nil {
+		return true
+	}
+	return c.exts[strings.ToLower(filepath.Ext(path))]
+}
@@ -1,20 +1,24 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"sort"
+	"regexp"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/keymap"
+	"github.com/phravins/devcli/pkg/utils"
 	"github.com/sahilm/fuzzy"
 )
 
@@ -29,6 +33,7 @@ type FileManagerModel struct {
 	quitting    bool
 	searchInput textinput.Model
 	err         error
+	keys        keymap.Map // Active key bindings (keys.filemanager.*)
 
 	selectedFile string
 
@@ -42,12 +47,32 @@ type FileManagerModel struct {
 	copyInput       textinput.Model
 	selectedForCopy string
 
+	// New File/Folder Implementation
+	createMode  bool
+	createInput textinput.Model
+
+	// Delete Implementation (asks for confirmation first)
+	deleteMode        bool
+	deleteIsDir       bool
+	selectedForDelete string
+
+	// Sort order (cycled with Alt+O) and the stat results it and the
+	// size/modified columns use, keyed by full path
+	sortMode  fileSort
+	metaCache map[string]entryMeta
+
+	// Hidden files (dotfiles, hidden attribute on Windows), toggled with
+	// Alt+. and saved as filemanager.show_hidden
+	showHidden bool
+
 	// Path Edit Implementation
 	pathMode  bool
 	pathInput textinput.Model
 
-	// Search Cache
-	allFilePaths []string
+	// Search Cache, capped at indexLimit paths (filemanager.max_index)
+	allFilePaths   []string
+	indexLimit     int
+	indexTruncated bool
 
 	// Navigation History
 	history []string
@@ -62,7 +87,25 @@ type FileManagerModel struct {
 	searchID int
 
 	// Concurrency
-	scanChan chan string
+	scanChan    chan string
+	scanStats   *utils.WalkStats // Filled by the global scan before it closes scanChan
+	scanDone    bool
+	scanCtx     context.Context // Cancelled by Ctrl+R to stop the scan
+	scanCancel  context.CancelFunc
+	scanIndexed int // Paths the current scan delivered so far
+	scanPaths   []string
+	scanRefresh bool      // The previous index serves searches until the scan completes
+	indexCached time.Time // When the cached index in use was saved; zero once rescanned
+
+	// Background index of the start folder (fills local search results)
+	indexChan chan string
+	indexRoot string
+	indexing  bool
+	spinner   spinner.Model
+
+	// Hex viewer for binary files (Enter) or any file (Alt+H)
+	showHex bool
+	hexView hexViewer
 
 	// Layout
 	ready bool
@@ -71,6 +114,28 @@ type FileManagerModel struct {
 	// Help
 	showHelp bool
 	helpView viewport.Model // New
+
+	// Category Filter (cycled with Alt+T)
+	categories  []fileCategory
+	categoryIdx int
+
+	// Search History (recalled with Alt+Up/Alt+Down)
+	historyIdx   int // -1 when not browsing the history
+	historyDraft string
+
+	// Content Search (Alt+F), run in grepRoot
+	grepMode      bool
+	grepRegex     bool
+	grepInput     textinput.Model
+	grepRe        *regexp.Regexp
+	grepMatches   []utils.GrepMatch
+	grepCursor    int
+	grepID        int // Bumped per query so stale results are dropped
+	grepRoot      string
+	grepRunning   bool
+	grepTruncated bool
+	grepErr       error
+	grepCancel    context.CancelFunc
 }
 
 type searchDebounceMsg struct {
@@ -82,7 +147,7 @@ type filterFinishedMsg struct {
 }
 
 // Async Search Command
-func performSearchCmd(paths []string, query string) tea.Cmd {
+func performSearchCmd(paths []string, query string, category fileCategory, showHidden bool) tea.Cmd {
 	return func() tea.Msg {
 		if query == "" {
 			// Special case: usually handled before calling this, but safe fallback
@@ -102,6 +167,9 @@ func performSearchCmd(paths []string, query string) tea.Cmd {
 				if len(matches) >= maxResults {
 					break
 				}
+				if !category.matches(path) || (!showHidden && inHiddenPath(path)) {
+					continue
+				}
 				if strings.Contains(strings.ToLower(path), lowerQuery) {
 					matches = append(matches, path)
 				}
@@ -113,6 +181,9 @@ func performSearchCmd(paths []string, query string) tea.Cmd {
 				if len(matches) >= maxResults {
 					break
 				}
+				if !category.matches(m.Str) || (!showHidden && inHiddenPath(m.Str)) {
+					continue
+				}
 				matches = append(matches, m.Str)
 			}
 		}
@@ -158,17 +229,6 @@ func NewFileManagerModel(startPath string) FileManagerModel {
 	hv := viewport.New(80, 20)
 	hv.Style = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("62")).Padding(1, 2)
 
-	// Render Markdown Help
-	renderer, _ := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(80),
-	)
-	out, err := renderer.Render(FileManagerHelp)
-	if err != nil {
-		out = FileManagerHelp
-	}
-	hv.SetContent(out)
-
 	mi := textinput.New()
 	mi.Placeholder = "New path/name..."
 	mi.CharLimit = 256
@@ -179,107 +239,102 @@ func NewFileManagerModel(startPath string) FileManagerModel {
 	ci.CharLimit = 256
 	ci.Width = 50
 
+	ni := textinput.New()
+	ni.Placeholder = "name.txt, or folder/ for a folder"
+	ni.CharLimit = 256
+	ni.Width = 50
+
 	pi := textinput.New()
 	pi.Placeholder = "/path/to/folder"
 	pi.CharLimit = 256
 	pi.Width = 60
 	pi.SetValue(startPath)
 
+	config.LoadConfig()
+	keys, keysWarning := loadKeymap("filemanager")
+	hv.SetContent(renderFileManagerHelp(keymapHelp(keys)))
+
 	m := FileManagerModel{
+		categories:   loadFileCategories(),
+		historyIdx:   -1,
 		currentPath:  startPath,
 		searchInput:  ti,
 		moveInput:    mi,
 		copyInput:    ci,
+		createInput:  ni,
+		metaCache:    map[string]entryMeta{},
+		showHidden:   loadShowHidden(),
+		grepInput:    newGrepInput(),
 		pathInput:    pi,
 		globalSearch: true, // Default to Global
 		loading:      true, // Start loading
-		scanChan:     make(chan string, 1000),
+		indexLimit:   maxIndex(),
+		indexChan:    make(chan string, 1000),
+		indexRoot:    startPath,
+		indexing:     true,
+		spinner:      spinner.New(spinner.WithSpinner(spinner.Dot)),
 		// width/height default to 0, waiting for WindowSizeMsg
 		helpView: hv,
+		keys:     keys,
+	}
+	if keysWarning != "" {
+		m.err = errors.New(keysWarning)
 	}
 
-	// Pre-load current directory recursively so search works immediately for local files
-	m.reloadAllFiles() // This fills m.allFilePaths with local files first
-
+	// The global scan and the recursive index of startPath are started by
+	// Init in the background
+	m.prepareScan()
+	if m.useFileIndexCache() {
+		m.showScanResult()
+	}
+	m.scanRefresh = len(m.allFilePaths) > 0
 	m.loadFiles()
 	return m
 }
 
-// Msg when scanning starts
-type scanStartedMsg struct{}
+// Msg when scanning starts. Scan messages carry their channel so those of
+// a scan that was stopped and restarted are dropped.
+type scanStartedMsg struct {
+	ch chan string
+}
 
 // Msg for incremental results
 type searchResultMsg struct {
 	paths []string
+	ch    chan string
 }
 
 // Msg when scanning is complete
-type scanFinishedMsg struct{}
+type scanFinishedMsg struct {
+	ch chan string
+}
 
-// Command to start background scanning
-func startGlobalScanCmd(ch chan string) tea.Cmd {
+// Command to start background scanning. User folders are walked before the
+// drives so they are indexed first if the scan stops at limit.
+func startGlobalScanCmd(ctx context.Context, ch chan string, limit int, stats *utils.WalkStats) tea.Cmd {
 	return func() tea.Msg {
 		go func() {
-			drives := getDrives()
-			var wg sync.WaitGroup
-
-			for _, drive := range drives {
-				wg.Add(1)
-				go func(d string) {
-					defer wg.Done()
-					filepath.WalkDir(d, func(path string, de fs.DirEntry, err error) error {
-						if err != nil {
-							if de != nil && de.IsDir() {
-								return filepath.SkipDir
-							}
-							return nil
-						}
-						// Non-blocking send or block? If buffer full, we block.
-						ch <- path
-						return nil
-					})
-				}(drive)
-			}
-
-			wg.Wait()
-			close(ch)
+			defer close(ch)
+			// If buffer full, we block until read or cancelled
+			*stats = utils.WalkLimitedContext(ctx, globalIndexRoots(), limit, scanOptions(), func(path string) {
+				select {
+				case ch <- path:
+				case <-ctx.Done():
+				}
+			})
 		}()
-		return scanStartedMsg{}
+		return scanStartedMsg{ch: ch}
 	}
 }
 
 // Command to listen for results (Batched with Time Buffer)
 func waitForSearchResults(ch chan string) tea.Cmd {
 	return func() tea.Msg {
-		var batch []string
-		const maxBatch = 5000
-		const batchTimeout = 200 * time.Millisecond
-
-		// 1. Blocking wait for at least one item
-		path, ok := <-ch
-		if !ok {
-			return scanFinishedMsg{}
+		batch, _ := collectBatch(ch)
+		if len(batch) == 0 {
+			return scanFinishedMsg{ch: ch}
 		}
-		batch = append(batch, path)
-
-		// 2. Try to collect more items
-		timer := time.NewTimer(batchTimeout)
-		defer timer.Stop()
-
-	loop:
-		for len(batch) < maxBatch {
-			select {
-			case p, open := <-ch:
-				if !open {
-					break loop
-				}
-				batch = append(batch, p)
-			case <-timer.C:
-				break loop
-			}
-		}
-
-		return searchResultMsg{paths: batch}
+		return searchResultMsg{paths: batch, ch: ch}
 	}
 }
 
@@ -289,43 +344,96 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	// Start listening when file load starts
 	case scanStartedMsg:
+		if msg.ch != m.scanChan {
+			return m, nil
+		}
 		m.loading = true
 		return m, waitForSearchResults(m.scanChan)
 
+	case openTerminalMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("open terminal: %w", msg.err)
+			return m, nil
+		}
+		return m, notify(fmt.Sprintf("Opened %s in %s", msg.terminal, msg.dir))
+
 	// Handle Streamed Result
 	case searchResultMsg:
-		m.allFilePaths = append(m.allFilePaths, msg.paths...)
-
-		// Performance Optimization: Incremental Filter
-		// Use simple substring match for real-time updates to avoid lag.
-		// Fuzzy is too slow for high-frequency updates.
-		if m.searchInput.Value() != "" {
-			query := strings.ToLower(m.searchInput.Value())
-			for _, p := range msg.paths {
-				if strings.Contains(strings.ToLower(p), query) {
-					m.filtered = append(m.filtered, dummyEntry{path: p})
-				}
-			}
+		if msg.ch != m.scanChan {
+			return m, nil
+		}
+		m.scanIndexed += len(msg.paths)
+		m.scanPaths = append(m.scanPaths, msg.paths...)
+		if !m.scanRefresh {
+			m.addIndexed(msg.paths)
 		}
 		return m, waitForSearchResults(m.scanChan)
 
+	case localIndexMsg:
+		// Paths are relative to indexRoot; drop them once the user has moved on
+		if m.currentPath == m.indexRoot {
+			m.addIndexed(msg.paths)
+		}
+		if !msg.done {
+			return m, waitForLocalIndex(m.indexChan)
+		}
+		m.indexing = false
+		if m.searchInput.Value() == "" || m.currentPath != m.indexRoot {
+			return m, nil
+		}
+		return m, performSearchCmd(m.allFilePaths, m.searchInput.Value(), m.activeCategory(), m.showHidden)
+
+	case grepDebounceMsg:
+		if msg.id == m.grepID {
+			return m, m.startGrep()
+		}
+		return m, nil
+
+	case grepResultMsg:
+		return m, m.addGrepResults(msg)
+
+	case spinner.TickMsg:
+		if !m.indexing && !m.loading && !m.grepRunning {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
 	case scanFinishedMsg:
+		if msg.ch != m.scanChan {
+			return m, nil
+		}
 		m.loading = false
-		m.searchInput.Placeholder = fmt.Sprintf("Search %d files across all drives...", len(m.allFilePaths))
+		m.scanDone = true
+		var save tea.Cmd
+		if !m.scanStats.Cancelled {
+			if m.scanRefresh {
+				// Swap in the new global index, keeping the local one
+				local, _ := splitIndex(m.allFilePaths)
+				m.allFilePaths = append(m.scanPaths, local...)
+				m.indexTruncated = false
+			}
+			m.indexCached = time.Time{}
+			save = saveFileIndexCmd(m.scanPaths, *m.scanStats)
+		}
+		m.scanPaths = nil
+		m.indexTruncated = m.indexTruncated || m.scanStats.Truncated
+		m.showScanResult()
 		if m.searchInput.Value() == "" {
-			return m, nil
+			return m, save
 		}
-		return m, performSearchCmd(m.allFilePaths, m.searchInput.Value())
+		return m, tea.Batch(save, performSearchCmd(m.allFilePaths, m.searchInput.Value(), m.activeCategory(), m.showHidden))
 
 	case searchDebounceMsg:
 		if msg.id == m.searchID {
 			m.searchID++
-			return m, performSearchCmd(m.allFilePaths, m.searchInput.Value())
+			return m, performSearchCmd(m.allFilePaths, m.searchInput.Value(), m.activeCategory(), m.showHidden)
 		}
 		return m, nil
 
 	case filterFinishedMsg:
 		m.filtered = msg.results
+		m.sortResults(m.filtered)
 		m.cursor = 0
 		return m, nil
 
@@ -338,14 +446,29 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Resize Help View
 		m.helpView.Width = msg.Width - 6
 		m.helpView.Height = msg.Height - 10
+		if m.showHex {
+			m.hexView.resize(m.width, m.height)
+		}
 		return m, nil
 
 	case tea.MouseMsg:
+		if m.showHex {
+			return m, nil
+		}
 		if m.showHelp {
 			var cmd tea.Cmd
 			m.helpView, cmd = m.helpView.Update(msg)
 			return m, cmd
 		}
+		if m.grepMode {
+			switch msg.Type {
+			case tea.MouseWheelUp:
+				m.grepCursor = max(m.grepCursor-3, 0)
+			case tea.MouseWheelDown:
+				m.grepCursor = max(min(m.grepCursor+3, len(m.grepMatches)-1), 0)
+			}
+			return m, nil
+		}
 
 		switch msg.Type {
 		case tea.MouseWheelUp:
@@ -390,38 +513,7 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				if clickedIndex >= 0 && clickedIndex < len(m.filtered) {
 					m.cursor = clickedIndex
-
-					// Trigger Select Action (Same as KeyEnter)
-					selected := m.filtered[m.cursor]
-					pathName := selected.Name()
-					var fullPath string
-					if filepath.IsAbs(pathName) {
-						fullPath = pathName
-					} else {
-						fullPath = filepath.Join(m.currentPath, pathName)
-					}
-
-					info, err := os.Stat(fullPath)
-					isDir := false
-					if err == nil && info.IsDir() {
-						isDir = true
-					} else if selected.IsDir() {
-						isDir = true
-					}
-
-					if isDir {
-						m.history = append(m.history, m.currentPath)
-						m.pathInput.SetValue(fullPath) // Update path input
-						m.currentPath = fullPath
-						m.searchInput.Reset()
-						m.globalSearch = false
-						m.loadFiles()
-						m.cursor = 0
-					} else {
-						m.selectedFile = fullPath
-						// Switch to Editor
-						return m, func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: fullPath} }
-					}
+					return m, m.openSelected(m.filtered[m.cursor])
 				}
 			}
 		}
@@ -429,6 +521,12 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.showHex {
+			done, cmd := m.hexView.update(msg)
+			m.showHex = !done
+			return m, cmd
+		}
+
 		// Modal Inputs (Move/Copy Prompt)
 		if m.moveMode {
 			switch msg.Type {
@@ -506,6 +604,14 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.deleteMode {
+			return m, m.updateDelete(msg)
+		}
+
+		if m.createMode {
+			return m, m.updateCreate(msg)
+		}
+
 		if m.pathMode {
 			switch msg.Type {
 			case tea.KeyEnter:
@@ -557,8 +663,31 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Rebound keys arrive as their default, which the cases below match
+		var bound bool
+		if msg, bound = m.keys.Translate(msg); !bound {
+			return m, nil
+		}
+
+		if m.grepMode {
+			return m.updateGrep(msg)
+		}
+
+		m.err = nil // Shown until the next key
+
 		// 1. Navigation & Search Control
 		switch msg.String() {
+		case "alt+f", "ctrl+g": // Ctrl+G is a fixed second key for content search
+			return m, m.toggleGrep()
+		case "alt+g":
+			return m, m.copyImportPath()
+		case "alt+s":
+			return m, openTerminalHere(m.currentPath)
+		case "alt+h":
+			if len(m.filtered) > 0 && !m.filtered[m.cursor].IsDir() {
+				m.openHexView(m.entryPath(m.filtered[m.cursor]))
+			}
+			return m, nil
 		case "?":
 			m.showHelp = true
 			m.helpView.GotoTop()
@@ -629,44 +758,8 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.filtered) == 0 {
 				return m, nil
 			}
-			selected := m.filtered[m.cursor]
-
-			pathName := selected.Name()
-			var fullPath string
-			if filepath.IsAbs(pathName) {
-				fullPath = pathName
-			} else {
-				fullPath = filepath.Join(m.currentPath, pathName)
-			}
-
-			// FIX: Check if it's a directory using os.Stat
-			// This handles dummyEntry (from search) which reports false for IsDir()
-			info, err := os.Stat(fullPath)
-			isDir := false
-			if err == nil && info.IsDir() {
-				isDir = true
-			} else if selected.IsDir() {
-				// Fallback to entry info if Stat fails (rare) or strict
-				isDir = true
-			}
-
-			if isDir {
-				m.history = append(m.history, m.currentPath)
-				m.currentPath = fullPath
-
-				// Entering a folder:
-				// 1. Reset Search (so we see contents, not the old query)
-				m.searchInput.Reset()
-				// 2. Disable Global Search (we are now scoping to this folder)
-				m.globalSearch = false
-
-				m.loadFiles()
-				m.cursor = 0
-			} else {
-				m.selectedFile = fullPath
-				return m, func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: fullPath} }
-			}
-			return m, nil
+			recordSearch(m.searchInput.Value())
+			return m, m.openSelected(m.filtered[m.cursor])
 
 		case "tab":
 			m.globalSearch = !m.globalSearch
@@ -688,6 +781,21 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterFiles(m.searchInput.Value())
 			return m, nil
 
+		case "alt+up", "alt+down":
+			delta := 1
+			if msg.String() == "alt+down" {
+				delta = -1
+			}
+			if !m.recallSearch(delta) {
+				return m, nil
+			}
+			m.searchID++
+			if m.searchInput.Value() == "" {
+				m.filterFiles("")
+				return m, nil
+			}
+			return m, performSearchCmd(m.allFilePaths, m.searchInput.Value(), m.activeCategory(), m.showHidden)
+
 		case "left_arrow_placeholder":
 			// Consolidated above
 		}
@@ -716,17 +824,34 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.copyInput.Focus()
 				return m, textinput.Blink
 			}
+		case "alt+d":
+			m.startDelete()
+			return m, nil
+		case "ctrl+r":
+			return m, m.toggleScan()
+		case "alt+n":
+			return m, m.startCreate()
+		case "alt+o":
+			m.cycleSort()
+			return m, nil
+		case "alt+.":
+			m.toggleHidden()
+			return m, nil
+		case "alt+x":
+			if len(m.filtered) > 0 {
+				return m, m.openExternally(m.entryPath(m.filtered[m.cursor]), false)
+			}
+			return m, nil
+		case "alt+t":
+			m.categoryIdx = (m.categoryIdx + 1) % len(m.categories)
+			m.filterFiles(m.searchInput.Value())
+			return m, nil
 		case "alt+e":
 			if len(m.filtered) > 0 {
 				selected := m.filtered[m.cursor]
 				if !selected.IsDir() {
-					pathName := selected.Name()
-					var fullPath string
-					if filepath.IsAbs(pathName) {
-						fullPath = pathName
-					} else {
-						fullPath = filepath.Join(m.currentPath, pathName)
-					}
+					fullPath := m.entryPath(selected)
+					recordSearch(m.searchInput.Value())
 					m.selectedFile = fullPath
 					return m, func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: fullPath} }
 				}
@@ -751,6 +876,7 @@ func (m FileManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.searchInput, nextCmd = m.searchInput.Update(msg)
 
 		if m.searchInput.Value() != oldValue {
+			m.historyIdx = -1
 			m.searchID++
 			// If empty, reset immediately
 			if m.searchInput.Value() == "" {
@@ -784,6 +910,10 @@ func (m FileManagerModel) View() string {
 		h = 24
 	}
 
+	if m.showHex {
+		return m.hexView.View(w, h)
+	}
+
 	// Show help screen
 	if m.showHelp {
 		return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center,
@@ -801,6 +931,10 @@ func (m FileManagerModel) View() string {
 		return lipgloss.NewStyle().Width(w).Height(h).Align(lipgloss.Center, lipgloss.Center).Render("Loading File Manager...")
 	}
 
+	if m.grepMode {
+		return m.grepView(w, h)
+	}
+
 	// 1. Render Search Bar (Header) First to measure
 	searchBorder := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -809,10 +943,16 @@ func (m FileManagerModel) View() string {
 		Width(w - 4)
 
 	loading := ""
-	if m.loading && m.searchInput.Value() != "" {
+	if m.indexing && m.currentPath == m.indexRoot {
+		loading = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+			Render(fmt.Sprintf(" %s Loading current directory... (%d files)", m.spinner.View(), len(m.allFilePaths)))
+	} else if m.indexTruncated {
+		loading = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).
+			Render(fmt.Sprintf("  index truncated at %d — results may be incomplete", m.indexLimit))
+	} else if m.loading && m.searchInput.Value() != "" {
 		loading = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF79C6")).Render("  Scanning...")
 	} else if m.loading {
-		loading = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" (Indexing...)")
+		loading = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf(" (Indexing... %d files)", m.scanIndexed))
 	}
 
 	searchBar := searchBorder.Render(m.searchInput.View() + loading)
@@ -842,22 +982,34 @@ func (m FileManagerModel) View() string {
 	pathBox := pathBoxStyle.Render(pathContent)
 
 	// Status Bar (Top of Footer)
-	status := fmt.Sprintf("  Files: %d  Global: %v", len(m.filtered), m.globalSearch)
-	infoBar := lipgloss.JoinHorizontal(lipgloss.Left, pathBox, infoStyle.Render(status))
+	hidden := "shown"
+	if !m.showHidden {
+		hidden = "hidden"
+	}
+	status := fmt.Sprintf("  Files: %d  Global: %v  Category: %s  Sort: %s  Dotfiles: %s  %s", len(m.filtered), m.globalSearch, m.activeCategory().name, m.sortMode, hidden, m.scanSummary())
+	statusText := infoStyle.Render(status)
+	if m.err != nil {
+		statusText = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("  " + m.err.Error())
+	}
+	infoBar := lipgloss.JoinHorizontal(lipgloss.Left, pathBox, statusText)
 
 	keyFooter := ""
 	if m.moveMode {
 		keyFooter = fmt.Sprintf("Rename/Move '%s' to: %s", m.selectedForMove, m.moveInput.View())
 	} else if m.copyMode {
 		keyFooter = fmt.Sprintf("Copy '%s' to: %s", m.selectedForCopy, m.copyInput.View())
+	} else if m.deleteMode {
+		keyFooter = m.deletePrompt()
+	} else if m.createMode {
+		keyFooter = fmt.Sprintf("New in '%s' (end with / for a folder): %s", filepath.Base(m.currentPath), m.createInput.View())
 	} else {
 		drives := getDrives()
-		keyFooter = infoStyle.Render(fmt.Sprintf("Esc: Back • Tab: Global • [Ctrl+L] Edit Path • [?] Help • Drives: %v", drives))
+		keyFooter = renderKeyFooter(0, relabelHints(m.keys, fileManagerKeyHints)) + infoStyle.Render(fmt.Sprintf(" • Drives: %v", drives))
 	}
 
 	totalFilesStr := fmt.Sprintf("Total files : %d", len(m.filtered))
-	leftText := keyFooter
 	rightText := infoStyle.Render(totalFilesStr)
+	leftText := lipgloss.NewStyle().MaxWidth(w - lipgloss.Width(rightText) - 3).Render(keyFooter)
 
 	gap := w - lipgloss.Width(leftText) - lipgloss.Width(rightText) - 2
 	if gap < 1 {
@@ -920,6 +1072,8 @@ func (m FileManagerModel) View() string {
 				}
 			}
 
+			column := m.entryColumn(f)
+
 			// Styling
 			var nameStyle, iconStyle lipgloss.Style
 			var rowRendered string
@@ -940,7 +1094,7 @@ func (m FileManagerModel) View() string {
 				rowRendered = lipgloss.NewStyle().
 					Background(lipgloss.Color("#5A4E8C")).
 					Width(w - 2).
-					Render(rowContent)
+					Render(withColumn(rowContent, column, w-2))
 			} else {
 				if f.IsDir() {
 					nameStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#44A8F0"))
@@ -950,6 +1104,7 @@ func (m FileManagerModel) View() string {
 				iconStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
 				rowRendered = fmt.Sprintf(" %s %s", iconStyle.Render(icon), nameStyle.Render(name))
+				rowRendered = withColumn(rowRendered, infoStyle.Render(column), w-2)
 				rowRendered = lipgloss.NewStyle().Width(w - 2).Render(rowRendered)
 			}
 
@@ -1007,25 +1162,41 @@ func (m *FileManagerModel) loadFiles() {
 		m.err = err
 		return
 	}
-	// ... sort ...
-	sort.Slice(entries, func(i, j int) bool {
-		if entries[i].IsDir() && !entries[j].IsDir() {
-			return true
-		}
-		if !entries[i].IsDir() && entries[j].IsDir() {
-			return false
-		}
-		return entries[i].Name() < entries[j].Name()
-	})
+	if !m.showHidden {
+		entries = withoutHidden(entries)
+	}
+	clear(m.metaCache) // Sizes and times may have changed since the last load
+	m.sortEntries(entries)
 
 	m.files = entries
 	// FIX: Always filter to update view when files are loaded, even if background scan is running.
 	m.filterFiles(m.searchInput.Value())
 }
 
+// activeCategory returns the selected category filter ("All" by default)
+func (m FileManagerModel) activeCategory() fileCategory {
+	if len(m.categories) == 0 {
+		return fileCategory{name: "All"}
+	}
+	return m.categories[m.categoryIdx]
+}
+
 func (m *FileManagerModel) filterFiles(query string) {
+	category := m.activeCategory()
 	if query == "" {
-		m.filtered = m.files
+		if category.exts == nil {
+			m.filtered = m.files
+			return
+		}
+		// Keep folders navigable, restrict files to the category
+		var results []fs.DirEntry
+		for _, f := range m.files {
+			if f.IsDir() || category.matches(f.Name()) {
+				results = append(results, f)
+			}
+		}
+		m.filtered = results
+		m.cursor = 0
 		return
 	}
 
@@ -1042,15 +1213,17 @@ func (m *FileManagerModel) filterFiles(query string) {
 		// FAST PATH: Simple Case-Insensitive Substring Match
 		lowerQuery := strings.ToLower(query)
 		for _, path := range m.allFilePaths {
-			if strings.Contains(strings.ToLower(path), lowerQuery) {
+			if category.matches(path) && (m.showHidden || !inHiddenPath(path)) && strings.Contains(strings.ToLower(path), lowerQuery) {
 				matches = append(matches, path)
 			}
 		}
 	} else {
 		// SLOW PATH: Fuzzy Match
 		fuzzyMatches := fuzzy.Find(query, m.allFilePaths)
-		for _, m := range fuzzyMatches {
-			matches = append(matches, m.Str)
+		for _, match := range fuzzyMatches {
+			if category.matches(match.Str) && (m.showHidden || !inHiddenPath(match.Str)) {
+				matches = append(matches, match.Str)
+			}
 		}
 	}
 
@@ -1058,6 +1231,7 @@ func (m *FileManagerModel) filterFiles(query string) {
 	for _, matchPath := range matches {
 		results = append(results, dummyEntry{path: matchPath})
 	}
+	m.sortResults(results)
 	m.filtered = results
 	m.cursor = 0
 }
@@ -1068,17 +1242,45 @@ func (m *FileManagerModel) reloadAllFiles() {
 	}
 	// Local recursive load (sync)
 	m.allFilePaths = []string{}
-	filepath.WalkDir(m.currentPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return nil
-		}
-		if path == m.currentPath {
-			return nil
+	// The limit counts the root itself, which is not listed
+	root := filepath.Clean(m.currentPath)
+	stats := utils.WalkLimitedContext(context.Background(), []string{root}, m.indexLimit+1, scanOptions(), func(path string) {
+		if path != root {
+			rel, _ := filepath.Rel(root, path)
+			m.allFilePaths = append(m.allFilePaths, rel)
 		}
-		rel, _ := filepath.Rel(m.currentPath, path)
-		m.allFilePaths = append(m.allFilePaths, rel)
-		return nil
 	})
+	m.indexTruncated = stats.Truncated
+}
+
+var fileManagerKeyHints = []keyHint{
+	{"Esc", "Back"},
+	{"Tab", "Global"},
+	{"Ctrl+L", "Edit Path"},
+	{"Alt+E", "Edit"},
+	{"Alt+X", "Open Externally"},
+	{"Alt+M", "Move"},
+	{"Alt+C", "Copy"},
+	{"Alt+D", "Delete"},
+	{"Alt+N", "New"},
+	{"Ctrl+R", "Stop/Rescan"},
+	{"Alt+T", "Category"},
+	{"Alt+O", "Sort"},
+	{"Alt+.", "Hidden"},
+	{"Alt+F", "Grep"},
+	{"Alt+↑/↓", "History"},
+	{"Alt+G", "Go Import Path"},
+	{"Alt+S", "Terminal Here"},
+	{"?", "Help"},
+}
+
+// entryPath resolves a list entry (a name in currentPath, or a search
+// result that may already be absolute) to a full path
+func (m FileManagerModel) entryPath(e fs.DirEntry) string {
+	if filepath.IsAbs(e.Name()) {
+		return e.Name()
+	}
+	return filepath.Join(m.currentPath, e.Name())
 }
 
 // Dummy entry for search results
@@ -1095,9 +1297,12 @@ func (m FileManagerModel) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	cmds = append(cmds, tea.EnableMouseCellMotion) // Enable Mouse
 
-	// Only start global scan if we haven't already loaded files or if explicitly requested.
-	if len(m.allFilePaths) == 0 {
-		cmds = append(cmds, startGlobalScanCmd(m.scanChan))
+	// A fresh cached index (filemanager.index_max_age) makes the scan unnecessary
+	if !m.scanDone {
+		cmds = append(cmds, startGlobalScanCmd(m.scanCtx, m.scanChan, m.indexLimit, m.scanStats))
+	}
+	if m.indexing {
+		cmds = append(cmds, startLocalIndexCmd(m.indexRoot, m.indexChan, m.indexLimit), m.spinner.Tick)
 	}
 	return tea.Batch(cmds...)
 }
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// indexMaxAgeKey is how old the cached global index may get before the
+// File Manager rescans in the background, as a duration ("24h"). "0"
+// rescans on every start; the cache still serves searches meanwhile.
+const indexMaxAgeKey = "filemanager.index_max_age"
+
+const defaultIndexMaxAge = 24 * time.Hour
+
+// fileIndexCache is the global index as saved by the last complete scan.
+// It is gob-encoded: a million paths decode several times faster than JSON.
+type fileIndexCache struct {
+	SavedAt time.Time
+	Paths   []string
+	Stats   utils.WalkStats
+}
+
+func indexMaxAge() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(config.GetString(indexMaxAgeKey))); err == nil && d >= 0 {
+		return d
+	}
+	return defaultIndexMaxAge
+}
+
+func fileIndexCachePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "fileindex.gob"), nil
+}
+
+// loadFileIndexCache returns the saved index, if there is a readable one
+func loadFileIndexCache() (fileIndexCache, bool) {
+	path, err := fileIndexCachePath()
+	if err != nil {
+		return fileIndexCache{}, false
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fileIndexCache{}, false
+	}
+	defer f.Close()
+	var cache fileIndexCache
+	if err := gob.NewDecoder(f).Decode(&cache); err != nil || len(cache.Paths) == 0 {
+		return fileIndexCache{}, false
+	}
+	return cache, true
+}
+
+// saveFileIndexCmd writes the index of a finished scan in the background
+func saveFileIndexCmd(paths []string, stats utils.WalkStats) tea.Cmd {
+	return func() tea.Msg {
+		path, err := fileIndexCachePath()
+		if err != nil {
+			return nil
+		}
+		var buf bytes.Buffer
+		if gob.NewEncoder(&buf).Encode(fileIndexCache{SavedAt: time.Now(), Paths: paths, Stats: stats}) == nil {
+			writeFileAtomic(path, buf.Bytes())
+		}
+		return nil
+	}
+}
+
+// useFileIndexCache fills the global index from the cache so search works
+// at once. It reports whether the cache is fresh enough to skip the scan.
+func (m *FileManagerModel) useFileIndexCache() (fresh bool) {
+	cache, ok := loadFileIndexCache()
+	if !ok {
+		return false
+	}
+	if len(cache.Paths) > m.indexLimit {
+		cache.Paths = cache.Paths[:m.indexLimit]
+		cache.Stats.Truncated = true
+	}
+	m.allFilePaths = cache.Paths
+	m.indexTruncated = cache.Stats.Truncated
+	m.indexCached = cache.SavedAt
+	if time.Since(cache.SavedAt) < indexMaxAge() {
+		*m.scanStats = cache.Stats
+		m.scanDone, m.loading = true, false
+		return true
+	}
+	return false
+}
+
+// splitIndex separates the local index (paths relative to the start
+// folder) from the global one (absolute paths)
+func splitIndex(paths []string) (local, global []string) {
+	for _, p := range paths {
+		if filepath.IsAbs(p) {
+			global = append(global, p)
+		} else {
+			local = append(local, p)
+		}
+	}
+	return local, global
+}
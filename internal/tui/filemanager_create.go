@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startCreate prompts for the name of a new file or folder (Alt+N)
+func (m *FileManagerModel) startCreate() tea.Cmd {
+	m.createMode = true
+	m.createInput.Reset()
+	m.createInput.Focus()
+	return textinput.Blink
+}
+
+// updateCreate handles a key in the new file/folder prompt
+func (m *FileManagerModel) updateCreate(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.createMode = false
+		m.createInput.Blur()
+		name := strings.TrimSpace(m.createInput.Value())
+		if name == "" {
+			return nil
+		}
+		created, err := createEntry(m.currentPath, name)
+		if err != nil {
+			m.err = fmt.Errorf("create failed: %w", err)
+			return nil
+		}
+		m.err = nil
+		m.searchInput.Reset() // So the new entry is listed
+		m.loadFiles()
+		m.selectEntry(created)
+		return notify("Created " + name)
+	case tea.KeyEsc:
+		m.createMode = false
+		m.createInput.Blur()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.createInput, cmd = m.createInput.Update(msg)
+	return cmd
+}
+
+// createEntry creates name under dir: a folder when name ends in a
+// separator, otherwise an empty file. Nested names create the folders
+// above them. It returns the entry of dir that now holds the result.
+func createEntry(dir, name string) (string, error) {
+	isDir := strings.HasSuffix(name, "/") || strings.HasSuffix(name, `\`)
+	rel := filepath.Clean(name)
+	if filepath.IsAbs(rel) || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("the name must stay inside the current folder")
+	}
+	path := filepath.Join(dir, rel)
+
+	if isDir {
+		if _, err := os.Stat(path); err == nil {
+			return "", fmt.Errorf("%s already exists", name)
+		}
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return "", err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+		// O_EXCL: never truncate a file that is already there
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return "", err
+		}
+		f.Close()
+	}
+	return strings.Split(rel, string(filepath.Separator))[0], nil
+}
+
+// selectEntry moves the cursor onto the listed entry called name
+func (m *FileManagerModel) selectEntry(name string) {
+	for i, e := range m.filtered {
+		if e.Name() == name {
+			m.cursor = i
+			return
+		}
+	}
+}
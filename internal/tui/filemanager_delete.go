@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startDelete asks to confirm deleting the selected entry (Alt+D)
+func (m *FileManagerModel) startDelete() {
+	if len(m.filtered) == 0 {
+		return
+	}
+	selected := m.filtered[m.cursor]
+	m.selectedForDelete = selected.Name()
+	m.deleteIsDir = false
+	if info, err := os.Lstat(m.entryPath(selected)); err == nil {
+		m.deleteIsDir = info.IsDir() // Search results do not know
+	}
+	m.deleteMode = true
+}
+
+// updateDelete answers the delete prompt: y deletes, any other key cancels
+func (m *FileManagerModel) updateDelete(msg tea.KeyMsg) tea.Cmd {
+	m.deleteMode = false
+	if strings.ToLower(msg.String()) != "y" {
+		return nil
+	}
+
+	name := m.selectedForDelete
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.currentPath, name)
+	}
+	info, err := os.Lstat(path)
+	if err == nil {
+		if info.IsDir() {
+			err = os.RemoveAll(path)
+		} else {
+			err = os.Remove(path) // A symlink goes, not what it points to
+		}
+	}
+	if err != nil {
+		m.err = fmt.Errorf("delete failed: %w", err)
+		return nil
+	}
+
+	m.err = nil
+	m.forgetPath(name)
+	if path != name {
+		m.forgetPath(path) // The global index holds absolute paths
+	}
+	m.loadFiles()
+	m.cursor = min(m.cursor, max(len(m.filtered)-1, 0))
+	return notify("Deleted " + filepath.Base(path))
+}
+
+// forgetPath drops a deleted entry, and anything under it, from the search
+// index so it stops showing up in results
+func (m *FileManagerModel) forgetPath(name string) {
+	prefix := name + string(filepath.Separator)
+	kept := m.allFilePaths[:0]
+	for _, p := range m.allFilePaths {
+		if p != name && !strings.HasPrefix(p, prefix) {
+			kept = append(kept, p)
+		}
+	}
+	m.allFilePaths = kept
+}
+
+// deletePrompt is the footer shown while a delete waits for confirmation
+func (m FileManagerModel) deletePrompt() string {
+	what := "file"
+	if m.deleteIsDir {
+		what = "folder and everything in it"
+	}
+	return fmt.Sprintf("Delete '%s' (%s)? [y/N]", m.selectedForDelete, what)
+}
@@ -0,0 +1,272 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// grepExcludeKey lists file and folder names content search skips, on top
+// of .gitignore. Unset, defaultGrepExclude applies.
+const grepExcludeKey = "filemanager.grep_exclude"
+
+var defaultGrepExclude = []string{".git", "node_modules", ".venv", "venv", "__pycache__"}
+
+// maxGrepResults stops a search once this many lines matched
+const maxGrepResults = 5000
+
+var (
+	grepPathStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#BD93F9"))
+	grepLineStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
+	grepMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F1FA8C")).Bold(true)
+)
+
+var grepKeyHints = []keyHint{
+	{"Enter", "Open at Line"},
+	{"↑/↓", "Move"},
+	{"Alt+R", "Regex"},
+	{"Esc", "Clear/Back"},
+	{"Alt+F", "File Search"},
+}
+
+type grepDebounceMsg struct {
+	id int
+}
+
+// grepResultMsg carries a batch of matches of search id
+type grepResultMsg struct {
+	id      int
+	matches []utils.GrepMatch
+	done    bool
+	ch      chan utils.GrepMatch
+}
+
+func newGrepInput() textinput.Model {
+	gi := textinput.New()
+	gi.Placeholder = "Search file contents..."
+	gi.CharLimit = 256
+	gi.Width = 60
+	gi.Prompt = "grep> "
+	gi.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
+	return gi
+}
+
+func grepExcludes() []string {
+	if names := config.GetStringSlice(grepExcludeKey); len(names) > 0 {
+		return names
+	}
+	return defaultGrepExclude
+}
+
+func waitForGrep(id int, ch chan utils.GrepMatch) tea.Cmd {
+	return func() tea.Msg {
+		matches, open := collectBatch(ch)
+		return grepResultMsg{id: id, matches: matches, done: !open, ch: ch}
+	}
+}
+
+// toggleGrep switches between file name search and content search (Alt+F)
+func (m *FileManagerModel) toggleGrep() tea.Cmd {
+	m.grepMode = !m.grepMode
+	if !m.grepMode {
+		m.stopGrep()
+		m.grepInput.Blur()
+		m.searchInput.Focus()
+		return nil
+	}
+	m.searchInput.Blur()
+	m.grepInput.Focus()
+	if m.grepRoot != m.currentPath {
+		return tea.Batch(textinput.Blink, m.startGrep()) // The folder changed since the last search
+	}
+	return textinput.Blink
+}
+
+func (m *FileManagerModel) stopGrep() {
+	if m.grepCancel != nil {
+		m.grepCancel()
+		m.grepCancel = nil
+	}
+	m.grepRunning = false
+}
+
+// startGrep cancels the running search and searches the current folder
+// for the query. Results stream in as grepResultMsg.
+func (m *FileManagerModel) startGrep() tea.Cmd {
+	m.stopGrep()
+	m.grepID++
+	m.grepMatches, m.grepCursor = nil, 0
+	m.grepErr, m.grepTruncated = nil, false
+	m.grepRoot = m.currentPath
+
+	query := m.grepInput.Value()
+	if query == "" {
+		m.grepRe = nil
+		return nil
+	}
+	re, err := utils.CompileGrepQuery(query, m.grepRegex)
+	if err != nil {
+		m.grepRe, m.grepErr = nil, err
+		return nil
+	}
+	m.grepRe = re
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan utils.GrepMatch, 1000)
+	go utils.Grep(ctx, m.grepRoot, re, utils.GrepOptions{Exclude: grepExcludes()}, ch)
+
+	cmds := []tea.Cmd{waitForGrep(m.grepID, ch)}
+	if !m.indexing && !m.loading && !m.grepRunning {
+		cmds = append(cmds, m.spinner.Tick)
+	}
+	m.grepCancel, m.grepRunning = cancel, true
+	return tea.Batch(cmds...)
+}
+
+// addGrepResults appends a batch of the current search, stopping it at
+// maxGrepResults
+func (m *FileManagerModel) addGrepResults(msg grepResultMsg) tea.Cmd {
+	if msg.id != m.grepID {
+		return nil // From a search that was replaced
+	}
+	if room := maxGrepResults - len(m.grepMatches); len(msg.matches) >= room {
+		m.grepMatches = append(m.grepMatches, msg.matches[:room]...)
+		m.grepTruncated = !msg.done || len(msg.matches) > room
+		m.stopGrep()
+		return nil
+	}
+	m.grepMatches = append(m.grepMatches, msg.matches...)
+	if msg.done {
+		m.stopGrep()
+		return nil
+	}
+	return waitForGrep(msg.id, msg.ch)
+}
+
+// updateGrep handles keys in content search mode
+func (m FileManagerModel) updateGrep(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.stopGrep()
+		m.quitting = true
+		return m, tea.Quit
+	case "alt+f", "ctrl+g":
+		return m, m.toggleGrep()
+	case "esc":
+		if m.grepInput.Value() == "" {
+			return m, m.toggleGrep()
+		}
+		m.grepInput.Reset()
+		return m, m.startGrep()
+	case "alt+r":
+		m.grepRegex = !m.grepRegex
+		return m, m.startGrep()
+	case "up":
+		if m.grepCursor > 0 {
+			m.grepCursor--
+		}
+		return m, nil
+	case "down":
+		if m.grepCursor < len(m.grepMatches)-1 {
+			m.grepCursor++
+		}
+		return m, nil
+	case "pgup":
+		m.grepCursor = max(m.grepCursor-10, 0)
+		return m, nil
+	case "pgdown":
+		m.grepCursor = max(min(m.grepCursor+10, len(m.grepMatches)-1), 0)
+		return m, nil
+	case "enter":
+		if len(m.grepMatches) == 0 {
+			return m, nil
+		}
+		match := m.grepMatches[m.grepCursor]
+		target := editorTarget{path: filepath.Join(m.grepRoot, filepath.FromSlash(match.Path)), line: match.Line, col: match.Col}
+		m.stopGrep()
+		m.selectedFile = target.path
+		return m, func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: target} }
+	}
+
+	oldValue := m.grepInput.Value()
+	var cmd tea.Cmd
+	m.grepInput, cmd = m.grepInput.Update(msg)
+	if m.grepInput.Value() == oldValue {
+		return m, cmd
+	}
+	// Reading files is costlier than filtering names, so wait a bit longer
+	m.grepID++
+	id := m.grepID
+	return m, tea.Batch(cmd, tea.Tick(300*time.Millisecond, func(_ time.Time) tea.Msg {
+		return grepDebounceMsg{id: id}
+	}))
+}
+
+// grepView lists the matches as path:line: text
+func (m FileManagerModel) grepView(w, h int) string {
+	header := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#FFB86C")).
+		Padding(0, 1).
+		Width(w - 4)
+
+	mode := "literal"
+	if m.grepRegex {
+		mode = "regex"
+	}
+	status := fmt.Sprintf("  %d matches (%s)", len(m.grepMatches), mode)
+	if m.grepRunning {
+		status = fmt.Sprintf("  %s %d matches so far (%s)", m.spinner.View(), len(m.grepMatches), mode)
+	} else if m.grepTruncated {
+		status = fmt.Sprintf("  stopped at %d matches (%s), refine the query", maxGrepResults, mode)
+	}
+	searchBar := header.Render(m.grepInput.View() + subtleStyle.Render(status))
+
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	info := infoStyle.Render("  in " + m.currentPath)
+	if m.grepErr != nil {
+		info = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("  " + m.grepErr.Error())
+	}
+	footer := lipgloss.JoinVertical(lipgloss.Left, info, lipgloss.NewStyle().MaxWidth(w).Render(renderKeyFooter(0, grepKeyHints)))
+
+	listHeight := max(h-lipgloss.Height(searchBar)-lipgloss.Height(footer), 0)
+	start := 0
+	if m.grepCursor >= listHeight {
+		start = m.grepCursor - listHeight + 1
+	}
+	end := min(len(m.grepMatches), start+listHeight)
+
+	var list strings.Builder
+	if len(m.grepMatches) == 0 && !m.grepRunning && m.grepInput.Value() != "" && m.grepErr == nil {
+		list.WriteString("\n  (No matches found)")
+	}
+	rowStyle := lipgloss.NewStyle().MaxWidth(w - 2)
+	for i := start; i < end; i++ {
+		match := m.grepMatches[i]
+		row := fmt.Sprintf(" %s:%s: %s", grepPathStyle.Render(match.Path), grepLineStyle.Render(fmt.Sprint(match.Line)), highlightGrep(m.grepRe, strings.TrimSpace(match.Text)))
+		if i == m.grepCursor {
+			row = lipgloss.NewStyle().Background(lipgloss.Color("#5A4E8C")).Width(w - 2).Render(row)
+		}
+		list.WriteString(rowStyle.Render(row) + "\n")
+	}
+
+	body := lipgloss.NewStyle().Height(listHeight).Render(list.String())
+	return lipgloss.JoinVertical(lipgloss.Left, searchBar, body, footer)
+}
+
+// highlightGrep marks the matches of re in text
+func highlightGrep(re *regexp.Regexp, text string) string {
+	if re == nil {
+		return text
+	}
+	return re.ReplaceAllStringFunc(text, func(s string) string { return grepMatchStyle.Render(s) })
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// showHiddenKey keeps the File Manager's hidden files toggle (Alt+.)
+// between sessions. Hidden files are shown unless it is "false".
+const showHiddenKey = "filemanager.show_hidden"
+
+func loadShowHidden() bool {
+	return !strings.EqualFold(strings.TrimSpace(config.GetString(showHiddenKey)), "false")
+}
+
+// toggleHidden shows or hides hidden entries and saves the choice
+func (m *FileManagerModel) toggleHidden() {
+	m.showHidden = !m.showHidden
+	if err := config.SaveConfig(showHiddenKey, m.showHidden); err != nil {
+		m.err = err
+	}
+	m.loadFiles()
+	m.cursor = 0
+}
+
+// isHidden reports whether a folder entry is hidden: its name starts with
+// a dot, or (on Windows) it has the hidden attribute
+func isHidden(e fs.DirEntry) bool {
+	if strings.HasPrefix(e.Name(), ".") {
+		return true
+	}
+	if runtime.GOOS != "windows" {
+		return false // Spare the stat
+	}
+	info, err := e.Info()
+	return err == nil && hasHiddenAttr(info)
+}
+
+// inHiddenPath reports whether a search result lies in, or is, a dotfile.
+// Results are not stat'ed, so the Windows attribute is not checked here.
+func inHiddenPath(path string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if len(part) > 1 && part[0] == '.' && part != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutHidden drops hidden entries from a folder listing
+func withoutHidden(entries []fs.DirEntry) []fs.DirEntry {
+	kept := entries[:0]
+	for _, e := range entries {
+		if !isHidden(e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
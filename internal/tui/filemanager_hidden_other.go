@@ -0,0 +1,10 @@
+//go:build !windows
+
+package tui
+
+import "io/fs"
+
+// hasHiddenAttr is always false: only dotfiles are hidden outside Windows
+func hasHiddenAttr(info fs.FileInfo) bool {
+	return false
+}
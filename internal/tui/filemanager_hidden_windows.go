@@ -0,0 +1,14 @@
+//go:build windows
+
+package tui
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// hasHiddenAttr reports whether the file has the Windows hidden attribute
+func hasHiddenAttr(info fs.FileInfo) bool {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	return ok && data.FileAttributes&syscall.FILE_ATTRIBUTE_HIDDEN != 0
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/project"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// defaultMaxIndex is the default for filemanager.max_index. A million paths
+// take roughly 150 MB.
+const defaultMaxIndex = 1_000_000
+
+// Config keys for what the File Manager's scans leave out. Unset,
+// defaultScanExclude applies and .gitignore files are honoured.
+const (
+	scanExcludeKey   = "filemanager.scan_exclude"
+	scanGitignoreKey = "filemanager.scan_gitignore"
+)
+
+var defaultScanExclude = []string{".git", "node_modules", ".venv", "venv", "__pycache__", "target", "dist", "build"}
+
+// scanOptions is what the global scan and the local index skip
+func scanOptions() utils.WalkOptions {
+	exclude := config.GetStringSlice(scanExcludeKey)
+	if len(exclude) == 0 {
+		exclude = defaultScanExclude
+	}
+	return utils.WalkOptions{
+		Exclude:   exclude,
+		Gitignore: !strings.EqualFold(strings.TrimSpace(config.GetString(scanGitignoreKey)), "false"),
+	}
+}
+
+// maxIndex is the most paths the File Manager keeps for global search
+func maxIndex() int {
+	n, err := strconv.Atoi(strings.TrimSpace(config.GetString("filemanager.max_index")))
+	if err != nil || n <= 0 {
+		return defaultMaxIndex
+	}
+	return n
+}
+
+// globalIndexRoots lists what global search indexes, most relevant first:
+// imported projects, ~/Projects, the home folder, then the drives
+func globalIndexRoots() []string {
+	roots := project.ImportedPaths()
+	if home, err := os.UserHomeDir(); err == nil {
+		roots = append(roots, filepath.Join(home, "Projects"), filepath.Join(home, "projects"), home)
+	}
+	return append(roots, getDrives()...)
+}
+
+// localIndexMsg carries a batch of paths (relative to indexRoot) from the
+// background index of the folder the File Manager opened in
+type localIndexMsg struct {
+	paths []string
+	done  bool
+}
+
+// startLocalIndexCmd walks root in the background so local search works
+// without blocking the first frame on deep directories. The walk stops
+// after limit paths, like the global index.
+func startLocalIndexCmd(root string, ch chan string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		go func() {
+			defer close(ch)
+			root = filepath.Clean(root)
+			// The root itself is not listed, and one path past the limit
+			// lets addIndexed mark the index truncated
+			utils.WalkLimitedContext(context.Background(), []string{root}, limit+2, scanOptions(), func(path string) {
+				if path != root {
+					rel, _ := filepath.Rel(root, path)
+					ch <- rel
+				}
+			})
+		}()
+		return waitForLocalIndex(ch)()
+	}
+}
+
+func waitForLocalIndex(ch chan string) tea.Cmd {
+	return func() tea.Msg {
+		paths, open := collectBatch(ch)
+		return localIndexMsg{paths: paths, done: !open}
+	}
+}
+
+// collectBatch blocks for the first item, then gathers more for a short
+// while so the UI is updated in chunks. open is false once ch is drained.
+func collectBatch[T any](ch chan T) (batch []T, open bool) {
+	const maxBatch = 5000
+	const batchTimeout = 200 * time.Millisecond
+
+	item, ok := <-ch
+	if !ok {
+		return nil, false
+	}
+	batch = append(batch, item)
+
+	timer := time.NewTimer(batchTimeout)
+	defer timer.Stop()
+	for len(batch) < maxBatch {
+		select {
+		case p, ok := <-ch:
+			if !ok {
+				return batch, false
+			}
+			batch = append(batch, p)
+		case <-timer.C:
+			return batch, true
+		}
+	}
+	return batch, true
+}
+
+// addIndexed appends newly indexed paths, up to indexLimit, and extends the
+// current results with a cheap substring match (fuzzy is too slow per batch)
+func (m *FileManagerModel) addIndexed(paths []string) {
+	if room := max(m.indexLimit-len(m.allFilePaths), 0); len(paths) > room {
+		paths = paths[:room]
+		m.indexTruncated = true
+	}
+	m.allFilePaths = append(m.allFilePaths, paths...)
+	if m.searchInput.Value() == "" {
+		return
+	}
+	query := strings.ToLower(m.searchInput.Value())
+	category := m.activeCategory()
+	for _, p := range paths {
+		if category.matches(p) && strings.Contains(strings.ToLower(p), query) {
+			m.filtered = append(m.filtered, dummyEntry{path: p})
+		}
+	}
+}
+
+// prepareScan sets up a new global scan, which Init or toggleScan starts
+func (m *FileManagerModel) prepareScan() {
+	if m.scanCancel != nil {
+		m.scanCancel() // Releases the previous scan's context
+	}
+	m.scanCtx, m.scanCancel = context.WithCancel(context.Background())
+	m.scanChan = make(chan string, 1000)
+	m.scanStats = new(utils.WalkStats)
+	m.scanDone, m.scanIndexed, m.scanPaths = false, 0, nil
+}
+
+// toggleScan handles Ctrl+R: it stops a running global scan, or rebuilds
+// the global index from scratch once the last scan has ended
+func (m *FileManagerModel) toggleScan() tea.Cmd {
+	if !m.scanDone {
+		m.scanCancel() // The walk stops and scanFinishedMsg reports it
+		return nil
+	}
+
+	// The current global index stays searchable until the new one is done
+	_, global := splitIndex(m.allFilePaths)
+	m.scanRefresh = len(global) > 0
+	m.prepareScan()
+	m.loading = true
+	return startGlobalScanCmd(m.scanCtx, m.scanChan, m.indexLimit, m.scanStats)
+}
+
+// scanSummary is the status bar note on the global scan
+func (m FileManagerModel) scanSummary() string {
+	switch {
+	case !m.scanDone && m.scanRefresh:
+		return fmt.Sprintf("Scan: refreshing the index, %d files (Ctrl+R: stop)", m.scanIndexed)
+	case !m.scanDone:
+		return fmt.Sprintf("Scan: running, %d files (Ctrl+R: stop)", m.scanIndexed)
+	case m.scanStats.Cancelled && m.scanRefresh:
+		return "Scan: cancelled, the previous index is kept (Ctrl+R: rescan)"
+	case m.scanStats.Cancelled:
+		return fmt.Sprintf("Scan: cancelled after %d files (Ctrl+R: rescan)", m.scanIndexed)
+	case !m.indexCached.IsZero():
+		return fmt.Sprintf("Index: cached at %s (Ctrl+R: rescan)", m.indexCached.Format("2006-01-02 15:04"))
+	}
+	st := m.scanStats
+	summary := fmt.Sprintf("Scan: %d folders in %s", len(st.Roots), st.Duration.Round(100*time.Millisecond))
+	if st.Skipped > 0 {
+		summary += fmt.Sprintf(", %d unreadable skipped", st.Skipped)
+	}
+	return summary + " (? for details)"
+}
+
+// showScanResult updates the search placeholder and the help screen's
+// scan report once the global index is complete (or loaded from cache)
+func (m *FileManagerModel) showScanResult() {
+	m.helpView.SetContent(renderFileManagerHelp(keymapHelp(m.keys) + scanReport(*m.scanStats, len(m.allFilePaths), m.indexTruncated)))
+	m.searchInput.Placeholder = fmt.Sprintf("Search %d files across all drives...", len(m.allFilePaths))
+	if m.indexTruncated {
+		m.searchInput.Placeholder = fmt.Sprintf("Search %d indexed files (index truncated)...", len(m.allFilePaths))
+	}
+	if m.scanStats.Cancelled {
+		m.searchInput.Placeholder = fmt.Sprintf("Search %d indexed files (scan stopped, Ctrl+R to rescan)...", len(m.allFilePaths))
+	}
+}
+
+// scanReport is the help screen section on the last global scan
+func scanReport(st utils.WalkStats, indexed int, truncated bool) string {
+	var b strings.Builder
+	b.WriteString("\n## Last Global Scan\n")
+	fmt.Fprintf(&b, "- **Indexed**: %d paths in %s\n", indexed, st.Duration.Round(100*time.Millisecond))
+	if st.Cancelled {
+		b.WriteString("- **Incomplete**: the scan was stopped with Ctrl+R (press it again to rescan)\n")
+	}
+	if truncated {
+		fmt.Fprintf(&b, "- **Incomplete**: the index is full (filemanager.max_index is %d), so later folders are missing from global search\n", maxIndex())
+	}
+	fmt.Fprintf(&b, "- **Folders walked** (%d):\n", len(st.Roots))
+	for _, root := range st.Roots {
+		fmt.Fprintf(&b, "  - %s\n", root)
+	}
+	if st.Skipped == 0 {
+		b.WriteString("- **Skipped**: none, every folder could be read\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "- **Skipped**: %d folders could not be read (usually missing permissions); files below them are not searchable:\n", st.Skipped)
+	for _, dir := range st.Examples {
+		fmt.Fprintf(&b, "  - %s\n", dir)
+	}
+	if more := st.Skipped - len(st.Examples); more > 0 {
+		fmt.Fprintf(&b, "  - ...and %d more\n", more)
+	}
+	return b.String()
+}
+
+// renderFileManagerHelp renders the help markdown with extra appended
+func renderFileManagerHelp(extra string) string {
+	renderer, _ := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(80),
+	)
+	out, err := renderer.Render(FileManagerHelp + extra)
+	if err != nil {
+		return FileManagerHelp + extra
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// systemExtensions open with the OS default application on Enter or a
+// click unless editor.open_extensions says otherwise: documents, images and
+// media the editor cannot show usefully
+var systemExtensions = newExtSet([]string{
+	"pdf", "doc", "docx", "xls", "xlsx", "ppt", "pptx", "odt", "ods", "odp", "rtf", "epub",
+	"png", "jpg", "jpeg", "gif", "bmp", "webp", "ico", "tif", "tiff", "heic", "psd",
+	"mp3", "wav", "flac", "ogg", "m4a", "mp4", "mkv", "mov", "avi", "webm",
+})
+
+// openExternally hands path to the OS default application (Alt+X, or
+// Enter on a document). When no application can be started, the file
+// opens inside DevCLI instead if fallback is set.
+func (m *FileManagerModel) openExternally(path string, fallback bool) tea.Cmd {
+	if err := utils.OpenFile(path); err != nil {
+		m.err = fmt.Errorf("no application to open %s: %w", filepath.Base(path), err)
+		if !fallback {
+			return nil
+		}
+		if isBinaryFile(path) {
+			m.openHexView(path)
+			return nil
+		}
+		m.selectedFile = path
+		return func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: path} }
+	}
+	m.err = nil
+	return notify("Opened " + filepath.Base(path) + " with the default application")
+}
+
+// openSelected opens an entry picked with Enter or a click: folders are
+// entered, documents go to the OS, binaries to the hex viewer and
+// everything else to the editor
+func (m *FileManagerModel) openSelected(selected fs.DirEntry) tea.Cmd {
+	fullPath := m.entryPath(selected)
+
+	// Stat as well, since search results (dummyEntry) report false for IsDir()
+	if info, err := os.Stat(fullPath); (err == nil && info.IsDir()) || selected.IsDir() {
+		m.history = append(m.history, m.currentPath)
+		m.pathInput.SetValue(fullPath)
+		m.currentPath = fullPath
+
+		// Show the folder's contents, not results of the old search
+		m.searchInput.Reset()
+		m.globalSearch = false
+
+		m.loadFiles()
+		m.cursor = 0
+		return nil
+	}
+	switch {
+	case !opensInEditor(fullPath):
+		// Documents and media, or configured to open with the OS
+		// (editor.external_extensions / editor.open_extensions)
+		return m.openExternally(fullPath, true)
+	case isBinaryFile(fullPath):
+		m.openHexView(fullPath)
+		return nil
+	}
+	m.selectedFile = fullPath
+	return func() tea.Msg { return SwitchViewMsg{TargetState: StateEditor, Args: fullPath} }
+}
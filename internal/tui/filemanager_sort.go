@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fileSort orders the listing; folders always come first
+type fileSort int
+
+const (
+	sortByName fileSort = iota
+	sortBySize
+	sortByModified
+	sortByExtension
+	numFileSorts
+)
+
+func (s fileSort) String() string {
+	return [...]string{"name", "size", "modified", "extension"}[s]
+}
+
+// entryMeta is the size and modification time shown next to an entry
+type entryMeta struct {
+	size    int64
+	modTime time.Time
+	ok      bool // Stat failed, e.g. the file is gone
+}
+
+// entryMeta stats an entry once. Search results only carry a path, so
+// they are stat'ed lazily as they are shown or sorted.
+func (m FileManagerModel) entryMeta(e fs.DirEntry) entryMeta {
+	path := m.entryPath(e)
+	if meta, ok := m.metaCache[path]; ok {
+		return meta
+	}
+	var info fs.FileInfo
+	var err error
+	if _, isSearch := e.(dummyEntry); isSearch {
+		info, err = os.Stat(path)
+	} else {
+		info, err = e.Info()
+	}
+	meta := entryMeta{}
+	if err == nil {
+		meta = entryMeta{size: info.Size(), modTime: info.ModTime(), ok: true}
+	}
+	m.metaCache[path] = meta
+	return meta
+}
+
+// sortEntries orders entries by the current mode, folders first. Size and
+// time sort largest and newest first.
+func (m FileManagerModel) sortEntries(entries []fs.DirEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.IsDir() != b.IsDir() {
+			return a.IsDir()
+		}
+		switch m.sortMode {
+		case sortBySize:
+			if sa, sb := m.entryMeta(a).size, m.entryMeta(b).size; sa != sb {
+				return sa > sb
+			}
+		case sortByModified:
+			if ta, tb := m.entryMeta(a).modTime, m.entryMeta(b).modTime; !ta.Equal(tb) {
+				return ta.After(tb)
+			}
+		case sortByExtension:
+			if ea, eb := strings.ToLower(filepath.Ext(a.Name())), strings.ToLower(filepath.Ext(b.Name())); ea != eb {
+				return ea < eb
+			}
+		}
+		return a.Name() < b.Name()
+	})
+}
+
+// sortResults sorts search results, which otherwise stay in match order,
+// when a mode other than name is picked
+func (m FileManagerModel) sortResults(results []fs.DirEntry) {
+	if m.sortMode != sortByName {
+		m.sortEntries(results)
+	}
+}
+
+// cycleSort switches to the next sort mode (Alt+O) and re-sorts the list
+func (m *FileManagerModel) cycleSort() {
+	m.sortMode = (m.sortMode + 1) % numFileSorts
+	m.sortEntries(m.files)
+	if m.searchInput.Value() == "" {
+		m.filterFiles("")
+	} else {
+		m.sortResults(m.filtered)
+	}
+	m.cursor = 0
+}
+
+// entryColumn is the right-hand size and modification time of an entry
+func (m FileManagerModel) entryColumn(e fs.DirEntry) string {
+	meta := m.entryMeta(e)
+	if !meta.ok {
+		return ""
+	}
+	size := formatBytes(meta.size)
+	if e.IsDir() {
+		size = "-"
+	}
+	return fmt.Sprintf("%10s  %s", size, meta.modTime.Format("2006-01-02 15:04"))
+}
+
+// withColumn right-aligns column after row within width, dropping it when
+// the name leaves no room
+func withColumn(row, column string, width int) string {
+	if column == "" {
+		return row
+	}
+	gap := width - lipgloss.Width(row) - lipgloss.Width(column) - 1
+	if gap < 2 {
+		return row
+	}
+	return row + strings.Repeat(" ", gap) + column
+}
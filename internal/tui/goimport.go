@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// copyGoImportPath copies the import path of the Go package containing
+// path to the clipboard and returns it
+func copyGoImportPath(path string) (string, error) {
+	importPath, err := utils.GoImportPath(path)
+	if err != nil {
+		return "", err
+	}
+	if err := clipboard.WriteAll(importPath); err != nil {
+		return importPath, fmt.Errorf("clipboard unavailable (%v); import path is %s", err, importPath)
+	}
+	return importPath, nil
+}
+
+// copyImportPath handles Alt+G in the editor
+func (m *model) copyImportPath() tea.Cmd {
+	if m.filename == "" {
+		m.status = "Save the file first: the import path comes from its location"
+		return nil
+	}
+	importPath, err := copyGoImportPath(m.filename)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	return notify("Copied import path: " + importPath)
+}
+
+// copyImportPath handles Alt+G in the File Manager for the selected entry
+func (m *FileManagerModel) copyImportPath() tea.Cmd {
+	if len(m.filtered) == 0 {
+		return nil
+	}
+	importPath, err := copyGoImportPath(m.entryPath(m.filtered[m.cursor]))
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	return notify("Copied import path: " + importPath)
+}
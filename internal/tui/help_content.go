@@ -20,6 +20,11 @@ Project Tools helps you create, manage, and maintain software projects with pre-
 | **Up/Down** | Navigate through lists |
 | **Enter** | Select / Confirm action |
 | **b** | Backup selected project (in project list) |
+| **i** | Import an existing project folder (in project list) |
+| **/** | Filter projects by name or stack (in project list) |
+| **d** | Duplicate the selected project (in project list) |
+| **r** | Refresh git branch and changes (in project list) |
+| **t** | Open a terminal in the selected project (in project list) |
 | **d** | Delete history entry (in history view) |
 
 ## HOW TO USE
@@ -32,6 +37,26 @@ Project Tools helps you create, manage, and maintain software projects with pre-
 - Specify parent directory path
 - Wait for automated setup and dependency installation
 
+With many projects, press **'/'** in the project list and type part of a
+name or stack (e.g. "react", "api"); matching is fuzzy. **"+ New Project"**
+always stays at the top. **Esc** clears the filter.
+
+To start from an existing project, select it and press **'d'**. Enter a name
+(the copy goes next to the original) or a full path; existing folders are never
+overwritten. .git, node_modules, .venv, venv and __pycache__ are left out (set
+"projects.duplicate_exclude" in config.yaml to change the list). You can start a
+fresh git repository and run the install command, and the copy is added to history.
+
+Projects that are git repositories show their branch and whether the tree
+is clean, e.g. "git: main (3 changed)". The status is read once and cached;
+press **'r'** in the project list to read it again.
+
+Already started a project by hand? Press **'i'** in the project list and
+enter its folder (the current directory is suggested). DevCLI detects the
+stack, dev server command and any virtual environment, records it in
+history, and lists it alongside your other projects. Nothing in the folder
+is created or changed.
+
 ### 2. PROJECT TEMPLATES
 Available templates include:
 - **Go Web Server** - Basic HTTP server with routing
@@ -40,6 +65,11 @@ Available templates include:
 - **Node.js Express** - JavaScript web server
 - And more...
 
+In the template list:
+- Press **'p'** to pin a template to the top (★)
+- Press **'x'** to hide a template you rarely use
+- Press **'a'** to show all templates, including hidden ones
+
 ### 3. PROJECT BACKUP
 - Navigate to project list
 - Select the project you want to backup
@@ -151,12 +181,24 @@ Key         Description
 ?           Show this help
 Esc/q       Go back to main menu
 s           Start/Stop server
+R           Restart the server (stops it fully, then starts it again)
+e           Toggle .env loading (before starting)
+c           Edit the server command (before starting; while running: clear logs)
+v           Set PORT and other environment variables (before starting)
 f           Toggle log filters
 b           Toggle backend/frontend (Full-stack projects)
+t           Toggle one tab per server (multi-server projects)
+Tab         Next server tab (Shift+Tab: previous)
+o           Open the server's local URL in the browser
 /           Search logs
 a           Toggle auto-scroll
-c           Clear logs
-Up/Down     Scroll through logs
+c           Clear logs (while running)
+w           Save the shown log lines to devserver-<date>-<time>.log
+Up/Down     Scroll through logs (ready screen: pick a Makefile target)
+
+While a server runs only s (stop) and Esc (stop and go back) ask
+"y/n" first; the other keys act at once. Set
+"devserver.confirm_actions: true" in config.yaml to confirm every key.
 
 DO (ACTIONS)
 
@@ -165,11 +207,35 @@ DO (ACTIONS)
      - package.json (Node.js/React)
      - go.mod (Go projects)
      - requirements.txt (Python/Flask)
+     - hugo.toml, _config.yml + Gemfile, .eleventy.js (static sites)
+     - Makefile (projects no framework marker matches)
      - Detects full-stack setups automatically
+   • For a Makefile the first dev, serve, start or run target is used;
+     Up/Down picks another target. Without one of those, the targets
+     are listed and you pick the one to run before pressing 's'
+   • If the server's command (hugo, bundle, npx, ...) is not installed,
+     the start screen says how to install it
+   • Wrong guess? Press 'c' to edit the command (e.g. pnpm dev, or add
+     a --port flag). It is remembered for that project folder and marked
+     "(custom)"; clear the line to go back to the detected command.
+     Projects with several servers ask for each command in turn.
+   • Press 'v' to give a server environment variables, e.g.
+     PORT=4000 API_URL=http://localhost:9000. They override your shell
+     and .env and are remembered per project folder; clear the line to
+     remove them. Most Node frameworks honour PORT; for others add a
+     port flag to the command with 'c'. The running view shows the port.
 
 2. START SERVER
    • Press 's' to start detected server
+   • A .env file in the server's folder is loaded into its environment
+     (quotes, comments and "export" are understood). Variables already
+     set in your shell take precedence. Press 'e' to turn this off.
    • Logs appear in real-time
+   • The local URL the server announces (e.g. http://localhost:1313/)
+     is shown next to the status; press 'o' to open it
+   • Press 'w' to save the log lines on screen (after the filter,
+     source/tab and search) with their times and server names to
+     devserver-<date>-<time>.log in the project folder
    • Color-coded by severity:
      - Green: Success messages
      - Yellow: Warnings
@@ -190,6 +256,11 @@ DO (ACTIONS)
    • Press '/' to search for specific errors or terms
    • Highlighted terms show in yellow/black
 
+   Tabbed view (projects with several servers):
+   • Press 't' to give each server its own tab; press again to merge
+   • Tab / Shift+Tab switch servers; each tab keeps its own
+     scroll position and filter ('f' changes the current tab only)
+
 5. TROUBLESHOOTING
    • "Port already in use": Stop other running servers
    • "Command not found": Ensure dependencies are installed (npm install, pip install)
@@ -198,9 +269,16 @@ DO (ACTIONS)
 SUPPORTED FRAMEWORKS
 • Node.js (npm start, npm run dev)
 • React (npm start, vite)
+• Next.js, Nuxt, SvelteKit (npm run dev)
+• Vue (npm run dev; npm run serve for Vue CLI)
+• Angular (npm start, which runs ng serve)
 • Python Flask (flask run, python app.py)
 • Go (go run main.go)
 • Express.js (node server.js)
+• Hugo (hugo server)
+• Jekyll (bundle exec jekyll serve)
+• Eleventy (npx @11ty/eleventy --serve)
+• Makefile (make dev, make serve, make start, make run)
 
 Press Esc to close this help`
 
@@ -220,7 +298,18 @@ A powerful keyboard-driven file explorer with fuzzy search across all drives, mo
 | **Tab** | Toggle global/local search mode |
 | **Alt+M** | Move/Rename selected file |
 | **Alt+C** | Copy selected file |
+| **Alt+D** | Delete selected file or folder (asks first) |
+| **Alt+N** | Create a file, or a folder when the name ends in / |
+| **Ctrl+R** | Stop the global scan, or rescan once it has ended |
 | **Alt+E** | Edit selected file |
+| **Alt+X** | Open with the system's default application |
+| **Alt+T** | Cycle file type category (All, Code, Images, ...) |
+| **Alt+O** | Cycle sort order (name, size, modified, extension) |
+| **Alt+.** | Show or hide hidden files |
+| **Alt+F / Ctrl+G** | Search file contents in the current folder (grep) |
+| **Alt+Up/Alt+Down** | Recall previous searches |
+| **Alt+G** | Copy the Go import path of the selected file or folder |
+| **Alt+S** | Open a terminal in the current folder |
 | **Backspace** | Go up one directory (when search empty) |
 | **Ctrl+L** | Customizable path search |
 
@@ -239,14 +328,60 @@ A powerful keyboard-driven file explorer with fuzzy search across all drives, mo
 ### 2. Global vs Local Search
 - **Tab** toggles between modes.
 - **Global Search**: Searches ALL indexed drives instantly.
+  Imported projects, ~/Projects and your home folder are indexed before the drives.
+  Folders named in "filemanager.scan_exclude" (default: .git, node_modules, .venv, venv,
+  __pycache__, target, dist, build) and paths ignored by a .gitignore are left out of the
+  index; set "filemanager.scan_gitignore: false" to index ignored paths. Press Ctrl+R after
+  changing either, as a cached index keeps the old contents.
+  The index holds at most "filemanager.max_index" paths (default 1000000); when it is
+  full the search bar shows "index truncated" and results may be incomplete.
+  Once the scan is done the status bar sums it up (folders walked, time, unreadable folders
+  skipped) and a "Last Global Scan" section at the end of this help lists the details.
+  The finished index is cached (fileindex.gob in the DevCLI config folder), so later starts
+  can search at once; it is rescanned in the background when older than
+  "filemanager.index_max_age" (default 24h, "0" rescans on every start).
+  While a scan runs the status bar counts the files indexed; **Ctrl+R** stops it, keeping what
+  was indexed so far, and once it has ended **Ctrl+R** rebuilds the index from scratch.
 - **Local Search**: Searches only the current directory.
+- **Alt+Up/Alt+Down**: Step through recent searches (the last 20 queries you opened a result from). Set "filemanager.persist_search_history: true" in config.yaml to keep them between sessions.
 
 ### 3. File Operations
 - **Alt+M**: Move or rename files across drives.
 - **Alt+C**: Copy files to a new destination.
+- **Alt+D**: Delete the selected file or folder, after a y/N confirmation. Folders are removed with everything in them; a symlink is removed, not its target.
+- **Alt+N**: Create an empty file in the current folder. End the name with / to create a folder instead. Names with / inside create the folders above them (src/main.go makes src first); names that would leave the current folder and existing files are refused.
 - **Alt+E**: Open text files in the built-in editor.
-
-### 4. Drive Switching
+- **Alt+H**: Open the selected file in the hex viewer (offset | hex | ASCII). Binary files
+  open there on **Enter** too. Only the visible rows are read, so any size works:
+  arrows/PgUp/PgDn/Home/End page through it, **g** jumps to an offset (decimal or 0x...).
+- **Enter** (or a click) on a file opens it in the editor. Documents, images and media (PDF, Office files,
+  PNG/JPEG, MP3/MP4, ...) open in your OS default application instead, as do extensions listed in
+  "editor.external_extensions"; when "editor.open_extensions" is set only those extensions open
+  in the editor. If no application can be started (e.g. xdg-open is missing) the file opens in
+  the hex viewer or editor, and the status bar says why.
+- **Alt+X**: Open the selected file or folder with the OS default application (xdg-open, open,
+  or explorer), whatever its type.
+- **Alt+T**: Filter by file type category. Add your own under "file_categories" in the DevCLI config.yaml.
+- **Alt+O**: Cycle the sort order: name, size (largest first), modified (newest first), extension.
+  Folders stay on top. Each entry shows its size and modification time on the right; search results
+  keep their best-match order under name sort, and are sorted like the folder otherwise.
+- **Alt+.**: Show or hide hidden files: names starting with a dot and, on Windows, files with the
+  hidden attribute. Search results under a dot folder are hidden too. The choice is saved as
+  "filemanager.show_hidden" and the status bar shows it (Dotfiles: shown/hidden).
+- **Alt+G**: In a Go project, copies the selected file's package import path (the module line of the nearest go.mod plus the folder). Also available in the editor for the open file.
+
+### 4. Content Search (grep)
+- **Alt+F** (or **Ctrl+G**) switches the search bar to file contents: type text and every matching line
+  below the current folder is listed as path:line: text while the search runs.
+- The query is literal; **Alt+R** treats it as a regular expression instead. Queries without
+  upper-case letters ignore case.
+- **Up/Down** pick a match, **Enter** opens the file in the editor at that line.
+  **Esc** clears the query, then returns to file name search (as does **Alt+F**).
+- Files ignored by .gitignore, binary files, files over 4 MB and folders named in
+  "filemanager.grep_exclude" (default: .git, node_modules, .venv, venv, __pycache__) are skipped.
+  The search stops at 5000 matches.
+
+### 5. Drive Switching
 - Available drives are shown in the footer.
 - Navigate to the drive root (e.g., C:\, D:\) to switch.
 
@@ -264,21 +399,49 @@ A powerful, lightweight terminal-based IDE integrated into DevCLI. Featuring syn
 ### 1. Language Selection Menu
 - **Arrow Keys / Mouse**: Navigate language list
 - **Enter**: Select and open Editor
+- **Ctrl + R**: **RECENT FILES** (the last 15 files saved with Ctrl + S or opened with "devcli editor <file>", with their last-modified time; files that no longer exist are skipped)
 - **?**: Open this Help Guide
 - **Esc / q**: Back to main dashboard
 
 ### 2. Code Editor Workspace
 - **Arrow Keys / Mouse**: Move cursor / Scroll viewport
+- **Home / End**: Start of the line's text (press Home again for column 0) / end of the line
+- **Ctrl + ← / →**: Previous / next word; **Ctrl + Home / End**: start / end of the buffer
 - **Ctrl + R**: **RUN** current code (Auto-detects language)
+- **Ctrl + K**: **STOP** the running program (or the compiler search); its output so far is kept, ending in "[Killed: stopped by user]". Runs are also killed after editor.run_timeout (30s by default) with "[Killed: exceeded timeout]".
 - **Ctrl + S**: **SAVE** current file (Prompts for path)
-- **Ctrl + N**: **NEW FILE** (Clear current buffer)
+- **Ctrl + N**: **NEW FILE** (Clear current buffer; asks first if it has unsaved changes)
+- **Ctrl + D / Alt + D**: **DELETE / DUPLICATE** the current line (the copy goes below it, with the cursor). Terminals cannot tell Ctrl + Shift + D from Ctrl + D, hence Alt + D.
+- **Ctrl + G**: **GO TO LINE**: type a line number (or line:column, e.g. 12:5) and press Enter. Numbers past the end go to the last line. To jump from a compiler error instead, click its line in the output, or focus the output (Ctrl + O), pick a location with Tab and press Enter.
+- **Ctrl + Z / Ctrl + Y**: **UNDO / REDO**. Characters typed in a row are undone together; Enter, moving the cursor or a half-second pause starts a new step. Each tab keeps its own history (up to 500 steps); a new file or a new language buffer starts an empty one.
+- **Ctrl + T**: **OPEN TAB** (Open a file, or a blank buffer, in a new tab)
+- **Alt + N**: **RENAME** the open file on disk (a bare name keeps its folder; existing files are never overwritten; unsaved edits stay in the buffer)
+- **Ctrl + W**: **CLOSE TAB** (Press twice to discard unsaved changes)
+- **Alt + ← / →** (or Ctrl + Shift + Tab / Ctrl + Tab): **SWITCH** tabs
 - **Ctrl + O**: **FOCUS** Output Terminal
+  When the output names a line of the buffer (e.g. main.c:12:5, Program.cs(12,5) or File "script.py", line 3), Tab / Shift + Tab cycle through those locations and Enter moves the cursor there. Lines from Alt + R runs are mapped back to the buffer unless a main() was generated.
 - **Ctrl + E**: **FOCUS** Code Editor
+- From the command line, "devcli editor main.go:42" opens a file at line 42 ("main.go:42:8" at column 8 too); lines past the end go to the last line.
 - **Ctrl + M**: **MAXIMIZE / MINIMIZE** Output area
+- **Ctrl + L**: **CLEAR** Output (last output per language is kept across restarts until cleared or the file changes)
 - **Ctrl + P**: **SHELL** Prompt (Run system commands)
+- **Shift + Arrows / Home / End**: **SELECT** text from the cursor; typing, Backspace or Delete replace the selection, Esc or a plain arrow drops it.
+- **Ctrl + C / Ctrl + X / Ctrl + V**: **COPY / CUT / PASTE** with the system clipboard. When it is unavailable (e.g. no xclip or wl-clipboard on Linux) an internal clipboard is used, so copy and paste still work inside DevCLI.
+- **Ctrl + ]**: **JUMP** to the matching bracket (matching pairs are highlighted)
+- **Alt + F**: **FORMAT** document (Go: goimports/gofmt; JSON/YAML: validate and pretty-print, jumping to the first syntax error)
+- **Ctrl + Space**: **MARK** the cursor line (press again to clear)
+- **Ctrl + /**: **COMMENT** toggle for the current line, or every line from the mark to the cursor ("//", "#" or "--" by language). Lines are uncommented only when all of them are commented.
+- **Ctrl + B**: **COLUMN SELECT**: arrows grow a rectangle; typed text goes in at the same column on every line, Backspace/Delete remove a column (short lines are padded). Esc or Ctrl + B ends it.
+- **Alt + R**: **RUN SELECTION**: runs the lines from the mark to the cursor, or just the current line.
+  Compiled languages get a generated main() when the lines have none (Go also gets imports for the standard packages it uses), and PHP gets its <?php tag; the output footer says so.
+- **Alt + E**: **RUN IN TERMINAL**: builds and runs the buffer in a new terminal window (Windows Terminal or cmd, Terminal.app, $TERMINAL, or gnome-terminal, konsole, xterm and others) so interactive programs get a real TTY. The editor stays usable; press Enter in the window to close it. No timeout applies.
+- **Alt + C**: **COPY RUN COMMAND**: copies the shell command that compiles and runs the buffer, with the resolved compiler paths, and shows it in the status bar. A saved file is used where it is (a compiled program is written next to it); otherwise the buffer is written to a temp folder that is kept for the command.
+- **Alt + P**: **REPL**: starts an interactive Python ("python -i") or Node interpreter in the output pane, in the file's folder. Type a line and press Enter to send it; Up/Down recall earlier lines, PgUp/PgDn scroll. Esc returns to the editor while the interpreter keeps running, and Alt + P comes back to it. With a mark set, Alt + P sends the lines from the mark to the cursor. Ctrl + D ends the interpreter, Ctrl + C stops it; leaving the editor stops it too. No timeout applies.
+- **Alt + S**: **PROGRAM INPUT**: text typed here is piped to the program's standard input on every run (Ctrl + R, Alt + R), so input(), scanf and Scanner read it instead of hitting end of input. It is kept until you change it; Esc returns, Ctrl + R returns and runs, Ctrl + L clears. (Ctrl + I cannot be used: terminals send it as Tab.)
+- **Alt + X**: **EXPLAIN** the last failed run with the configured AI provider (Debugger agent); Esc aborts the request. Up to 16 KB of code and 8 KB of output are sent.
 - **? / Ctrl + H**: **TOGGLE** this Help Guide
-- **Esc**: **BACK** to Language Selection menu
-- **Ctrl + C**: **EXIT** Editor immediately
+- **Esc**: **BACK** to Language Selection menu. With unsaved changes it asks "Discard unsaved changes? [y/N]" first, as do Ctrl + N and picking another language for an untitled buffer.
+- **Ctrl + Q**: **EXIT** Editor (asks first if a tab has unsaved changes). Ctrl + C only copies, so it never closes the editor by accident
 
 ## Compiler & Runtime Guide
 
@@ -290,7 +453,63 @@ DevCLI tries to find these automatically if they are in your PATH:
 - **Rust**: Requires Rust toolchain (rustc, cargo).
 - **Zig**: Requires Zig compiler from ziglang.org.
 - **C#**: Requires .NET SDK 6.0+.
+- **PHP**: Requires the PHP CLI ("php").
+- **Swift**: Requires the Swift toolchain ("swiftc"); Xcode command line tools on macOS.
+- **JavaScript**: Requires Node.js ("node").
+- **TypeScript**: Requires "ts-node" (npm install -g ts-node typescript) or "tsx" (npm install -g tsx).
+- **Go**: Requires the Go toolchain; the buffer runs with "go run" and needs package main.
 - **Web**: Automatically launches a local dev server.
+- **HTML**: **Ctrl + R** on an .html file serves the buffer on a local port and opens it in your browser. Relative links (CSS, JS, images) load from the file's folder. Saving, auto-save and Ctrl + R push the buffer to the open page, which reloads itself. If no port can be opened, a static copy is opened instead.
+
+## Status Bar
+
+The status bar shows the cursor position, the buffer's line count and the language mode,
+e.g. "Ln 12, Col 5 · 340 lines · python". Columns count characters, not bytes, so they
+match compiler messages such as "line 12 col 5".
+
+## Git Status
+
+When the open file is inside a git repository, the status bar shows the branch and the
+file's state: clean, modified, staged, added, untracked, renamed, deleted or conflict.
+It is read in the background and refreshed after saves, renames and Ctrl + P commands.
+
+## Auto-save and Recovery
+
+With "editor.autosave_interval" set (e.g. 30s; Settings > Runner Options), modified named files
+are saved on that interval and the status bar shows when. Unnamed buffers are written to a
+recovery file instead; if DevCLI exits before they are saved, the editor offers to restore
+them on its next launch. Saving the buffer or pressing Ctrl + N removes the recovery file.
+
+## Whitespace Cleanup on Save
+
+Set "editor.trim_trailing_whitespace: true" in config.yaml to strip spaces and tabs at
+the end of every line, and "editor.final_newline: true" to end the file with exactly one
+newline. Both happen on every save, Ctrl + S and auto-save alike, and are off by default.
+
+## Indentation
+
+Enter keeps the indentation of the current line, adding one level after an opening
+brace (or a colon in Python); between braces the closing brace goes on its own line
+at the outer level. One level, and what Tab inserts, is 4 spaces unless "editor.indent_style" (spaces or
+tabs) or "editor.indent_size" (number of spaces) say otherwise (Settings > Runner
+Options; read when the editor opens). With either set, the starting code of a new
+buffer is re-indented to match. Makefiles always get a tab, as make requires.
+
+## .editorconfig
+
+For a named file, the nearest .editorconfig files up the folder tree (stopping at one
+with "root = true") are read and their sections matched against the file, e.g. [*.go]
+or [src/**.{js,ts}]. indent_style and indent_size set one indentation level for Tab
+and Enter (the Indentation settings otherwise); trim_trailing_whitespace and
+insert_final_newline override the two settings above for that file. The status bar shows ".editorconfig" when one applies.
+
+## Large Files
+
+Files bigger than "editor.max_open_bytes" in config.yaml (default 4 MB, in bytes)
+are not loaded for editing. They open as a **read-only hex preview** of the first 64 KB
+instead; typing, saving, running and formatting are disabled for that buffer.
+Press **Alt + H** to page through the whole file in the hex viewer (g jumps to an
+offset); it works for any saved file.
 
 ---
 *Press **Esc** or **Ctrl+H** to close this guide*`
@@ -306,6 +525,7 @@ Chat with AI models from multiple providers including **Ollama** (local), **Open
 | :--- | :--- |
 | **?** | Show this help |
 | **Enter** | Send message |
+| **Alt+P** | Switch to the next AI profile |
 | **Up/Down** | Scroll chat history |
 | **Mouse Wheel** | Scroll history |
 | **Esc / Ctrl+C** | Exit chat |
@@ -329,6 +549,17 @@ Chat with AI models from multiple providers including **Ollama** (local), **Open
 - **Free and Private**: No API key needed.
 - Install from [ollama.ai](https://ollama.ai) and run **ollama pull llama3**.
 
+### 4. Profiles
+- Named backends from the **profiles** section of config.yaml (see the Settings help).
+- **Alt+P** cycles through them, then back to the Settings backend; the header shows the active one.
+- The conversation so far is kept and sent to the new backend with your next message.
+
+### 5. Piping a Prompt In
+- **cat spec.md | devcli ai chat** sends the file as your first message and opens the chat
+  with the answer, so you can keep asking about it.
+- When the output goes to a pipe or file (**cat spec.md | devcli ai chat > plan.md**), or with
+  **--print**, only the answer is printed and DevCLI exits: handy in scripts.
+
 ---
 *Press **Esc** to close this guide*`
 
@@ -345,6 +576,8 @@ Configure AI providers, API keys, models, and other DevCLI settings. All setting
 | **Esc** | Cancel and return |
 | **Tab/Up/Down** | Navigate between fields |
 | **Enter** | Save settings (on last field) |
+| **Ctrl+L** | List the backend's models and check the entered one |
+| **Ctrl+R** | Reset editor/UI settings to their defaults |
 
 ## How to Use
 
@@ -360,6 +593,10 @@ Configure AI providers, API keys, models, and other DevCLI settings. All setting
   - *OpenAI*: gpt-4, gpt-3.5-turbo
   - *Gemini*: gemini-1.5-flash, gemini-pro
   - *Claude*: claude-3-opus, claude-3-sonnet
+- Press **Ctrl+L** to list the models your backend offers (using the key and base URL
+  as entered). A model missing from the list is flagged, and again when you save.
+  If the endpoint is unreachable or rate limited you are told why; saving still works.
+  Hugging Face and local models have no list.
 
 ### 3. API Key
 - Required for cloud providers
@@ -373,10 +610,66 @@ Configure AI providers, API keys, models, and other DevCLI settings. All setting
 - For custom API endpoints (e.g., LM Studio: http://localhost:1234/v1)
 - Leave empty for default provider endpoints
 
+### 5. Runner Options
+Extra flags for the editor's **Ctrl+R** runner (config keys in brackets):
+- **Python Binary** (runner.python_bin) - Interpreter to use instead of the auto-detected one
+- **C++ / C Flags** (runner.cpp_flags, runner.c_flags) - e.g. -O2 -std=c++17
+- **Rust Flags** (runner.rust_flags) - e.g. -O
+- **Javac Flags** (runner.java_flags) - e.g. -Xlint:all
+
+Flags are passed directly to the compiler without a shell, so shell characters like ; | & $ are rejected.
+
+- **Shell** (shell) - Shell for the editor's **Ctrl+P** prompt and the web terminal, e.g. pwsh, bash, zsh or fish. Empty uses $SHELL on macOS/Linux and PowerShell (or cmd) on Windows.
+- **Editor Auto-save** (editor.autosave_interval) - Every interval, e.g. 30s or 2m, the editor writes a modified named file to disk (atomically, via a temp file). An unnamed buffer is kept in a recovery file in the config folder instead and offered back the next time the editor opens. Empty or 0 turns it off.
+- **Editor Run Timeout** (editor.run_timeout) - Kills a program run from the editor (compile included) after this long, e.g. 30s or 2m, keeping its output so far. Empty uses the Command Timeout, or 30s when that is not set either; 0 means no limit. Ctrl+K stops a run at any time.
+- **Command Timeout** (exec.default_timeout) - Kills one-shot commands (editor runs without an Editor Run Timeout, tasks, installs, web runs) that take longer, e.g. 90s or 5m, with a "timed out after" message. Dev servers and dev/start/serve/watch tasks are exempt. Empty or 0 means no limit; pass --timeout to any devcli command to override it for that run.
+- **Confirm Quit** (ui.confirm_quit) - Quitting DevCLI (q, Esc or Ctrl+C on the main menu, Ctrl+C elsewhere) while dev servers or other started processes are running, or with unsaved editor buffers, asks first. On yes, servers get a few seconds to shut down cleanly before they are killed. Set to false to quit immediately.
+
+Program output (editor runs and compiles, the Ctrl+P shell, web runs and terminal) is
+converted to UTF-8 before it is shown. By default valid UTF-8 is kept and anything else
+is read in the Windows ANSI code page, or has bad bytes replaced on macOS/Linux. Set
+"exec.output_encoding" in config.yaml to force one, e.g. cp1252, cp932, shift_jis or
+utf-8, and "exec.output_encodings.<language>" (python, java, shell, ...) for one language.
+
 ## Configuration File
 Settings are stored at:
-- **Windows**: C:\Users\<user>\.devcli\config.yaml
-- **Linux/Mac**: ~/.devcli/config.yaml
+- **Windows**: %AppData%\devcli\config.yaml
+- **macOS**: ~/Library/Application Support/devcli/config.yaml
+- **Linux**: $XDG_CONFIG_HOME/devcli/config.yaml (default ~/.config/devcli)
+
+Files from the old ~/.devcli folder and ~/.devcli.yaml are moved there automatically.
+
+To use another file (e.g. a separate work profile), start with
+**devcli --config path/to/config.yaml** or set **DEVCLI_CONFIG**. The flag wins over
+the variable; Settings reads and saves the chosen file.
+
+## AI Profiles
+To switch between backends (e.g. local Ollama for quick questions, a cloud model for
+hard problems), add named profiles to config.yaml. Each takes backend, model, api_key
+and base_url; empty fields use the backend's defaults.
+
+    ai_profile: fast
+    profiles:
+      fast:
+        backend: ollama
+        model: llama3
+      smart:
+        backend: claude
+        model: claude-3-5-sonnet-latest
+        api_key: sk-ant-...
+
+"ai_profile" is used at startup by every AI feature; without it the fields above apply.
+Press **Alt+P** in AI Chat or the AI Assistant to cycle profiles for that session.
+
+## Resetting Editor/UI Settings
+**Ctrl+R** lists the editor and UI settings that differ from their defaults (editor_theme,
+editor.*, ui.confirm_quit, devserver.confirm_actions and the filemanager.* options) and restores them after you
+press **y**. AI keys, profiles, runner options and saved history are kept. From a shell,
+run **devcli config reset --section ui** (add --yes to skip the question).
+
+Path prompts (dev server, venv create/scan/clone, new project, import and backup)
+start with the last path you entered there, saved under "paths.last". Delete a key
+to go back to the default (usually the workspace).
 
 ---
 *Press **Esc** to close this guide*`
@@ -526,11 +819,15 @@ HOW TO USE
 3. MANAGING OUTPUT
    • Use Arrow Keys or Mouse Wheel to scroll through logs.
    • Tasks automatically scroll to the bottom as they run.
+   • The last 2000 lines are kept; older ones are dropped (the top of the
+     output says how many).
    • Once complete, review the logs and press Esc to return.
 
 4. CANCELLING TASKS
    • If a task is taking too long or hung, press Ctrl+C.
-   • Task Runner will attempt to terminate the process.
+   • The task is killed together with every process it started (e.g. node
+     under npm). The header shows "Cancelling" until it has exited, then
+     the result reads "Task Cancelled".
 
 SUPPORTED LANGUAGES
 • Go, Python, Node.js, Java, Rust, C/C++, Makefile targets.
@@ -710,6 +1007,7 @@ Esc         Go back / Clear input
 Ctrl+D      Send prompt to AI
 Tab         Switch Agent (CodeGen -> Architect -> Debugger)
 Shift+Tab   Switch Agent backwards
+Alt+P       Switch AI profile (see Settings help)
 Up/Down     Scroll output
                                                                 
 AGENTS
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	hexRowBytes = 16
+	hexRowWidth = 78 // Printed width of a full row
+)
+
+// looksBinary applies git's heuristic: a NUL byte near the start
+func looksBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0
+}
+
+// isBinaryFile reports whether the start of path looks binary
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, 8000)
+	n, _ := io.ReadFull(f, head)
+	return looksBinary(head[:n])
+}
+
+// hexViewer pages through a file as offset | hex bytes | ASCII. Only the
+// rows on screen are read, so file size does not matter.
+type hexViewer struct {
+	path   string
+	size   int64
+	offset int64 // Offset of the top row, a multiple of hexRowBytes
+	view   viewport.Model
+	input  textinput.Model // Jump-to-offset prompt (g)
+	asking bool
+	err    error
+}
+
+func newHexViewer(path string, width, height int) (hexViewer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return hexViewer{}, err
+	}
+	if info.IsDir() {
+		return hexViewer{}, fmt.Errorf("%s is a directory", path)
+	}
+	ti := textinput.New()
+	ti.Placeholder = "Offset, e.g. 0x1f40 or 8000"
+	ti.CharLimit = 24
+	ti.Width = 30
+
+	h := hexViewer{path: path, size: info.Size(), input: ti}
+	h.resize(width, height)
+	return h, nil
+}
+
+func (h *hexViewer) rows() int {
+	return max(h.view.Height, 1)
+}
+
+func (h *hexViewer) resize(width, height int) {
+	h.view.Width = min(max(width-4, 20), hexRowWidth)
+	h.view.Height = max(height-6, 1)
+	h.load()
+}
+
+// lastOffset is the top-row offset that shows the end of the file
+func (h *hexViewer) lastOffset() int64 {
+	rows := (h.size + hexRowBytes - 1) / hexRowBytes
+	return max(rows-int64(h.rows()), 0) * hexRowBytes
+}
+
+// seek moves the top row to offset, clamped to the file and aligned to a row
+func (h *hexViewer) seek(offset int64) {
+	offset = min(max(offset, 0), h.lastOffset())
+	h.offset = offset - offset%hexRowBytes
+	h.load()
+}
+
+// load reads the window at h.offset and renders it into the viewport
+func (h *hexViewer) load() {
+	f, err := os.Open(h.path)
+	if err != nil {
+		h.err = err
+		return
+	}
+	defer f.Close()
+
+	buf := make([]byte, h.rows()*hexRowBytes)
+	n, err := f.ReadAt(buf, h.offset)
+	if err != nil && err != io.EOF {
+		h.err = err
+		return
+	}
+	h.err = nil
+	h.view.SetContent(hexRows(buf[:n], h.offset))
+}
+
+// hexRows formats data starting at offset base, hexRowBytes per line
+func hexRows(data []byte, base int64) string {
+	var b strings.Builder
+	for i := 0; i < len(data); i += hexRowBytes {
+		row := data[i:min(i+hexRowBytes, len(data))]
+		fmt.Fprintf(&b, "%08x  ", base+int64(i))
+		for j := 0; j < hexRowBytes; j++ {
+			if j < len(row) {
+				fmt.Fprintf(&b, "%02x ", row[j])
+			} else {
+				b.WriteString("   ")
+			}
+			if j == 7 {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(" |")
+		for _, c := range row {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			b.WriteByte(c)
+		}
+		b.WriteString("|")
+		if i+hexRowBytes < len(data) {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// parseOffset accepts decimal or 0x-prefixed hex
+func parseOffset(s string) (int64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if rest, ok := strings.CutPrefix(s, "0x"); ok {
+		return strconv.ParseInt(rest, 16, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// update handles a key; done is true when the viewer should close
+func (h *hexViewer) update(msg tea.KeyMsg) (done bool, cmd tea.Cmd) {
+	if h.asking {
+		switch msg.String() {
+		case "esc":
+			h.asking = false
+			h.input.Blur()
+		case "enter":
+			h.asking = false
+			h.input.Blur()
+			if off, err := parseOffset(h.input.Value()); err != nil || off < 0 {
+				h.err = fmt.Errorf("invalid offset %q", h.input.Value())
+			} else {
+				h.seek(off)
+			}
+		default:
+			h.input, cmd = h.input.Update(msg)
+		}
+		return false, cmd
+	}
+
+	page := int64(h.rows()) * hexRowBytes
+	switch msg.String() {
+	case "esc", "q":
+		return true, nil
+	case "up", "k":
+		h.seek(h.offset - hexRowBytes)
+	case "down", "j":
+		h.seek(h.offset + hexRowBytes)
+	case "pgup", "b":
+		h.seek(h.offset - page)
+	case "pgdown", " ", "f":
+		h.seek(h.offset + page)
+	case "home":
+		h.seek(0)
+	case "end", "G":
+		h.seek(h.lastOffset())
+	case "g":
+		h.asking = true
+		h.input.SetValue("")
+		h.input.Focus()
+		return false, textinput.Blink
+	}
+	return false, nil
+}
+
+func (h hexViewer) View(width, height int) string {
+	end := min(h.offset+int64(h.rows())*hexRowBytes, h.size)
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).
+		Render(fmt.Sprintf("Hex: %s", filepath.Base(h.path)))
+	info := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+		Render(fmt.Sprintf("%s (%d bytes) • showing 0x%x-0x%x", formatBytes(h.size), h.size, h.offset, end))
+
+	footer := "↑/↓: Row • PgUp/PgDn: Page • Home/End • g: Go to offset • Esc: Back"
+	if h.asking {
+		footer = "Go to offset: " + h.input.View() + "  (Enter to jump, Esc to cancel)"
+	} else if h.err != nil {
+		footer = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: "+h.err.Error()) + "  " + footer
+	}
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left,
+			title+"  "+info,
+			lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("62")).Render(h.view.View()),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(footer),
+		),
+	)
+}
+
+// openHexView handles Alt+H in the editor: the saved file, not the buffer,
+// is shown, which is how oversized read-only previews are read in full
+func (m *model) openHexView() {
+	if m.filename == "" {
+		m.status = "Hex view needs a file on disk (Ctrl+S to save first)"
+		return
+	}
+	h, err := newHexViewer(m.filename, m.width, m.height)
+	if err != nil {
+		m.status = fmt.Sprintf("Error opening hex view: %v", err)
+		return
+	}
+	m.hexView = h
+	m.showHex = true
+}
+
+// openHexView shows path in the File Manager's hex viewer
+func (m *FileManagerModel) openHexView(path string) {
+	h, err := newHexViewer(path, m.width, m.height)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.hexView = h
+	m.showHex = true
+}
@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// toolInstallHint describes how to get a missing compiler/interpreter on each platform
+type toolInstallHint struct {
+	name    string // Human readable tool name
+	windows string
+	darwin  string
+	linux   string
+	url     string
+}
+
+var jdkInstallHint = toolInstallHint{
+	name:    "Java (JDK)",
+	windows: "winget install EclipseAdoptium.Temurin.21.JDK",
+	darwin:  "brew install openjdk",
+	linux:   "sudo apt install default-jdk",
+	url:     "https://adoptium.net/",
+}
+
+var nodeInstallHint = toolInstallHint{
+	name:    "Node.js",
+	windows: "winget install OpenJS.NodeJS.LTS",
+	darwin:  "brew install node",
+	linux:   "sudo apt install nodejs npm",
+	url:     "https://nodejs.org/",
+}
+
+// toolInstallHints is keyed by executable name: the editor toolchains passed
+// to resolveExecutable and the dev server commands
+var toolInstallHints = map[string]toolInstallHint{
+	"python": {
+		name:    "Python",
+		windows: "winget install Python.Python.3.12 (or the installer from python.org)",
+		darwin:  "brew install python",
+		linux:   "sudo apt install python3",
+		url:     "https://www.python.org/downloads/",
+	},
+	"java":  jdkInstallHint,
+	"javac": jdkInstallHint,
+	"g++": {
+		name:    "g++ (C++ compiler)",
+		windows: "install MinGW-w64 (e.g. via MSYS2: pacman -S mingw-w64-ucrt-x86_64-gcc)",
+		darwin:  "xcode-select --install (or brew install gcc)",
+		linux:   "sudo apt install g++",
+		url:     "https://www.mingw-w64.org/downloads/",
+	},
+	"gcc": {
+		name:    "gcc (C compiler)",
+		windows: "install MinGW-w64 (e.g. via MSYS2: pacman -S mingw-w64-ucrt-x86_64-gcc)",
+		darwin:  "xcode-select --install (or brew install gcc)",
+		linux:   "sudo apt install gcc",
+		url:     "https://www.mingw-w64.org/downloads/",
+	},
+	"rustc": {
+		name:    "Rust",
+		windows: "winget install Rustlang.Rustup",
+		darwin:  "curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh",
+		linux:   "curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh",
+		url:     "https://rustup.rs/",
+	},
+	"zig": {
+		name:    "Zig",
+		windows: "winget install zig.zig",
+		darwin:  "brew install zig",
+		linux:   "sudo snap install zig --classic --beta",
+		url:     "https://ziglang.org/download/",
+	},
+	"dotnet": {
+		name:    ".NET SDK",
+		windows: "winget install Microsoft.DotNet.SDK.8",
+		darwin:  "brew install --cask dotnet-sdk",
+		linux:   "sudo apt install dotnet-sdk-8.0",
+		url:     "https://dotnet.microsoft.com/download",
+	},
+	"php": {
+		name:    "PHP",
+		windows: "winget install PHP.PHP.8.3",
+		darwin:  "brew install php",
+		linux:   "sudo apt install php-cli",
+		url:     "https://www.php.net/downloads",
+	},
+	"swiftc": {
+		name:    "Swift",
+		windows: "winget install Swift.Toolchain",
+		darwin:  "xcode-select --install",
+		linux:   "download the toolchain for your distribution from swift.org",
+		url:     "https://www.swift.org/install/",
+	},
+	"go": {
+		name:    "Go",
+		windows: "winget install GoLang.Go",
+		darwin:  "brew install go",
+		linux:   "sudo apt install golang-go",
+		url:     "https://go.dev/dl/",
+	},
+	"goimports": {
+		name:    "goimports (or gofmt from the Go toolchain)",
+		windows: "go install golang.org/x/tools/cmd/goimports@latest",
+		darwin:  "go install golang.org/x/tools/cmd/goimports@latest",
+		linux:   "go install golang.org/x/tools/cmd/goimports@latest",
+		url:     "https://pkg.go.dev/golang.org/x/tools/cmd/goimports",
+	},
+	"ts-node": {
+		name:    "ts-node (TypeScript)",
+		windows: "npm install -g ts-node typescript (or: npm install -g tsx)",
+		darwin:  "npm install -g ts-node typescript (or: npm install -g tsx)",
+		linux:   "npm install -g ts-node typescript (or: npm install -g tsx)",
+		url:     "https://typestrong.org/ts-node/",
+	},
+	"node": nodeInstallHint,
+	"npm":  nodeInstallHint,
+	"npx":  nodeInstallHint,
+	"hugo": {
+		name:    "Hugo",
+		windows: "winget install Hugo.Hugo.Extended",
+		darwin:  "brew install hugo",
+		linux:   "sudo snap install hugo",
+		url:     "https://gohugo.io/installation/",
+	},
+	"bundle": {
+		name:    "Ruby Bundler (for Jekyll)",
+		windows: "winget install RubyInstallerTeam.RubyWithDevKit.3.2, then: gem install bundler jekyll",
+		darwin:  "brew install ruby && gem install bundler jekyll",
+		linux:   "sudo apt install ruby-full build-essential && gem install bundler jekyll",
+		url:     "https://jekyllrb.com/docs/installation/",
+	},
+}
+
+// installHintFor returns the install command for the current platform
+func installHintFor(tool string) string {
+	hint, ok := toolInstallHints[tool]
+	if !ok {
+		return ""
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return hint.windows
+	case "darwin":
+		return hint.darwin
+	default:
+		return hint.linux
+	}
+}
+
+// InstallHint is a short "Install:/Download:" note for a missing tool, or ""
+// when there is no hint for it
+func InstallHint(tool string) string {
+	hint, ok := toolInstallHints[tool]
+	if !ok {
+		return ""
+	}
+	note := fmt.Sprintf("%s is not installed (%q not found on PATH)\n  Install:   %s", hint.name, tool, installHintFor(tool))
+	if hint.url != "" {
+		note += "\n  Download:  " + hint.url
+	}
+	return note
+}
+
+// missingToolGuidance explains how to fix a missing compiler/interpreter:
+// what is missing, how to install it here, and which config key to set
+// to point DevCLI at an existing install.
+func missingToolGuidance(tool string) string {
+	name := tool
+	if hint, ok := toolInstallHints[tool]; ok {
+		name = hint.name
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s was not found on PATH or in common install locations.\n\n", name))
+	if install := installHintFor(tool); install != "" {
+		sb.WriteString(fmt.Sprintf("  Install:   %s\n", install))
+	}
+	if hint, ok := toolInstallHints[tool]; ok && hint.url != "" {
+		sb.WriteString(fmt.Sprintf("  Download:  %s\n", hint.url))
+	}
+	configPath, err := config.Path()
+	if err != nil {
+		configPath = "the DevCLI config.yaml"
+	}
+	sb.WriteString(fmt.Sprintf("  Or set the path manually in %s:\n      compilers:\n        %s: /full/path/to/%s\n", configPath, tool, tool))
+	sb.WriteString("\nRestart your terminal after installing so PATH changes are picked up.")
+	return sb.String()
+}
+
+// missingToolResult is the execResult returned by runCode when a tool is missing
+func missingToolResult(tool string) execResult {
+	return execResult{
+		output: missingToolGuidance(tool),
+		err:    fmt.Errorf("%s not found (install it or set compilers.%s)", tool, tool),
+		stage:  stageSetup,
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/keymap"
+)
+
+// loadKeymap reads the keys.<scope>.* overrides; warning lists the ones
+// that were ignored, for the screen's status line
+func loadKeymap(scope string) (km keymap.Map, warning string) {
+	km, warnings := keymap.Load(scope, config.GetString)
+	if len(warnings) > 0 {
+		warning = "Keymap: " + strings.Join(warnings, "; ")
+	}
+	return km, warning
+}
+
+// relabelHints shows the active keys in a footer built with the defaults
+func relabelHints(km keymap.Map, hints []keyHint) []keyHint {
+	out := make([]keyHint, len(hints))
+	for i, h := range hints {
+		out[i] = keyHint{key: km.Relabel(h.key), desc: h.desc}
+	}
+	return out
+}
+
+// keymapHelp is the help section listing the active bindings of km
+func keymapHelp(km keymap.Map) string {
+	var b strings.Builder
+	b.WriteString("\n## Active Keybindings\n")
+	b.WriteString(fmt.Sprintf("Set keys.%s.<action> in the config to change a key (e.g. keys.%s.%s: f5).\n",
+		km.Scope(), km.Scope(), keymap.Defaults[km.Scope()][0].Action))
+	b.WriteString("Only the editor and File Manager keys can be changed; the dashboards keep their fixed keys.\n\n")
+	b.WriteString("| Key | Action | Config key |\n| :--- | :--- | :--- |\n")
+	for _, binding := range keymap.Defaults[km.Scope()] {
+		key := keymap.Label(km.Key(binding.Action))
+		if km.Key(binding.Action) != binding.Default {
+			key += " (default " + keymap.Label(binding.Default) + ")"
+		}
+		b.WriteString(fmt.Sprintf("| **%s** | %s | %s |\n", key, binding.Desc, keymap.ConfigKey(km.Scope(), binding.Action)))
+	}
+	return b.String()
+}
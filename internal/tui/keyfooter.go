@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyFooterToggleKey collapses/expands the shortcut footer in every dashboard
+const keyFooterToggleKey = "f1"
+
+// keyFooterHidden is shared so the choice sticks when switching dashboards
+var keyFooterHidden bool
+
+// keyHint is a single shortcut shown in the footer legend
+type keyHint struct {
+	key  string
+	desc string
+}
+
+func toggleKeyFooter() {
+	keyFooterHidden = !keyFooterHidden
+}
+
+// renderKeyFooter renders the compact shortcut legend for the active view.
+// When collapsed only the toggle hint is shown.
+func renderKeyFooter(width int, hints []keyHint) string {
+	keyStyle := lipgloss.NewStyle().Foreground(colorPink).Bold(true)
+	descStyle := subtleStyle
+
+	if keyFooterHidden {
+		return descStyle.Render("[F1] Show keys")
+	}
+
+	parts := make([]string, 0, len(hints)+1)
+	for _, h := range hints {
+		parts = append(parts, fmt.Sprintf("%s %s", keyStyle.Render("["+h.key+"]"), descStyle.Render(h.desc)))
+	}
+	parts = append(parts, fmt.Sprintf("%s %s", keyStyle.Render("[F1]"), descStyle.Render("Hide keys")))
+
+	footer := lipgloss.NewStyle()
+	if width > 0 {
+		footer = footer.MaxWidth(width)
+	}
+	return footer.Render(strings.Join(parts, descStyle.Render(" • ")))
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Path prompts whose last value is remembered under "paths.last.<context>"
+const (
+	pathDevServer  = "devserver"
+	pathVenvCreate = "venv_create"
+	pathVenvScan   = "venv_scan"
+	pathVenvClone  = "venv_clone"
+	pathProject    = "project"
+	pathImport     = "project_import"
+	pathBackup     = "backup"
+)
+
+func lastPathKey(context string) string {
+	return "paths.last." + context
+}
+
+// lastPath returns the path last entered in the context's prompt, or
+// fallback when none was saved or it no longer exists
+func lastPath(context, fallback string) string {
+	path := config.GetString(lastPathKey(context))
+	if path == "" {
+		return fallback
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fallback
+	}
+	return path
+}
+
+// rememberPath saves path as the default for the context's prompt. Saving
+// is best-effort: a read-only config must not block the action.
+func rememberPath(context, path string) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if config.GetString(lastPathKey(context)) == path {
+		return
+	}
+	config.SaveConfig(lastPathKey(context), path)
+}
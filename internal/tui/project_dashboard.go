@@ -2,6 +2,7 @@ package tui
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -18,19 +19,22 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/devserver"
 	"github.com/phravins/devcli/internal/history"
+	"github.com/phravins/devcli/internal/procs"
 	"github.com/phravins/devcli/internal/project"
-	"github.com/phravins/devcli/internal/templates"
 )
 
 type ProjectDashboardModel struct {
-	menuList     list.Model // Top Level Menu
-	projectList  list.Model // Project List (Sub Menu)
-	templateList list.Model // Wizard Step 1
-	input        textinput.Model
-	pathInput    textinput.Model // New Input for Path
-	spinner      spinner.Model
-	historyList  list.Model // New History List
+	menuList      list.Model      // Top Level Menu
+	projectList   list.Model      // Project List (Sub Menu)
+	allProjects   []list.Item     // Unfiltered workspace projects
+	projectFilter textinput.Model // "/" filter over the project list
+	templateList  list.Model      // Wizard Step 1
+	input         textinput.Model
+	pathInput     textinput.Model // New Input for Path
+	spinner       spinner.Model
+	historyList   list.Model // New History List
 
 	// State
 	state         int
@@ -46,9 +50,19 @@ type ProjectDashboardModel struct {
 	boilerplateModel BoilerplateDashboardModel
 	bonusModel       BonusDashboardModel
 
-	selectedTpl string
-	err         error
-	statusMsg   string
+	selectedTpl      string
+	showAllTemplates bool   // Include hidden templates in the wizard
+	templateStatus   string // Feedback for pin/hide in the wizard
+	projectStatus    string // Feedback on the project list, e.g. after an import
+	createdPath      string // Shown on the success screen when set (duplicates)
+	err              error
+	statusMsg        string
+
+	importing bool // Folder being inspected for an import
+
+	// Duplicate action: source, resolved destination and options
+	dupSrc, dupDest    string
+	dupGit, dupInstall bool
 
 	// Installation Logging
 	installOutput *strings.Builder
@@ -57,18 +71,21 @@ type ProjectDashboardModel struct {
 }
 
 const (
-	StateMenu           = iota // Top level: "Project Creation & Management", etc.
-	StateProjectList           // Spec: "My Projects" list with "+ New Project"
-	StateSelectTemplate        // Wizard Step 1
-	StateNameProject           // Wizard Step 2
-	StateSelectPath            // New State
-	StateCreating              // Wizard Step 3 (Processing)
-	StateSuccess               // Completion Screen
-	StateBackupInput           // New Backup State
-	StateCleanupPrompt         // New: Ask to delete old logs
-	StateHistoryList           // New: View History
-	StateConfirmDelete         // New: Confirm Deletion
-	StateProjectHelp           // Help screen
+	StateMenu             = iota // Top level: "Project Creation & Management", etc.
+	StateProjectList             // Spec: "My Projects" list with "+ New Project"
+	StateSelectTemplate          // Wizard Step 1
+	StateNameProject             // Wizard Step 2
+	StateSelectPath              // New State
+	StateCreating                // Wizard Step 3 (Processing)
+	StateSuccess                 // Completion Screen
+	StateBackupInput             // New Backup State
+	StateCleanupPrompt           // New: Ask to delete old logs
+	StateHistoryList             // New: View History
+	StateConfirmDelete           // New: Confirm Deletion
+	StateProjectHelp             // Help screen
+	StateImportPath              // Folder of an existing project to import
+	StateDuplicateName           // Name or path for a copy of a project
+	StateDuplicateOptions        // Git and install choices for the copy
 
 	StateVenvWizard  // Sub-feature 2 (Delegated to venvModel)
 	StateDevServer   // Sub-feature 3 (Dev Server Launcher)
@@ -94,18 +111,14 @@ func NewProjectDashboardModel() ProjectDashboardModel {
 	menu.SetShowTitle(false)
 
 	// 2. Project List (Sub-feature)
-	items := loadProjects(mgr.Workspace)
-	items = append([]list.Item{item{title: "+ New Project", desc: "Create a new project from template"}}, items...)
-	pl := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	projects := loadProjects(mgr.Workspace)
+	pl := list.New(append([]list.Item{newProjectItem}, projects...), list.NewDefaultDelegate(), 0, 0)
 	pl.Title = "My Projects"
 	pl.SetShowHelp(false)
+	pl.SetFilteringEnabled(false) // Own filter keeps "+ New Project" pinned
 
 	// 3. Template List (Wizard)
-	var tplItems []list.Item
-	for _, t := range templates.List() {
-		tplItems = append(tplItems, item{title: t.Name, desc: t.Description})
-	}
-	tplList := list.New(tplItems, list.NewDefaultDelegate(), 0, 0)
+	tplList := list.New(templateItems(false), list.NewDefaultDelegate(), 0, 0)
 	tplList.Title = "Select a Template"
 	tplList.Title = "Select Project Template (v2)"
 	tplList.SetShowHelp(false)
@@ -125,8 +138,8 @@ func NewProjectDashboardModel() ProjectDashboardModel {
 	// Path Input
 	pi := textinput.New()
 	pi.Placeholder = "Parent Directory (e.g. C:\\Projects or ~)"
-	// Default to current Workspace
-	pi.SetValue(mgr.Workspace)
+	// Default to the last parent folder used, else the current Workspace
+	pi.SetValue(lastPath(pathProject, mgr.Workspace))
 	pi.CharLimit = 100
 	pi.Width = 50
 
@@ -157,17 +170,19 @@ func NewProjectDashboardModel() ProjectDashboardModel {
 	return ProjectDashboardModel{
 		menuList:         menu,
 		projectList:      pl,
+		allProjects:      projects,
+		projectFilter:    newProjectFilterInput(),
 		templateList:     tplList,
 		historyList:      histList,
 		input:            ti,
 		pathInput:        pi, // Add to struct
 		spinner:          s,
 		manager:          mgr,
-		venvModel:        NewVenvDashboardModel(),                     // Init Venv Model
-		devServerModel:   NewDevServerDashboardModel(mgr.Workspace),   // Init Dev Server Model
-		boilerplateModel: NewBoilerplateDashboardModel(mgr.Workspace), // Init Boilerplate Model
-		bonusModel:       NewBonusDashboardModel(mgr.Workspace),       // Init Bonus Model
-		state:            StateMenu,                                   // Start at Top Level
+		venvModel:        NewVenvDashboardModel(),                                            // Init Venv Model
+		devServerModel:   NewDevServerDashboardModel(lastPath(pathDevServer, mgr.Workspace)), // Init Dev Server Model
+		boilerplateModel: NewBoilerplateDashboardModel(mgr.Workspace),                        // Init Boilerplate Model
+		bonusModel:       NewBonusDashboardModel(mgr.Workspace),                              // Init Bonus Model
+		state:            StateMenu,                                                          // Start at Top Level
 		installOutput:    &strings.Builder{},
 		installView:      vp,
 		helpView:         hv,
@@ -180,24 +195,52 @@ func loadProjects(workspace string) []list.Item {
 		return []list.Item{}
 	}
 	var items []list.Item
+	listed := map[string]bool{}
 	for _, e := range entries {
 		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
 			// Smart Filtering: Only list if it looks like a project
 			fullPath := filepath.Join(workspace, e.Name())
 			if isProject(fullPath) {
-				info, err := e.Info()
-				desc := "Existing Project"
-				if err == nil {
-					modTime := info.ModTime().Format("2006-01-02 15:04")
-					desc = fmt.Sprintf("Path: %s | Modified: %s", fullPath, modTime)
-				}
-				items = append(items, item{title: e.Name(), desc: desc})
+				listed[fullPath] = true
+				items = append(items, projectListItem(fullPath, ""))
 			}
 		}
 	}
+	// Projects registered with "Import Existing" live anywhere
+	for _, p := range project.ImportedPaths() {
+		if !listed[p] {
+			listed[p] = true
+			items = append(items, projectListItem(p, "imported"))
+		}
+	}
 	return items
 }
 
+func projectListItem(fullPath, tag string) item {
+	desc := "Existing Project"
+	if info, err := os.Stat(fullPath); err == nil {
+		modTime := info.ModTime().Format("2006-01-02 15:04")
+		desc = fmt.Sprintf("Path: %s | Modified: %s", fullPath, modTime)
+	}
+	stack := ""
+	if t := devserver.Detect(fullPath).Type; t != devserver.TypeUnknown {
+		stack = string(t)
+		desc = stack + " | " + desc
+	}
+	if tag != "" {
+		desc = tag + " | " + desc
+		stack = strings.TrimSpace(stack + " " + tag)
+	}
+	// Only repositories rooted at the project are checked, so folders
+	// without git cost nothing
+	if _, err := os.Stat(filepath.Join(fullPath, ".git")); err == nil {
+		if st, ok := project.Git(fullPath); ok {
+			desc = "git: " + st.Summary() + " | " + desc
+		}
+	}
+	return item{id: fullPath, title: filepath.Base(fullPath), desc: desc, tags: stack}
+}
+
 // isProject checks if a directory contains common project markers
 func isProject(dir string) bool {
 	markers := []string{
@@ -245,8 +288,11 @@ type installDoneMsg struct{ err error }
 
 // Actual implementation using "Next Line" command pattern
 type cmdProcess struct {
-	cmd    *exec.Cmd
-	reader *bufio.Reader
+	cmd     *exec.Cmd
+	reader  *bufio.Reader
+	ctx     context.Context // Bounded by exec.default_timeout
+	cancel  context.CancelFunc
+	timeout time.Duration
 }
 
 type installStartedMsg struct {
@@ -267,30 +313,39 @@ func createProjectCmd(mgr *project.Manager, name, stack, path string) tea.Cmd {
 
 func startInstallCmd(dir, cmdStr string) tea.Cmd {
 	return func() tea.Msg {
+		timeout := procs.Timeout()
+		ctx, cancel := procs.WithTimeout(context.Background(), timeout)
+
 		// Use explicit echoes to force output
 		// We use 'call' to ensure batch files work.
 		var c *exec.Cmd
 		if runtime.GOOS == "windows" {
-			fullCmd := fmt.Sprintf("@echo on & echo [DevCLI] Starting installation process... & echo [DevCLI] Directory: %s & echo [DevCLI] Running: %s & echo ---------------------------------------- & call %s & echo. & echo ---------------------------------------- & echo [DevCLI] Process Completed.", dir, cmdStr, cmdStr)
-			c = exec.Command("cmd", "/c", fullCmd)
+			// Escape && in the echoed copy so it is printed, not run twice
+			echoCmd := strings.NewReplacer("&", "^&", "|", "^|", "<", "^<", ">", "^>").Replace(cmdStr)
+			fullCmd := fmt.Sprintf("@echo on & echo [DevCLI] Starting installation process... & echo [DevCLI] Directory: %s & echo [DevCLI] Running: %s & echo ---------------------------------------- & call %s & echo. & echo ---------------------------------------- & echo [DevCLI] Process Completed.", dir, echoCmd, cmdStr)
+			c = exec.CommandContext(ctx, "cmd", "/c", fullCmd)
 		} else {
 			// Unix/Linux/Mac Buffer-friendly command chain
 			fullCmd := fmt.Sprintf("echo '[DevCLI] Starting installation process...' && echo '[DevCLI] Directory: %s' && echo '[DevCLI] Running: %s' && echo '----------------------------------------' && %s && echo '' && echo '----------------------------------------' && echo '[DevCLI] Process Completed.'", dir, cmdStr, cmdStr)
-			c = exec.Command("sh", "-c", fullCmd)
+			c = exec.CommandContext(ctx, "sh", "-c", fullCmd)
 		}
 		c.Dir = dir
 
 		outPipe, _ := c.StdoutPipe()
 		c.Stderr = c.Stdout // Merge stderr
 
-		if err := c.Start(); err != nil {
+		if err := procs.Start(c, "install: "+cmdStr); err != nil {
+			cancel()
 			return installDoneMsg{err: err}
 		}
 
 		return installStartedMsg{
 			proc: &cmdProcess{
-				cmd:    c,
-				reader: bufio.NewReader(outPipe),
+				cmd:     c,
+				reader:  bufio.NewReader(outPipe),
+				ctx:     ctx,
+				cancel:  cancel,
+				timeout: timeout,
 			},
 		}
 	}
@@ -304,10 +359,12 @@ func readNextLine(proc *cmdProcess) tea.Cmd {
 		line, err := proc.reader.ReadString('\n')
 		if err != nil {
 			proc.cmd.Wait() // Cleanup
+			procs.Unregister(proc.cmd)
+			proc.cancel()
 			if err == io.EOF {
-				return installDoneMsg{err: nil}
+				err = nil
 			}
-			return installDoneMsg{err: err}
+			return installDoneMsg{err: procs.TimeoutError(proc.ctx, err, proc.timeout)}
 		}
 		return installOutputMsg{line: line, proc: proc}
 	}
@@ -450,6 +507,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter", "esc":
 				m.state = StateProjectList
+				m.reloadProjects()
 				return m, nil
 			}
 
@@ -464,10 +522,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if ok {
 					if i.title == "Project Creation & Management" {
 						m.state = StateProjectList
-						// Refresh projects?
-						items := loadProjects(m.manager.Workspace)
-						items = append([]list.Item{item{title: "+ New Project", desc: "Create a new project from template"}}, items...)
-						m.projectList.SetItems(items)
+						m.reloadProjects()
 						return m, nil
 					}
 					if i.title == "Virtual Environment Wizard" {
@@ -482,7 +537,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					if i.title == "Dev Server" {
 						m.state = StateDevServer
-						m.devServerModel = NewDevServerDashboardModel(m.manager.Workspace)
+						m.devServerModel = NewDevServerDashboardModel(lastPath(pathDevServer, m.manager.Workspace))
 						// Initialize with current dimensions to ensure correct layout (centering)
 						h, v := AppBorderStyle.GetFrameSize()
 						innerW := m.width - h - 2
@@ -589,7 +644,30 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 
 		case StateProjectList:
+			if m.projectFilter.Focused() {
+				return m, m.updateProjectFilter(msg)
+			}
+			m.projectStatus = ""
 			switch msg.String() {
+			case "i":
+				return m, m.startImport()
+			case "d":
+				return m, m.startDuplicate()
+			case "r":
+				project.RefreshGit("")
+				selected := m.projectList.Index()
+				m.reloadProjects()
+				m.projectList.Select(selected)
+				return m, notify("Git status refreshed")
+			case "t":
+				if i, ok := m.projectList.SelectedItem().(item); ok && i.id != "" {
+					return m, openTerminalHere(i.id)
+				}
+				m.projectStatus = "Select a project to open a terminal in"
+				return m, nil
+			case "/":
+				m.projectFilter.Focus()
+				return m, textinput.Blink
 			case "?":
 				m.previousState = StateProjectList
 				m.state = StateProjectHelp
@@ -600,6 +678,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if ok && i.title == "+ New Project" {
 					m.state = StateSelectTemplate
 					m.templateList.ResetSelected()
+					m.templateStatus = ""
 					return m, nil
 				}
 			case "b": // Backup
@@ -609,12 +688,16 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if ok && i.title != "+ New Project" && i.desc == "Existing Project" {
 						m.state = StateBackupInput
 						m.pathInput.Placeholder = "Backup Destination (e.g. D:\\Backups)"
-						m.pathInput.SetValue("")
+						m.pathInput.SetValue(lastPath(pathBackup, ""))
 						m.pathInput.Focus()
 						return m, nil
 					}
 				}
 			case "esc":
+				if m.projectFilter.Value() != "" {
+					m.clearProjectFilter()
+					return m, nil
+				}
 				// Back to Top Menu
 				m.state = StateMenu
 				return m, nil
@@ -622,6 +705,15 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.projectList, cmd = m.projectList.Update(msg)
 			return m, cmd
 
+		case StateImportPath:
+			return m, m.updateImport(msg)
+
+		case StateDuplicateName:
+			return m, m.updateDuplicateName(msg)
+
+		case StateDuplicateOptions:
+			return m, m.updateDuplicateOptions(msg)
+
 		case StateBackupInput:
 			switch msg.String() {
 			case "esc":
@@ -629,6 +721,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				dest := m.pathInput.Value()
 				if dest != "" {
+					rememberPath(pathBackup, dest)
 					// Perform Backup
 					i, _ := m.projectList.SelectedItem().(item)
 					projectName := i.title
@@ -656,11 +749,47 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 
 		case StateSelectTemplate:
+			if m.templateList.FilterState() == list.Filtering {
+				m.templateList, cmd = m.templateList.Update(msg)
+				return m, cmd
+			}
 			switch msg.String() {
+			case "p", "x":
+				i, ok := m.templateList.SelectedItem().(item)
+				if !ok {
+					return m, nil
+				}
+				key, verb := templateFavoritesKey, "Pinned"
+				if msg.String() == "x" {
+					key, verb = templateHiddenKey, "Hid"
+				}
+				added, err := toggleTemplatePref(key, i.id)
+				switch {
+				case err != nil:
+					m.templateStatus = fmt.Sprintf("Failed to save preference: %v", err)
+				case added:
+					m.templateStatus = fmt.Sprintf("%s %s", verb, i.id)
+				default:
+					m.templateStatus = fmt.Sprintf("Un%s %s", strings.ToLower(verb), i.id)
+				}
+				m.refreshTemplateList(i.id)
+				return m, nil
+			case "a":
+				m.showAllTemplates = !m.showAllTemplates
+				m.templateStatus = "Hidden templates are hidden"
+				if m.showAllTemplates {
+					m.templateStatus = "Showing all templates"
+				}
+				current := ""
+				if i, ok := m.templateList.SelectedItem().(item); ok {
+					current = i.id
+				}
+				m.refreshTemplateList(current)
+				return m, nil
 			case "enter":
 				i, ok := m.templateList.SelectedItem().(item)
 				if ok {
-					m.selectedTpl = i.title
+					m.selectedTpl = i.id
 					// Smart Naming
 					suggestion := m.manager.SuggestProjectName(m.selectedTpl)
 					m.input.SetValue(suggestion)
@@ -709,9 +838,11 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.err = nil                                 // Clear error
 				m.pathInput.TextStyle = lipgloss.NewStyle() // Reset style
+				rememberPath(pathProject, pathVal)
 
 				// Create!
 				m.state = StateCreating
+				m.createdPath = ""
 				m.statusMsg = "Initializing Project..."
 				m.installOutput.Reset()
 
@@ -734,6 +865,20 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case projectDuplicatedMsg:
+		return m, m.finishDuplicate(msg)
+
+	case projectImportedMsg:
+		m.finishImport(msg)
+		return m, nil
+
+	case openTerminalMsg:
+		if msg.err != nil {
+			m.projectStatus = fmt.Sprintf("Could not open a terminal: %v", msg.err)
+			return m, nil
+		}
+		return m, notify(fmt.Sprintf("Opened %s in %s", msg.terminal, msg.dir))
+
 	case cleanupPromptMsg:
 		m.state = StateCleanupPrompt
 		return m, nil
@@ -801,7 +946,7 @@ func (m ProjectDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Resize Lists with appropriate offsets for headers/footers
 		m.menuList.SetSize(innerW, innerH-14)    // Reserve space for Big Header + Spacing
-		m.projectList.SetSize(innerW, innerH-4)  // Reserve space for Footer
+		m.projectList.SetSize(innerW, innerH-6)  // Reserve space for Footer, filter and status
 		m.templateList.SetSize(innerW, innerH-4) // Reserve space for Header
 		m.historyList.SetSize(innerW, innerH-4)  // Reserve space for Footer
 
@@ -889,14 +1034,21 @@ func (m ProjectDashboardModel) View() string {
 
 	case StateSuccess:
 		title := lipgloss.NewStyle().Foreground(colorGreen).Bold(true).Render(" PROJECT CREATED ")
-		msg := fmt.Sprintf("Your project is ready at:\n%s\n\n(Press Enter to Exit)", m.pathInput.Value())
+		path := m.pathInput.Value()
+		if m.createdPath != "" {
+			path = m.createdPath
+		}
+		msg := fmt.Sprintf("Your project is ready at:\n%s\n\n(Press Enter to Exit)", path)
 
 		content := lipgloss.JoinVertical(lipgloss.Center, title, "\n", msg)
 		innerContent = lipgloss.Place(contentWidth, contentHeight, lipgloss.Center, lipgloss.Center,
 			successBoxStyle.Render(content),
 		)
 
-	case StateNameProject, StateSelectPath, StateBackupInput:
+	case StateDuplicateOptions:
+		innerContent = lipgloss.Place(contentWidth, contentHeight, lipgloss.Center, lipgloss.Center, m.duplicateOptionsView())
+
+	case StateNameProject, StateSelectPath, StateBackupInput, StateImportPath, StateDuplicateName:
 		// Centered Card Layout for Inputs
 		var title, inputView, footer string
 
@@ -904,15 +1056,32 @@ func (m ProjectDashboardModel) View() string {
 		case StateNameProject:
 			title = "Step 1: Project Name"
 			inputView = m.input.View()
-			footer = "(Enter to Next, Esc to Back)"
+			footer = subtleStyle.Render("(Enter to Next, Esc to Back)")
 		case StateSelectPath:
 			title = "Step 2: Project Path"
 			inputView = m.pathInput.View()
-			footer = "(Enter to Create, Esc to Back)"
+			footer = subtleStyle.Render("(Enter to Create, Esc to Back)")
 		case StateBackupInput:
 			title = "Backup Project"
 			inputView = m.pathInput.View()
-			footer = "(Enter Path to Backup, Esc to Cancel)"
+			footer = subtleStyle.Render("(Enter Path to Backup, Esc to Cancel)")
+		case StateImportPath:
+			title = "Import Existing Project"
+			inputView = m.pathInput.View()
+			footer = subtleStyle.Render("(Enter to Import - nothing in the folder is changed, Esc to Cancel)")
+			if m.importing {
+				footer = subtleStyle.Render("Inspecting folder...")
+			}
+			if m.err != nil {
+				footer = errorStyle.Render(m.err.Error()) + "\n" + footer
+			}
+		case StateDuplicateName:
+			title = "Duplicate " + filepath.Base(m.dupSrc)
+			inputView = m.pathInput.View()
+			footer = subtleStyle.Render("(A bare name goes next to the original. Enter to Next, Esc to Cancel)")
+			if m.err != nil {
+				footer = errorStyle.Render(m.err.Error()) + "\n" + footer
+			}
 		}
 
 		// Calculate vertical center
@@ -921,7 +1090,7 @@ func (m ProjectDashboardModel) View() string {
 			"\n",
 			focusedInputBoxStyle.Render(inputView),
 			"\n",
-			subtleStyle.Render(footer),
+			footer,
 		)
 
 		innerContent = lipgloss.Place(contentWidth, contentHeight, lipgloss.Center, lipgloss.Center, content)
@@ -930,10 +1099,19 @@ func (m ProjectDashboardModel) View() string {
 		header := lipgloss.NewStyle().Width(contentWidth).Align(lipgloss.Center).Render(
 			titleStyle.Render("Select Project Template"),
 		)
+		showAll := "Show All"
+		if m.showAllTemplates {
+			showAll = "Hide Hidden"
+		}
+		footer := subtleStyle.Render(fmt.Sprintf("[p] Pin/Unpin • [x] Hide/Unhide • [a] %s", showAll))
+		if m.templateStatus != "" {
+			footer += subtleStyle.Render(" • " + m.templateStatus)
+		}
 		innerContent = docStyle.Render(
 			lipgloss.JoinVertical(lipgloss.Left,
 				header,
 				m.templateList.View(),
+				footer,
 			),
 		)
 
@@ -964,7 +1142,7 @@ func (m ProjectDashboardModel) View() string {
 			titleStyle.Render("Project History"),
 		)
 		listContent := m.historyList.View()
-		footer := subtleStyle.Render("\n [d] Delete Entry • [?] Help • [Esc] Back")
+		footer := "\n " + renderKeyFooter(contentWidth, []keyHint{{"d", "Delete Entry"}, {"?", "Help"}, {"Esc", "Back"}})
 
 		// Align with other list views style if needed, or simple render
 		innerContent = docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, header, listContent, footer))
@@ -982,7 +1160,17 @@ func (m ProjectDashboardModel) View() string {
 	default:
 		// Default List View (Select Template)
 		listContent := m.projectList.View()
-		footer := subtleStyle.Render("\n [Enter] Select • [b] Backup Project • [?] Help • [Esc] Back")
+		if filter := m.projectFilterLine(); filter != "" {
+			listContent = lipgloss.JoinVertical(lipgloss.Left, filter, listContent)
+		}
+		hints := []keyHint{{"Enter", "Select"}, {"/", "Filter"}, {"i", "Import Existing"}, {"d", "Duplicate"}, {"b", "Backup Project"}, {"r", "Refresh Git"}, {"t", "Terminal Here"}, {"?", "Help"}, {"Esc", "Back"}}
+		if m.projectFilter.Focused() {
+			hints = []keyHint{{"↑/↓", "Navigate"}, {"Enter", "Apply Filter"}, {"Esc", "Clear Filter"}}
+		}
+		footer := "\n " + renderKeyFooter(contentWidth, hints)
+		if m.projectStatus != "" {
+			footer = "\n " + subtleStyle.Render(m.projectStatus) + footer
+		}
 		innerContent = docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, listContent, footer))
 	}
 	return innerContent
@@ -995,3 +1183,16 @@ func RunProjectDashboard() {
 		fmt.Println("Error:", err)
 	}
 }
+
+// refreshTemplateList reloads the template picker after a preference
+// change, keeping the cursor on the template with id selected.
+func (m *ProjectDashboardModel) refreshTemplateList(selected string) {
+	items := templateItems(m.showAllTemplates)
+	m.templateList.SetItems(items)
+	for idx, it := range items {
+		if i, ok := it.(item); ok && i.id == selected {
+			m.templateList.Select(idx)
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/project"
+)
+
+// projectDuplicatedMsg reports the end of a copy started from the project
+// list; installCmd is set when dependencies should be installed next
+type projectDuplicatedMsg struct {
+	path       string
+	installCmd string
+	err        error
+}
+
+// startDuplicate asks for the name of a copy of the selected project
+func (m *ProjectDashboardModel) startDuplicate() tea.Cmd {
+	i, ok := m.projectList.SelectedItem().(item)
+	if !ok || i.id == "" {
+		m.projectStatus = "Select a project to duplicate"
+		return nil
+	}
+	m.dupSrc = i.id
+	m.dupGit, m.dupInstall = true, true
+	m.state = StateDuplicateName
+	m.err = nil
+	m.pathInput.Placeholder = "Name of the copy, or a full path"
+	m.pathInput.SetValue(project.SuggestDuplicateName(i.id))
+	m.pathInput.CursorEnd()
+	m.pathInput.Focus()
+	return textinput.Blink
+}
+
+func (m *ProjectDashboardModel) updateDuplicateName(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.endDuplicate()
+		return nil
+	case "enter":
+		dest, err := m.manager.DuplicateTarget(m.dupSrc, m.pathInput.Value())
+		if err != nil {
+			m.err = err
+			return nil
+		}
+		m.err = nil
+		m.dupDest = dest
+		m.state = StateDuplicateOptions
+		return nil
+	}
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return cmd
+}
+
+func (m *ProjectDashboardModel) updateDuplicateOptions(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDuplicateName
+	case "g":
+		m.dupGit = !m.dupGit
+	case "i":
+		m.dupInstall = !m.dupInstall
+	case "enter":
+		src, dest, reinitGit, install := m.dupSrc, m.dupDest, m.dupGit, m.dupInstall
+		m.state = StateCreating
+		m.statusMsg = "Duplicating project..."
+		m.createdPath = dest
+		m.installOutput.Reset()
+		m.installOutput.WriteString(fmt.Sprintf("PROJECT DUPLICATION LOG\n========================\nFrom : %s\nTo   : %s\nTime : %s\nSkip : %s\n========================\n\n",
+			src, dest, time.Now().Format("2006-01-02 15:04:05"), strings.Join(project.DuplicateExcludes(), ", ")))
+		m.installView.SetContent(m.installOutput.String())
+		mgr := m.manager
+		return func() tea.Msg {
+			if err := mgr.DuplicateProject(src, dest, reinitGit); err != nil {
+				return projectDuplicatedMsg{path: dest, err: err}
+			}
+			cmd := ""
+			if install {
+				cmd = project.InstallCommand(dest)
+			}
+			return projectDuplicatedMsg{path: dest, installCmd: cmd}
+		}
+	}
+	return nil
+}
+
+// finishDuplicate logs the copy and starts the install command, if any
+func (m *ProjectDashboardModel) finishDuplicate(msg projectDuplicatedMsg) tea.Cmd {
+	m.endDuplicate()
+	m.reloadProjects()
+	if msg.err != nil {
+		m.projectStatus = fmt.Sprintf("Duplicate failed: %v", msg.err)
+		return nil
+	}
+	if msg.installCmd == "" {
+		m.projectStatus = fmt.Sprintf("Duplicated to %s", msg.path)
+		return nil
+	}
+	m.state = StateCreating
+	m.installOutput.WriteString(fmt.Sprintf("Project copied to %s\nPreparing to install dependencies...\n", msg.path))
+	m.installView.SetContent(m.installOutput.String())
+	m.statusMsg = "Starting installation..."
+	return startInstallCmd(msg.path, msg.installCmd)
+}
+
+// endDuplicate returns to the project list, restoring the path input the
+// creation wizard shares
+func (m *ProjectDashboardModel) endDuplicate() {
+	m.state = StateProjectList
+	m.err = nil
+	m.pathInput.Placeholder = "Parent Directory (e.g. C:\\Projects or ~)"
+	m.pathInput.SetValue(lastPath(pathProject, m.manager.Workspace))
+}
+
+// duplicateOptionsView is the confirmation card shown before copying
+func (m ProjectDashboardModel) duplicateOptionsView() string {
+	check := func(on bool) string {
+		if on {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	install := project.InstallCommand(m.dupSrc)
+	if install == "" {
+		install = "nothing detected to install"
+	}
+	lines := []string{
+		fmt.Sprintf("From: %s", m.dupSrc),
+		fmt.Sprintf("To:   %s", m.dupDest),
+		"",
+		fmt.Sprintf("Skipped folders: %s", strings.Join(project.DuplicateExcludes(), ", ")),
+		"",
+		fmt.Sprintf("%s (g) Initialize a new git repository", check(m.dupGit)),
+		fmt.Sprintf("%s (i) Install dependencies (%s)", check(m.dupInstall), install),
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Duplicate "+filepath.Base(m.dupSrc)),
+		"",
+		focusedInputBoxStyle.Align(lipgloss.Left).Render(strings.Join(lines, "\n")),
+		"",
+		subtleStyle.Render("(g/i to toggle, Enter to Duplicate, Esc to Back)"),
+	)
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var newProjectItem = item{title: "+ New Project", desc: "Create a new project from template"}
+
+func newProjectFilterInput() textinput.Model {
+	fi := textinput.New()
+	fi.Prompt = "/ "
+	fi.Placeholder = "Filter by name or stack (e.g. api, react)"
+	fi.CharLimit = 64
+	fi.Width = 40
+	return fi
+}
+
+// reloadProjects rescans the workspace and reapplies the current filter
+func (m *ProjectDashboardModel) reloadProjects() {
+	m.allProjects = loadProjects(m.manager.Workspace)
+	m.applyProjectFilter()
+}
+
+// applyProjectFilter fuzzy-matches the filter against each project's name
+// and stack. "+ New Project" stays pinned at the top.
+func (m *ProjectDashboardModel) applyProjectFilter() {
+	items := []list.Item{newProjectItem}
+	query := m.projectFilter.Value()
+	if query == "" {
+		items = append(items, m.allProjects...)
+	} else {
+		targets := make([]string, len(m.allProjects))
+		for i, it := range m.allProjects {
+			p := it.(item)
+			targets[i] = p.title + " " + p.tags
+		}
+		for _, r := range list.DefaultFilter(query, targets) {
+			items = append(items, m.allProjects[r.Index])
+		}
+	}
+	m.projectList.SetItems(items)
+	m.projectList.ResetSelected()
+}
+
+// updateProjectFilter handles keys while the filter input has focus. Enter
+// keeps the filter and returns to the list; Esc clears it.
+func (m *ProjectDashboardModel) updateProjectFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "down":
+		var cmd tea.Cmd
+		m.projectList, cmd = m.projectList.Update(msg)
+		return cmd
+	case "enter":
+		m.projectFilter.Blur()
+		return nil
+	case "esc":
+		m.clearProjectFilter()
+		return nil
+	}
+	before := m.projectFilter.Value()
+	var cmd tea.Cmd
+	m.projectFilter, cmd = m.projectFilter.Update(msg)
+	if m.projectFilter.Value() != before {
+		m.applyProjectFilter()
+	}
+	return cmd
+}
+
+func (m *ProjectDashboardModel) clearProjectFilter() {
+	m.projectFilter.Blur()
+	m.projectFilter.Reset()
+	m.applyProjectFilter()
+}
+
+// projectFilterLine is shown above the list while a filter is being typed
+// or is active
+func (m ProjectDashboardModel) projectFilterLine() string {
+	if !m.projectFilter.Focused() && m.projectFilter.Value() == "" {
+		return ""
+	}
+	line := m.projectFilter.View()
+	if m.projectFilter.Value() != "" {
+		line += fmt.Sprintf("  (%d of %d)", len(m.projectList.Items())-1, len(m.allProjects))
+	}
+	return line
+}
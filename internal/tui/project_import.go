@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/devserver"
+	"github.com/phravins/devcli/internal/project"
+)
+
+// projectImportedMsg carries the result of inspecting a folder to import
+type projectImportedMsg struct {
+	project project.ImportedProject
+	err     error
+}
+
+// startImport asks for the folder of an existing project, defaulting to the
+// current directory
+func (m *ProjectDashboardModel) startImport() tea.Cmd {
+	cwd, _ := os.Getwd()
+	m.state = StateImportPath
+	m.err = nil
+	m.pathInput.Placeholder = "Existing Project Folder (e.g. ~/code/api)"
+	m.pathInput.SetValue(lastPath(pathImport, cwd))
+	m.pathInput.CursorEnd()
+	m.pathInput.Focus()
+	return textinput.Blink
+}
+
+func (m *ProjectDashboardModel) updateImport(msg tea.KeyMsg) tea.Cmd {
+	if m.importing {
+		return nil // Wait for the folder to be inspected
+	}
+	switch msg.String() {
+	case "esc":
+		m.endImport()
+		return nil
+	case "enter":
+		// Sizing node_modules or a .venv can take a while: keep the UI live
+		m.importing = true
+		m.err = nil
+		mgr, dir := m.manager, m.pathInput.Value()
+		return func() tea.Msg {
+			p, err := mgr.InspectProject(dir)
+			return projectImportedMsg{project: p, err: err}
+		}
+	}
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return cmd
+}
+
+// finishImport registers the inspected project and shows what was found
+func (m *ProjectDashboardModel) finishImport(msg projectImportedMsg) {
+	m.importing = false
+	if msg.err == nil {
+		msg.err = project.RegisterImport(msg.project)
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		return
+	}
+	rememberPath(pathImport, filepath.Dir(msg.project.Path))
+	m.endImport()
+	m.reloadProjects()
+	m.projectStatus = importSummary(msg.project)
+}
+
+// endImport returns to the project list, restoring the path input the
+// creation wizard shares
+func (m *ProjectDashboardModel) endImport() {
+	m.state = StateProjectList
+	m.err = nil
+	m.pathInput.Placeholder = "Parent Directory (e.g. C:\\Projects or ~)"
+	m.pathInput.SetValue(lastPath(pathProject, m.manager.Workspace))
+}
+
+// importSummary reports what was detected in an imported project
+func importSummary(p project.ImportedProject) string {
+	parts := []string{"Imported " + p.Name}
+	if p.Info.Type != devserver.TypeUnknown {
+		parts[0] += fmt.Sprintf(" (%s)", p.Info.Type)
+	}
+	for _, srv := range p.Info.Servers {
+		parts = append(parts, "dev server: "+srv.CommandLine())
+	}
+	for _, env := range p.Envs {
+		parts = append(parts, fmt.Sprintf("%s: %s", env.Type, env.Path))
+	}
+	return strings.Join(parts, " • ")
+}
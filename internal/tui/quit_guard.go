@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
+)
+
+// confirmQuitKey turns the quit confirmation off when set to "false"
+const confirmQuitKey = "ui.confirm_quit"
+
+// quitGrace is how long dev servers get to shut down after a confirmed quit
+const quitGrace = 3 * time.Second
+
+// quitRequestMsg replaces a quit that would stop servers or lose edits
+type quitRequestMsg struct{ reason string }
+
+// quitFilter holds back tea.Quit from any screen of the root TUI while
+// processes are running or editor buffers are unsaved, and asks first
+func quitFilter(m tea.Model, msg tea.Msg) tea.Msg {
+	if _, ok := msg.(tea.QuitMsg); !ok {
+		return msg
+	}
+	root, ok := m.(RootModel)
+	if !ok || root.quitConfirmed || config.GetString(confirmQuitKey) == "false" {
+		return msg
+	}
+	if reason := root.quitRisk(); reason != "" {
+		return quitRequestMsg{reason: reason}
+	}
+	return msg
+}
+
+// quitRisk describes what quitting now would stop or throw away
+func (m RootModel) quitRisk() string {
+	var risks []string
+	if n := len(procs.Running()); n > 0 {
+		risks = append(risks, fmt.Sprintf("%d running process(es) such as dev servers will be stopped", n))
+	}
+	if m.state == StateEditor && !m.editor.quitConfirmed { // The editor already asked
+		if n := m.editor.dirtyTabs(); n > 0 {
+			risks = append(risks, fmt.Sprintf("%d editor buffer(s) with unsaved changes will be lost", n))
+		}
+	}
+	return strings.Join(risks, "\n")
+}
+
+// updateQuitPrompt handles keys while the confirmation is shown
+func (m RootModel) updateQuitPrompt(msg tea.KeyMsg) (RootModel, tea.Cmd) {
+	if m.quitConfirmed {
+		return m, nil // Already shutting down
+	}
+	switch msg.String() {
+	case "y", "Y", "enter", "ctrl+c":
+		m.quitConfirmed = true
+		return m, func() tea.Msg {
+			procs.StopAll(quitGrace)
+			return tea.QuitMsg{}
+		}
+	case "n", "N", "esc":
+		m.quitPrompt = ""
+		// Screens that blank themselves on quit come back
+		m.dashboard.quitting = false
+		m.fileManager.quitting = false
+	}
+	return m, nil
+}
+
+// quitPromptView is the confirmation dialog, or the shutdown notice once
+// the quit is confirmed
+func (m RootModel) quitPromptView() string {
+	text := "Really quit?\n\n" + m.quitPrompt + "\n\n" +
+		"y / Enter: Quit   n / Esc: Stay\n" +
+		subtleStyle.Render(fmt.Sprintf("Set %s: false in the config to quit without asking", confirmQuitKey))
+	if m.quitConfirmed {
+		text = fmt.Sprintf("Stopping running processes...\n\nWaiting up to %s for dev servers to shut down", quitGrace)
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, errorBoxStyle.Render(text))
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
 )
 
 // Global States
@@ -23,6 +25,13 @@ type SwitchViewMsg struct {
 	Args        interface{} // Generic args (e.g., initial path)
 }
 
+// editorTarget opens the editor at a position of a file when passed as
+// the Args of a SwitchViewMsg to StateEditor; a string opens it at the top
+type editorTarget struct {
+	path      string
+	line, col int // 1-based
+}
+
 type BackMsg struct{}
 
 // Feature-specific Back Messages for nested navigation
@@ -47,6 +56,12 @@ type RootModel struct {
 
 	// Generic error
 	err error
+
+	// Quit confirmation (see quitFilter)
+	quitPrompt    string // What quitting would stop or lose; non-empty while asking
+	quitConfirmed bool
+
+	toast toaster
 }
 
 func NewRootModel() RootModel {
@@ -67,12 +82,29 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if cmd, ok := m.toast.update(msg); ok {
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		// We do NOT manually propagate here. The active model will receive it in the switch below.
 
+	case quitRequestMsg:
+		m.quitPrompt = msg.reason
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.quitPrompt != "" {
+			return m.updateQuitPrompt(msg)
+		}
+		if msg.String() == keyFooterToggleKey {
+			toggleKeyFooter()
+			return m, nil
+		}
+
 	case SwitchViewMsg:
 		m.state = msg.TargetState
 
@@ -99,13 +131,19 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case StateEditor:
 			filename := ""
+			target, hasTarget := msg.Args.(editorTarget)
 			if f, ok := msg.Args.(string); ok {
 				filename = f
+			} else if hasTarget {
+				filename = target.path
 			}
 			m.editor = initialModel(filename)
 			var em tea.Model
 			em, cmd = m.editor.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 			m.editor = em.(model)
+			if hasTarget {
+				m.editor.gotoLineCol(target.line, target.col)
+			}
 			cmds = append(cmds, cmd, m.editor.Init())
 
 		case StateProject:
@@ -161,6 +199,14 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m RootModel) View() string {
+	if m.quitPrompt != "" {
+		return m.quitPromptView()
+	}
+	return m.toast.overlay(m.stateView(), m.width, m.height)
+}
+
+// stateView renders the active screen
+func (m RootModel) stateView() string {
 	switch m.state {
 	case StateDashboard:
 		return m.dashboard.View()
@@ -179,9 +225,11 @@ func (m RootModel) View() string {
 }
 
 func RunRoot() {
-	p := tea.NewProgram(NewRootModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	config.LoadConfig() // ui.confirm_quit
+	p := tea.NewProgram(NewRootModel(), tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFilter(quitFilter))
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running devcli: %v\n", err)
+		procs.KillAll()
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,19 @@
+package tui
+
+import "github.com/phravins/devcli/internal/runner"
+
+// Runner options spliced into the editor's compile/run commands
+var runnerSettings = []configSetting{
+	{"runner.python_bin", "Python Binary: ", "python3 / C:\\Python312\\python.exe", validateExecutable("runner.python_bin")},
+	{"runner.cpp_flags", "C++ Flags: ", "-O2 -std=c++17 -Wall", validateFlags("runner.cpp_flags")},
+	{"runner.c_flags", "C Flags: ", "-O2 -std=c11 -lm", validateFlags("runner.c_flags")},
+	{"runner.rust_flags", "Rust Flags: ", "-O -C debuginfo=0", validateFlags("runner.rust_flags")},
+	{"runner.java_flags", "Javac Flags: ", "-Xlint:all", validateFlags("runner.java_flags")},
+}
+
+// validateFlags checks a compiler flag list before it reaches exec
+func validateFlags(key string) func(string) error {
+	return func(value string) error {
+		return runner.ValidateFlags(key, value)
+	}
+}
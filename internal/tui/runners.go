@@ -5,17 +5,22 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/procs"
 )
 
 func RunDevServer(path string) {
 	if path == "" {
-		path, _ = os.Getwd()
+		cwd, _ := os.Getwd()
+		path = lastPath(pathDevServer, cwd)
 	}
 	p := tea.NewProgram(Wrap(NewDevServerDashboardModel(path)), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running dev server dashboard: %v\n", err)
+		procs.KillAll()
 		os.Exit(1)
 	}
+	// Standalone there is no quit guard to stop the servers
+	procs.StopAll(quitGrace)
 }
 
 func RunFileManager(path string) {
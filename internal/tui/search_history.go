@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Config keys for the File Manager search history. Persisting is opt-in;
+// otherwise the history only lives for the current session.
+const (
+	searchHistoryKey        = "filemanager.search_history"
+	searchHistoryPersistKey = "filemanager.persist_search_history"
+	maxSearchHistory        = 20
+)
+
+// Recent File Manager queries, newest first. Kept at package level so the
+// history survives leaving and re-opening the File Manager.
+var (
+	searchHistory       []string
+	searchHistoryLoaded bool
+)
+
+func persistSearchHistory() bool {
+	return strings.EqualFold(config.GetString(searchHistoryPersistKey), "true")
+}
+
+// recentSearches returns the history, loading the persisted copy on first use
+func recentSearches() []string {
+	if !searchHistoryLoaded {
+		searchHistoryLoaded = true
+		if persistSearchHistory() {
+			searchHistory = config.GetStringSlice(searchHistoryKey)
+			if len(searchHistory) > maxSearchHistory {
+				searchHistory = searchHistory[:maxSearchHistory]
+			}
+		}
+	}
+	return searchHistory
+}
+
+// recordSearch moves query to the front of the history, dropping any
+// earlier copy and trimming the list to maxSearchHistory entries.
+func recordSearch(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+	history := []string{query}
+	for _, q := range recentSearches() {
+		if q != query && len(history) < maxSearchHistory {
+			history = append(history, q)
+		}
+	}
+	searchHistory = history
+	if persistSearchHistory() {
+		config.SaveConfig(searchHistoryKey, history)
+	}
+}
+
+// recallSearch steps through the history (delta 1 = older, -1 = newer)
+// and loads the entry into the search box. Stepping past the newest entry
+// restores whatever was typed before browsing started.
+func (m *FileManagerModel) recallSearch(delta int) bool {
+	history := recentSearches()
+	if len(history) == 0 {
+		return false
+	}
+	if m.historyIdx < 0 {
+		if delta < 0 {
+			return false
+		}
+		m.historyDraft = m.searchInput.Value()
+	}
+
+	idx := m.historyIdx + delta
+	if idx >= len(history) {
+		idx = len(history) - 1
+	}
+	m.historyIdx = idx
+	if idx < 0 {
+		m.historyIdx = -1
+		m.searchInput.SetValue(m.historyDraft)
+	} else {
+		m.searchInput.SetValue(history[idx])
+	}
+	m.searchInput.CursorEnd()
+	return true
+}
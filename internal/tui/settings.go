@@ -13,6 +13,9 @@ import (
 	"github.com/phravins/devcli/internal/config"
 )
 
+// Index of the first settingFields input; the AI fields come before it
+const settingInputOffset = 4
+
 type SettingsModel struct {
 	inputs     []textinput.Model
 	focusedIdx int
@@ -24,12 +27,24 @@ type SettingsModel struct {
 	height     int
 	helpView   viewport.Model
 	mainView   viewport.Model
+
+	// Pending "Reset settings" (Ctrl+R) awaiting y/n
+	resetPlan []config.Change
+
+	// Last model list (Ctrl+L) and the backend it was fetched for
+	listingModels bool
+	models        []string
+	modelsErr     error
+	modelsFor     string
 }
 
+// resetSection is the config section Ctrl+R restores
+const resetSection = "ui"
+
 func NewSettingsModel() SettingsModel {
 	cfg, _ := config.LoadConfig()
 
-	inputs := make([]textinput.Model, 4)
+	inputs := make([]textinput.Model, settingInputOffset+len(settingFields))
 
 	// AI Backend
 	inputs[0] = textinput.New()
@@ -78,6 +93,17 @@ func NewSettingsModel() SettingsModel {
 	inputs[3].CharLimit = 100
 	inputs[3].Width = 50
 
+	// Runner, editor and general options
+	for i, rs := range settingFields {
+		in := textinput.New()
+		in.Placeholder = rs.placeholder
+		in.Prompt = rs.label
+		in.SetValue(config.GetString(rs.key))
+		in.CharLimit = 200
+		in.Width = 40
+		inputs[settingInputOffset+i] = in
+	}
+
 	// Help Viewport
 	hv := viewport.New(100, 40)
 	hv.Style = lipgloss.NewStyle().
@@ -130,6 +156,12 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateMainViewContent()
 		return m, nil
 
+	case settingsModelsMsg:
+		m.listingModels = false
+		m.models, m.modelsErr, m.modelsFor = msg.models, msg.err, msg.endpoint
+		m.updateMainViewContent()
+		return m, nil
+
 	case tea.KeyMsg:
 		// Help screen handler
 		if m.showHelp {
@@ -144,7 +176,29 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.resetPlan != nil {
+			switch msg.String() {
+			case "y", "Y":
+				m.applyReset()
+			case "n", "N", "esc":
+				m.resetPlan = nil
+			}
+			m.updateMainViewContent()
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "ctrl+r":
+			m.planReset()
+			m.updateMainViewContent()
+			return m, nil
+		case "ctrl+l":
+			if m.listingModels {
+				return m, nil
+			}
+			cmd := m.listModels()
+			m.updateMainViewContent()
+			return m, cmd
 		case "?":
 			m.showHelp = true
 			m.helpView.GotoTop()
@@ -264,7 +318,16 @@ func (m *SettingsModel) updateMainViewContent() {
 	b.WriteString(lipgloss.NewStyle().Align(lipgloss.Center).Width(54).Render(title))
 	b.WriteString("\n\n")
 
+	if m.resetPlan != nil {
+		b.WriteString(m.resetConfirmView())
+		m.mainView.SetContent(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card.Render(b.String())))
+		return
+	}
+
 	for i := range m.inputs {
+		if title, ok := sectionTitleAt(i - settingInputOffset); ok {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true).Render(title) + "\n\n")
+		}
 		b.WriteString(m.inputs[i].View())
 		if i < len(m.inputs)-1 {
 			b.WriteString("\n\n") // More spacing
@@ -293,6 +356,9 @@ func (m *SettingsModel) updateMainViewContent() {
 	}
 	b.WriteString(button)
 
+	if models := m.modelsView(); models != "" {
+		b.WriteString("\n\n" + models)
+	}
 	if m.successMsg != "" {
 		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Align(lipgloss.Center).Width(54).Render(m.successMsg))
 	}
@@ -300,7 +366,7 @@ func (m *SettingsModel) updateMainViewContent() {
 		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Align(lipgloss.Center).Width(54).Render(m.err.Error()))
 	}
 
-	help := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Align(lipgloss.Center).Width(54).Render("Esc to Cancel • Tab to Navigate • [?] Help")
+	help := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Align(lipgloss.Center).Width(54).Render("Esc Cancel • Tab Navigate • Ctrl+L Models • Ctrl+R Reset UI • [?] Help")
 	b.WriteString("\n\n" + help)
 
 	// Wrap everything in a nice centered box
@@ -335,11 +401,18 @@ func (m *SettingsModel) saveConfig() {
 
 	config.Set("ai_base_url", strings.TrimSpace(m.inputs[3].Value()))
 
+	for i, rs := range settingFields {
+		config.Set(rs.key, strings.TrimSpace(m.inputs[settingInputOffset+i].Value()))
+	}
+
 	if err := config.Write(); err != nil {
 		m.err = err
 		m.successMsg = ""
 	} else {
 		m.successMsg = "Configuration Saved Successfully!"
+		if warning := m.modelWarning(); warning != "" {
+			m.successMsg += "\n(" + warning + ")"
+		}
 		m.err = nil
 	}
 }
@@ -368,6 +441,12 @@ func (m *SettingsModel) validateInputs() error {
 		}
 	}
 
+	for i, rs := range settingFields {
+		if err := rs.check(m.inputs[settingInputOffset+i].Value()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
+)
+
+// configSetting is a config key edited as a text field in Settings
+type configSetting struct {
+	key         string
+	label       string
+	placeholder string
+	validate    func(value string) error // Called with a trimmed, non-empty value
+}
+
+// check validates value before it is saved; empty always means "default"
+func (s configSetting) check(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" || s.validate == nil {
+		return nil
+	}
+	return s.validate(value)
+}
+
+// Editor options: run time limit, auto-save and indentation
+var editorSettings = []configSetting{
+	{runTimeoutKey, "Editor Run Timeout: ", "30s / 2m (empty = command timeout or 30s, 0 = no limit)", validateTimeout},
+	{autosaveIntervalKey, "Editor Auto-save: ", "30s / 2m (empty or 0 = off)", validateAutosaveInterval},
+	{indentStyleKey, "Indent Style: ", "spaces / tabs (empty = spaces; .editorconfig wins)", validateIndentStyle},
+	{indentSizeKey, "Indent Size: ", "2 / 4 / 8 spaces (empty = 4)", validateIntRange(indentSizeKey, 1, 16)},
+}
+
+// Options shared by all of DevCLI: the shell used by the Ctrl+P prompt and
+// web terminal, the limit for one-shot commands and the quit confirmation
+var generalSettings = []configSetting{
+	{config.ShellKey, "Shell: ", "pwsh / bash / zsh / fish (empty = platform default)", validateExecutable(config.ShellKey)},
+	{procs.TimeoutKey, "Command Timeout: ", "90s / 5m (empty or 0 = no limit; dev servers exempt)", validateTimeout},
+	{confirmQuitKey, "Confirm Quit: ", "true / false (ask before quitting with running servers or unsaved edits)", validateOneOf(confirmQuitKey, "true", "false")},
+}
+
+// settingSection is a titled group of fields on the Settings screen
+type settingSection struct {
+	title  string
+	fields []configSetting
+}
+
+var settingSections = []settingSection{
+	{"RUNNER (editor compile/run)", runnerSettings},
+	{"EDITOR", editorSettings},
+	{"GENERAL", generalSettings},
+}
+
+// settingFields lists the fields of every section in display order; the
+// inputs after the AI fields follow this order
+var settingFields = func() []configSetting {
+	var fields []configSetting
+	for _, s := range settingSections {
+		fields = append(fields, s.fields...)
+	}
+	return fields
+}()
+
+// sectionTitleAt returns the heading shown above settingFields[i], if any
+func sectionTitleAt(i int) (string, bool) {
+	start := 0
+	for _, s := range settingSections {
+		if i == start {
+			return s.title, true
+		}
+		start += len(s.fields)
+	}
+	return "", false
+}
+
+func validateTimeout(value string) error {
+	_, err := procs.ParseTimeout(value)
+	return err
+}
+
+func validateAutosaveInterval(value string) error {
+	if _, err := procs.ParseTimeout(value); err != nil {
+		return fmt.Errorf("invalid auto-save interval %q: use a duration like 30s or 2m", value)
+	}
+	return nil
+}
+
+// validateExecutable accepts paths passed straight to exec, such as the
+// interpreter and shell
+func validateExecutable(key string) func(string) error {
+	return func(value string) error {
+		if _, err := exec.LookPath(value); err != nil {
+			return fmt.Errorf("%s: %q is not an executable", key, value)
+		}
+		return nil
+	}
+}
+
+// validateIndentStyle accepts any case, as the indent setting is read that way
+func validateIndentStyle(value string) error {
+	return validateOneOf(indentStyleKey, "spaces", "tabs")(strings.ToLower(value))
+}
+
+func validateOneOf(key string, allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be %s", key, strings.Join(allowed, " or "))
+	}
+}
+
+func validateIntRange(key string, lo, hi int) func(string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(value); err != nil || n < lo || n > hi {
+			return fmt.Errorf("%s must be a number from %d to %d", key, lo, hi)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/ai/providers"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// Models shown under the form; the rest are counted
+const maxListedModels = 8
+
+// settingsModelsMsg carries the answer of the provider's models endpoint
+type settingsModelsMsg struct {
+	endpoint string
+	models   []string
+	err      error
+}
+
+// aiConfigFromInputs builds a config from the (unsaved) AI fields
+func (m SettingsModel) aiConfigFromInputs() *config.Config {
+	key := strings.TrimSpace(m.inputs[2].Value())
+	return &config.Config{
+		AIBackend:    strings.TrimSpace(m.inputs[0].Value()),
+		AIModel:      strings.TrimSpace(m.inputs[1].Value()),
+		AIAPIKey:     key,
+		GeminiAPIKey: key,
+		AIBaseURL:    strings.TrimSpace(m.inputs[3].Value()),
+	}
+}
+
+// modelsEndpoint identifies the backend a model list belongs to, so a list
+// is not used to judge a model of another backend
+func (m SettingsModel) modelsEndpoint() string {
+	return strings.ToLower(strings.TrimSpace(m.inputs[0].Value())) + " " + strings.TrimSpace(m.inputs[3].Value())
+}
+
+// listModels handles Ctrl+L: the models endpoint is asked in the background
+func (m *SettingsModel) listModels() tea.Cmd {
+	if strings.TrimSpace(m.inputs[0].Value()) == "" {
+		m.err, m.successMsg = fmt.Errorf("backend cannot be empty"), ""
+		return nil
+	}
+	cfg, endpoint := m.aiConfigFromInputs(), m.modelsEndpoint()
+	m.listingModels = true
+	return func() tea.Msg {
+		models, err := providers.ListModels(cfg)
+		return settingsModelsMsg{endpoint: endpoint, models: models, err: err}
+	}
+}
+
+// modelWarning is set when a list for the current backend is known and
+// does not contain the entered model
+func (m SettingsModel) modelWarning() string {
+	model := strings.TrimSpace(m.inputs[1].Value())
+	if model == "" || m.modelsErr != nil || len(m.models) == 0 || m.modelsFor != m.modelsEndpoint() {
+		return ""
+	}
+	if providers.HasModel(m.models, model) {
+		return ""
+	}
+	return fmt.Sprintf("Model %q is not in the provider's list", model)
+}
+
+// modelsView shows the last model list (or why there is none) under the form
+func (m SettingsModel) modelsView() string {
+	if m.listingModels {
+		return subtleStyle.Render("Listing models...")
+	}
+	if m.modelsFor == "" || m.modelsFor != m.modelsEndpoint() {
+		return ""
+	}
+	if m.modelsErr != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Width(54).
+			Render("Could not list models: " + m.modelsErr.Error() + "\nSettings can still be saved.")
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true).Render(fmt.Sprintf("AVAILABLE MODELS (%d)", len(m.models))) + "\n")
+	shown := m.models
+	if len(shown) > maxListedModels {
+		shown = shown[:maxListedModels]
+	}
+	for _, name := range shown {
+		b.WriteString("  " + name + "\n")
+	}
+	if more := len(m.models) - len(shown); more > 0 {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("  ... and %d more", more)) + "\n")
+	}
+	if warning := m.modelWarning(); warning != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("! "+warning) + "\n")
+	}
+	return lipgloss.NewStyle().Width(54).Render(strings.TrimRight(b.String(), "\n"))
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/config"
+)
+
+// planReset lists what Ctrl+R would change and asks to confirm
+func (m *SettingsModel) planReset() {
+	changes, err := config.PlanReset(resetSection)
+	m.err, m.successMsg = err, ""
+	if err != nil {
+		return
+	}
+	if len(changes) == 0 {
+		m.successMsg = "Editor/UI settings already use their defaults"
+		return
+	}
+	m.resetPlan = changes
+}
+
+// applyReset restores the editor/UI defaults and refreshes the fields
+// showing one of them. AI and runner fields keep their (unsaved) values.
+func (m *SettingsModel) applyReset() {
+	changes := m.resetPlan
+	m.resetPlan = nil
+	if _, err := config.Reset(resetSection); err != nil {
+		m.err, m.successMsg = err, ""
+		return
+	}
+	for _, c := range changes {
+		for i, rs := range settingFields {
+			if rs.key == c.Key {
+				m.inputs[settingInputOffset+i].SetValue(config.GetString(rs.key))
+			}
+		}
+	}
+	m.err = nil
+	m.successMsg = fmt.Sprintf("Reset %d editor/UI setting(s) to defaults", len(changes))
+}
+
+// resetConfirmView lists the pending changes as key: old -> default
+func (m SettingsModel) resetConfirmView() string {
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	oldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	newStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Reset editor/UI settings to their defaults?") + "\n")
+	b.WriteString(subtleStyle.Render("AI keys, profiles and runner options are kept.") + "\n\n")
+	for _, c := range m.resetPlan {
+		b.WriteString(keyStyle.Render(c.Key) + "\n")
+		b.WriteString("  " + oldStyle.Render(c.Old) + " -> " + newStyle.Render(c.New) + "\n")
+	}
+	b.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Align(lipgloss.Center).Width(54).Render("[y] Reset • [n/Esc] Cancel"))
+	return lipgloss.NewStyle().Width(54).Render(b.String())
+}
@@ -103,13 +103,10 @@ func NewSnippetsModel() SnippetsModel {
 
 func (m SnippetsModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		snips, err := m.storage.LoadAll()
+		snips, err := m.storage.Library()
 		if err != nil {
 			return snippetsLoadedMsg{err: err}
 		}
-		if len(snips) == 0 {
-			snips = snippets.GetDefaultSnippets()
-		}
 		return snippetsLoadedMsg{snippets: snips}
 	}
 }
@@ -2,8 +2,8 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -19,7 +19,10 @@ type TaskRunnerModel struct {
 	tasks       []taskrunner.Task
 	list        list.Model
 	running     bool
-	output      *strings.Builder
+	cancelling  bool // Ctrl+C was pressed; waiting for the task to exit
+	runID       int  // Tags output so a finished run's leftovers are dropped
+	taskErr     error
+	output      *taskrunner.Output
 	outputView  viewport.Model
 	helpView    viewport.Model
 	currentTask *taskrunner.Task
@@ -42,9 +45,29 @@ type tasksDetectedMsg struct {
 	tasks []taskrunner.Task
 }
 
-type taskOutputMsg string
-type taskCompleteMsg struct {
-	err error
+// maxTaskOutputLines is how much task output the viewport keeps
+const maxTaskOutputLines = 2000
+
+// taskOutputMsg carries a batch of output lines of run runID; done is set
+// with the task's error once it has exited
+type taskOutputMsg struct {
+	runID int
+	lines []string
+	done  bool
+	err   error
+	ch    chan string
+	errc  chan error
+}
+
+func waitForTaskOutput(runID int, ch chan string, errc chan error) tea.Cmd {
+	return func() tea.Msg {
+		lines, open := collectBatch(ch)
+		msg := taskOutputMsg{runID: runID, lines: lines, ch: ch, errc: errc}
+		if !open {
+			msg.done, msg.err = true, <-errc
+		}
+		return msg
+	}
 }
 
 func NewTaskRunnerModel(workspace string) TaskRunnerModel {
@@ -55,7 +78,7 @@ func NewTaskRunnerModel(workspace string) TaskRunnerModel {
 	return TaskRunnerModel{
 		workspace:  workspace,
 		list:       list.New([]list.Item{}, list.NewDefaultDelegate(), 60, 14),
-		output:     &strings.Builder{},
+		output:     taskrunner.NewOutput(maxTaskOutputLines),
 		outputView: viewport.New(80, 20),
 		helpView:   viewport.New(80, 20),
 		spinner:    sp,
@@ -95,40 +118,40 @@ func (m TaskRunnerModel) Update(msg tea.Msg) (TaskRunnerModel, tea.Cmd) {
 		return m, nil
 
 	case taskOutputMsg:
-		// Limit buffer size to prevent memory issues with long processes
-		const maxOutputLen = 50000 // characters
-
-		newStr := string(msg) + "\n"
-
-		if m.output.Len()+len(newStr) > maxOutputLen {
-			// Truncate old output
-			fullStr := m.output.String() + newStr
-			keepStart := len(fullStr) - maxOutputLen
-			if keepStart < 0 {
-				keepStart = 0
-			}
-			m.output.Reset()
-			m.output.WriteString("... (truncated output) ...\n")
-			m.output.WriteString(fullStr[keepStart:])
-		} else {
-			m.output.WriteString(newStr)
+		if msg.runID != m.runID {
+			return m, nil
+		}
+		for _, line := range msg.lines {
+			m.output.Add(line)
+		}
+		if !msg.done {
+			m.showOutput()
+			return m, waitForTaskOutput(msg.runID, msg.ch, msg.errc)
 		}
 
-		m.outputView.SetContent(m.output.String())
-		m.outputView.GotoBottom()
-		return m, nil
-
-	case taskCompleteMsg:
-		m.running = false
+		// The task has exited, cancelled or not: back to a clean idle state
+		if m.cancel != nil {
+			m.cancel()
+		}
+		m.ctx, m.cancel = nil, nil
+		m.running, m.cancelling = false, false
 		m.state = trStateCompleted
-		if msg.err != nil {
-			m.output.WriteString(fmt.Sprintf("\n Error: %v\n", msg.err))
-		} else {
-			m.output.WriteString("\n Task completed successfully!\n")
+		m.taskErr = msg.err
+		m.output.Add("")
+		var toast tea.Cmd
+		switch {
+		case errors.Is(msg.err, context.Canceled):
+			m.output.Add(" Task cancelled")
+			toast = notify("Task cancelled")
+		case msg.err != nil:
+			m.output.Add(fmt.Sprintf(" Error: %v", msg.err))
+			toast = notifyError("Task failed: " + msg.err.Error())
+		default:
+			m.output.Add(" Task completed successfully!")
+			toast = notify("Task completed")
 		}
-		m.outputView.SetContent(m.output.String())
-		m.outputView.GotoBottom()
-		return m, nil
+		m.showOutput()
+		return m, toast
 
 	case tea.KeyMsg:
 		if m.state == trStateHelp {
@@ -164,26 +187,20 @@ func (m TaskRunnerModel) Update(msg tea.Msg) (TaskRunnerModel, tea.Cmd) {
 					m.currentTask = &m.tasks[idx]
 					m.state = trStateRunning
 					m.running = true
+					m.taskErr = nil
 					m.output.Reset()
-					m.output.WriteString(fmt.Sprintf("Running: %s\n\n", m.currentTask.Name))
-					m.outputView.SetContent(m.output.String())
+					m.output.Add(fmt.Sprintf("Running: %s", m.currentTask.Name))
+					m.output.Add("")
+					m.showOutput()
 
+					m.runID++
 					m.ctx, m.cancel = context.WithCancel(context.Background())
-					outputChan := make(chan string, 100)
-
-					go func() {
-						err := taskrunner.ExecuteTask(m.ctx, *m.currentTask, m.workspace, outputChan)
-						if err != nil {
-							tea.Printf("Task error: %v\n", err)
-						}
-					}()
-
-					return m, func() tea.Msg {
-						for line := range outputChan {
-							return taskOutputMsg(line)
-						}
-						return taskCompleteMsg{}
-					}
+					outputChan := make(chan string, 256)
+					errc := make(chan error, 1)
+					task, ctx := *m.currentTask, m.ctx
+					go func() { errc <- taskrunner.ExecuteTask(ctx, task, m.workspace, outputChan) }()
+
+					return m, waitForTaskOutput(m.runID, outputChan, errc)
 				}
 			}
 			m.list, cmd = m.list.Update(msg)
@@ -192,13 +209,12 @@ func (m TaskRunnerModel) Update(msg tea.Msg) (TaskRunnerModel, tea.Cmd) {
 		case trStateRunning:
 			switch msg.String() {
 			case "ctrl+c":
-				if m.cancel != nil {
+				// The task's process group is killed; the screen goes idle
+				// once it has exited and its output is drained
+				if m.cancel != nil && !m.cancelling {
 					m.cancel()
+					m.cancelling = true
 				}
-				m.running = false
-				m.state = trStateCompleted
-				m.output.WriteString("\n Task cancelled\n")
-				m.outputView.SetContent(m.output.String())
 				return m, nil
 			case "?":
 				m.state = trStateHelp
@@ -325,10 +341,14 @@ func (m TaskRunnerModel) View() string {
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 
 	case trStateRunning:
+		runningLabel := "Running"
+		if m.cancelling {
+			runningLabel = "Cancelling"
+		}
 		header := lipgloss.NewStyle().
 			Width(contentWidth).
 			Align(lipgloss.Center).
-			Render(titleStyle.Render(fmt.Sprintf("%s %s Running: %s", m.spinner.View(), m.currentTask.Icon, m.currentTask.Name)))
+			Render(titleStyle.Render(fmt.Sprintf("%s %s %s: %s", m.spinner.View(), m.currentTask.Icon, runningLabel, m.currentTask.Name)))
 
 		outputBox := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -355,7 +375,10 @@ func (m TaskRunnerModel) View() string {
 	case trStateCompleted:
 		title := "Task Completed"
 		borderColor := colorGreen
-		if strings.Contains(m.output.String(), "Error") || strings.Contains(m.output.String(), "fail") {
+		if errors.Is(m.taskErr, context.Canceled) {
+			title = "Task Cancelled"
+			borderColor = colorYellow
+		} else if m.taskErr != nil {
 			title = "Task Failed"
 			borderColor = colorRed
 		}
@@ -415,3 +438,9 @@ func (m TaskRunnerModel) View() string {
 
 	return ""
 }
+
+// showOutput puts the output buffer in the viewport, following the end
+func (m *TaskRunnerModel) showOutput() {
+	m.outputView.SetContent(m.output.String())
+	m.outputView.GotoBottom()
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/templates"
+)
+
+// Config keys for the template picker preferences
+const (
+	templateFavoritesKey = "templates.favorites"
+	templateHiddenKey    = "templates.hidden"
+)
+
+// templateItems builds the wizard's template list: pinned templates first
+// (in pin order), then the rest in registry order. Hidden templates are
+// left out unless showAll is set, in which case they are listed last.
+func templateItems(showAll bool) []list.Item {
+	favorites := config.GetStringSlice(templateFavoritesKey)
+	hidden := toSet(config.GetStringSlice(templateHiddenKey))
+
+	byName := map[string]templates.Template{}
+	for _, t := range templates.List() {
+		byName[t.Name] = t
+	}
+
+	var items, hiddenItems []list.Item
+	pinned := map[string]bool{}
+	for _, name := range favorites {
+		if t, ok := byName[name]; ok && !pinned[name] {
+			pinned[name] = true
+			items = append(items, item{id: t.Name, title: "★ " + t.Name, desc: t.Description})
+		}
+	}
+	for _, t := range templates.List() {
+		switch {
+		case pinned[t.Name]:
+			continue
+		case hidden[t.Name]:
+			if showAll {
+				hiddenItems = append(hiddenItems, item{id: t.Name, title: t.Name, desc: "(hidden) " + t.Description})
+			}
+		default:
+			items = append(items, item{id: t.Name, title: t.Name, desc: t.Description})
+		}
+	}
+	return append(items, hiddenItems...)
+}
+
+// toggleTemplatePref adds or removes name from the config list at key and
+// reports whether it is now present. Pinning and hiding are exclusive.
+func toggleTemplatePref(key, name string) (bool, error) {
+	names := config.GetStringSlice(key)
+	for i, n := range names {
+		if n == name {
+			return false, config.SaveConfig(key, append(names[:i:i], names[i+1:]...))
+		}
+	}
+
+	other := templateHiddenKey
+	if key == templateHiddenKey {
+		other = templateFavoritesKey
+	}
+	var kept []string
+	for _, n := range config.GetStringSlice(other) {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	config.Set(other, kept)
+	return true, config.SaveConfig(key, append(names, name))
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
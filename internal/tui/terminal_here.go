@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+// openTerminalMsg reports whether a terminal window was opened in dir
+type openTerminalMsg struct {
+	dir      string
+	terminal string
+	err      error
+}
+
+// shellScript returns a launcher that starts shell in dir and deletes
+// itself, so no temp file is left behind
+func shellScript(dir, shell string) string {
+	if runtime.GOOS == "windows" {
+		return strings.Join([]string{
+			"@echo off",
+			"cd /d " + quoteArg(dir),
+			quoteArg(shell),
+			`(goto) 2>nul & del "%~f0"`,
+		}, "\r\n") + "\r\n"
+	}
+	return strings.Join([]string{
+		"#!/bin/sh",
+		`rm -f "$0"`,
+		"cd " + quoteArg(dir) + " || exit 1",
+		"exec " + quoteArg(shell),
+	}, "\n") + "\n"
+}
+
+// openTerminalHere opens a new terminal window running the configured shell
+// in dir, using the same terminal lookup as the editor's Alt+E
+func openTerminalHere(dir string) tea.Cmd {
+	return func() tea.Msg {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return openTerminalMsg{dir: dir, err: fmt.Errorf("not a folder: %s", dir)}
+		}
+		pattern := "devcli_shell_*.sh"
+		switch runtime.GOOS {
+		case "windows":
+			pattern = "devcli_shell_*.bat"
+		case "darwin":
+			pattern = "devcli_shell_*.command"
+		}
+		f, err := os.CreateTemp("", pattern)
+		if err != nil {
+			return openTerminalMsg{dir: dir, err: err}
+		}
+		script := f.Name()
+		fail := func(err error) tea.Msg {
+			os.Remove(script)
+			return openTerminalMsg{dir: dir, err: err}
+		}
+		_, err = f.WriteString(shellScript(dir, utils.InteractiveShell(config.Shell())))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if err := os.Chmod(script, 0755); err != nil {
+			return fail(err)
+		}
+
+		argv, terminal, err := terminalCommand(script)
+		if err != nil {
+			return fail(err)
+		}
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Dir = dir
+		if err := cmd.Start(); err != nil {
+			return fail(fmt.Errorf("failed to start %s: %v", terminal, err))
+		}
+		go cmd.Wait() // Reap the launcher; the window outlives it
+		return openTerminalMsg{dir: dir, terminal: terminal}
+	}
+}
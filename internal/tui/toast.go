@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastDuration is how long a toast stays on screen
+const toastDuration = 3 * time.Second
+
+var (
+	toastStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#282A36")).
+			Background(lipgloss.Color("#50FA7B")).
+			Bold(true).
+			Padding(0, 1)
+	toastErrorStyle = toastStyle.Background(lipgloss.Color("#FF5555"))
+)
+
+// toastMsg asks the top-level model to show a toast
+type toastMsg struct {
+	text  string
+	isErr bool
+}
+
+// toastExpiredMsg hides toast id, unless a newer toast replaced it
+type toastExpiredMsg struct{ id int }
+
+// notify shows text as a toast at the bottom of the screen. Any model can
+// return it for a one-off outcome (copied, saved, finished) that should not
+// linger in its own status line.
+func notify(text string) tea.Cmd {
+	return func() tea.Msg { return toastMsg{text: text} }
+}
+
+// notifyError is notify for a failed action
+func notifyError(text string) tea.Cmd {
+	return func() tea.Msg { return toastMsg{text: text, isErr: true} }
+}
+
+// toaster holds the toast on screen; the top-level models (RootModel and
+// StandaloneWrapper) own one and draw it over their view
+type toaster struct {
+	text  string
+	isErr bool
+	id    int
+}
+
+// update handles the toast messages; handled is false for any other
+func (t *toaster) update(msg tea.Msg) (cmd tea.Cmd, handled bool) {
+	switch msg := msg.(type) {
+	case toastMsg:
+		t.id++
+		t.text, t.isErr = msg.text, msg.isErr
+		id := t.id
+		return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} }), true
+	case toastExpiredMsg:
+		if msg.id == t.id {
+			t.text = ""
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// overlay draws the toast centred over the bottom line of view, which is
+// first padded to the screen height
+func (t toaster) overlay(view string, width, height int) string {
+	if t.text == "" || width <= 0 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	style := toastStyle
+	if t.isErr {
+		style = toastErrorStyle
+	}
+	text := strings.ReplaceAll(t.text, "\n", " ")
+	if limit := width - 2; lipgloss.Width(text) > limit && limit > 3 {
+		text = string([]rune(text)[:limit-3]) + "..."
+	}
+	lines[len(lines)-1] = lipgloss.PlaceHorizontal(width, lipgloss.Center, style.Render(text))
+	return strings.Join(lines, "\n")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/phravins/devcli/internal/project"
 	"github.com/phravins/devcli/internal/venv"
 )
 
@@ -100,6 +101,7 @@ func NewVenvDashboardModel() VenvDashboardModel {
 }
 
 func loadVenvs(mgr *venv.Manager) []list.Item {
+	mgr.Projects = project.ImportedPaths() // May have grown since the last scan
 	envs, err := mgr.List()
 	if err != nil {
 		return []list.Item{item{title: "Error", desc: err.Error()}}
@@ -208,14 +210,14 @@ func (m VenvDashboardModel) Update(msg tea.Msg) (VenvDashboardModel, tea.Cmd) {
 			case "n":
 				m.state = StateVenvCreateInput
 				m.input.Placeholder = "New Environment Path (e.g. ./my-venv)"
-				m.input.SetValue("") // Clear previous
+				m.input.SetValue(lastPath(pathVenvCreate, ""))
 				m.input.Focus()
 				m.message = "" // Clear message
 				return m, nil
 			case "s": // Scan (was 'o')
 				m.state = StateVenvScanInput
 				m.input.Placeholder = "Scan Folder Path"
-				m.input.SetValue("")
+				m.input.SetValue(lastPath(pathVenvScan, ""))
 				m.input.Focus()
 				m.message = "" // Clear message
 				return m, nil
@@ -261,8 +263,8 @@ func (m VenvDashboardModel) Update(msg tea.Msg) (VenvDashboardModel, tea.Cmd) {
 			case "c": // Clone
 				m.state = StateVenvCloneInput
 				m.input.Placeholder = "Destination directory (e.g., D:\\MyNewProject)"
-				// Start empty so user can type any path they want
-				m.input.SetValue("")
+				// Start from the last clone destination, if any
+				m.input.SetValue(lastPath(pathVenvClone, ""))
 				m.input.Focus()
 				return m, nil
 			}
@@ -278,6 +280,7 @@ func (m VenvDashboardModel) Update(msg tea.Msg) (VenvDashboardModel, tea.Cmd) {
 					if abs, err := filepath.Abs(target); err == nil {
 						target = abs
 					}
+					rememberPath(pathVenvClone, target)
 					// Append .venv to destination (consistent with create)
 					venvPath := filepath.Join(target, ".venv")
 					m.targetPath = venvPath // Store for navigation
@@ -332,6 +335,7 @@ func (m VenvDashboardModel) Update(msg tea.Msg) (VenvDashboardModel, tea.Cmd) {
 						target = abs
 					}
 
+					rememberPath(pathVenvCreate, target)
 					// Append .venv to create venv inside a .venv subfolder
 					venvPath := filepath.Join(target, ".venv")
 
@@ -366,6 +370,7 @@ func (m VenvDashboardModel) Update(msg tea.Msg) (VenvDashboardModel, tea.Cmd) {
 			case "enter":
 				target := m.input.Value()
 				if target != "" {
+					rememberPath(pathVenvScan, target)
 					m.manager.Workspace = target // Update workspace
 					m.state = StateVenvList
 					// m.list.Title = "Scanning: " + target
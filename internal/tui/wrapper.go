@@ -7,7 +7,9 @@ import (
 // StandaloneWrapper wraps a model to handle BackMsg/Quit
 // This allows models designed for nested use (returning BackMsg) to work standalone (Quitting on BackMsg)
 type StandaloneWrapper struct {
-	model tea.Model
+	model         tea.Model
+	toast         toaster
+	width, height int
 }
 
 func Wrap(m tea.Model) StandaloneWrapper {
@@ -19,10 +21,21 @@ func (m StandaloneWrapper) Init() tea.Cmd {
 }
 
 func (m StandaloneWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if cmd, ok := m.toast.update(msg); ok {
+		return m, cmd
+	}
+
 	// Intercept BackMsg variants and Quit
-	switch msg.(type) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
 	case BackMsg, DevServerBackMsg, VenvBackMsg, BoilerplateBackMsg, BonusBackMsg:
 		return m, tea.Quit
+	case tea.KeyMsg:
+		if msg.String() == keyFooterToggleKey {
+			toggleKeyFooter()
+			return m, nil
+		}
 	}
 
 	newModel, cmd := m.model.Update(msg)
@@ -31,5 +44,5 @@ func (m StandaloneWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m StandaloneWrapper) View() string {
-	return m.model.View()
+	return m.toast.overlay(m.model.View(), m.width, m.height)
 }
@@ -1,11 +1,14 @@
 package venv
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/phravins/devcli/internal/procs"
 )
 
 type EnvironmentType string
@@ -27,6 +30,7 @@ type Environment struct {
 type Manager struct {
 	Workspace  string
 	PythonPath string
+	Projects   []string // Extra project folders to check, e.g. imported projects outside Workspace
 }
 
 func NewManager(workspace string) *Manager {
@@ -135,9 +139,39 @@ func (m *Manager) List() ([]Environment, error) {
 		return nil
 	})
 
+	for _, dir := range m.Projects {
+		if rel, err := filepath.Rel(workspace, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			continue // Already walked
+		}
+		envs = append(envs, ProjectEnvs(dir)...)
+	}
+
 	return envs, nil
 }
 
+// projectEnvDirs are the folder names a project's environment usually lives in
+var projectEnvDirs = []string{".venv", "venv", "env", "node_modules"}
+
+// ProjectEnvs returns the environments directly inside a project folder
+func ProjectEnvs(dir string) []Environment {
+	var envs []Environment
+	for _, name := range projectEnvDirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		if t := detectType(path); t != TypeUnknown {
+			envs = append(envs, Environment{
+				Name: filepath.Join(filepath.Base(dir), name),
+				Path: path,
+				Type: t,
+				Size: getSize(path),
+			})
+		}
+	}
+	return envs
+}
+
 func (m *Manager) CreateVenv(projectPath string) error {
 	if m.PythonPath == "" {
 		if err := m.CheckPrerequisites(); err != nil {
@@ -157,10 +191,14 @@ func (m *Manager) CreateVenv(projectPath string) error {
 		return fmt.Errorf("failed to create parent dir: %w", err)
 	}
 
-	cmd := exec.Command(m.PythonPath, "-m", "venv", absPath)
+	timeout := procs.Timeout()
+	ctx, cancel := procs.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, m.PythonPath, "-m", "venv", absPath)
 	// Output captured primarily for error usage; we don't stream here to avoid TUI corruption
-	if out, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("venv creation failed: %s: %w", string(out), err)
+	if out, err := procs.CombinedOutput(cmd, "venv: create"); err != nil {
+		return fmt.Errorf("venv creation failed: %s: %w", string(out), procs.TimeoutError(ctx, err, timeout))
 	}
 
 	return nil
@@ -212,9 +250,13 @@ func (m *Manager) Clone(srcPath, destPath string) error {
 	os.WriteFile(reqFile, out, 0644)
 	defer os.Remove(reqFile)
 
-	install := exec.Command(destPip, "install", "-r", reqFile)
-	if out, err := install.CombinedOutput(); err != nil {
-		return fmt.Errorf("cloning install failed: %s: %w", string(out), err)
+	timeout := procs.Timeout()
+	ctx, cancel := procs.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	install := exec.CommandContext(ctx, destPip, "install", "-r", reqFile)
+	if out, err := procs.CombinedOutput(install, "venv: pip install"); err != nil {
+		return fmt.Errorf("cloning install failed: %s: %w", string(out), procs.TimeoutError(ctx, err, timeout))
 	}
 	return nil
 }
@@ -0,0 +1,135 @@
+package web
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reloadPath is the Server-Sent Events stream preview pages listen on
+const reloadPath = "/__devcli/reload"
+
+// reloadScript is injected into previewed pages; the browser reconnects on
+// its own if the stream drops
+const reloadScript = `<script>new EventSource("` + reloadPath + `").onmessage = () => location.reload();</script>`
+
+// Preview serves one HTML document on a local port and reloads the pages
+// showing it whenever Update is called. Other paths are served from the
+// document's folder so relative stylesheets, scripts and images load.
+type Preview struct {
+	mu      sync.Mutex
+	html    string
+	dir     string
+	clients map[chan struct{}]struct{}
+	ln      net.Listener
+	srv     *http.Server
+}
+
+// StartPreview serves html on a free port on 127.0.0.1. dir is the folder
+// relative links resolve against; empty serves nothing besides the page.
+func StartPreview(dir, html string) (*Preview, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &Preview{html: html, dir: dir, clients: map[chan struct{}]struct{}{}, ln: ln}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", p.serve)
+	mux.HandleFunc(reloadPath, p.events)
+	p.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go p.srv.Serve(ln)
+	return p, nil
+}
+
+// URL is the address of the previewed page
+func (p *Preview) URL() string {
+	return "http://" + p.ln.Addr().String() + "/"
+}
+
+// Update replaces the document and reloads every open page
+func (p *Preview) Update(dir, html string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.html, p.dir = html, dir
+	for c := range p.clients {
+		select {
+		case c <- struct{}{}:
+		default: // A reload is already pending
+		}
+	}
+}
+
+// Watching is the number of pages connected for live reload
+func (p *Preview) Watching() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// Close stops the server and disconnects open pages
+func (p *Preview) Close() error {
+	return p.srv.Close()
+}
+
+func (p *Preview) serve(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	html, dir := p.html, p.dir
+	p.mu.Unlock()
+
+	if r.URL.Path != "/" && r.URL.Path != "/index.html" {
+		if dir == "" {
+			http.NotFound(w, r)
+			return
+		}
+		http.FileServer(http.Dir(dir)).ServeHTTP(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(injectReload(html)))
+}
+
+func (p *Preview) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+
+	c := make(chan struct{}, 1)
+	p.mu.Lock()
+	p.clients[c] = struct{}{}
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.clients, c)
+		p.mu.Unlock()
+	}()
+
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// injectReload adds reloadScript before </body>, or at the end of a
+// fragment without one
+func injectReload(html string) string {
+	if i := strings.LastIndex(strings.ToLower(html), "</body>"); i >= 0 {
+		return html[:i] + reloadScript + html[i:]
+	}
+	return html + reloadScript
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestPreview_ServesPageAndAssets(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "style.css"), []byte("body { color: red }"), 0644)
+
+	p, err := StartPreview(dir, "<html><body><h1>Hi</h1></body></html>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	page := get(t, p.URL())
+	if !strings.Contains(page, "<h1>Hi</h1>") || !strings.Contains(page, reloadPath) {
+		t.Errorf("page missing content or reload script:\n%s", page)
+	}
+	if strings.Index(page, reloadScript) > strings.Index(page, "</body>") {
+		t.Errorf("reload script not injected before </body>:\n%s", page)
+	}
+	if css := get(t, p.URL()+"style.css"); css != "body { color: red }" {
+		t.Errorf("relative asset = %q", css)
+	}
+}
+
+func TestPreview_UpdateReloadsPages(t *testing.T) {
+	p, err := StartPreview("", "<p>one</p>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	resp, err := http.Get(strings.TrimSuffix(p.URL(), "/") + reloadPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	events.ReadString('\n') // ": connected"
+
+	for deadline := time.Now().Add(2 * time.Second); p.Watching() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("event stream never registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.Update("", "<p>two</p>")
+
+	got := make(chan string, 1)
+	go func() {
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil || strings.HasPrefix(line, "data:") {
+				got <- line
+				return
+			}
+		}
+	}()
+	select {
+	case line := <-got:
+		if strings.TrimSpace(line) != "data: reload" {
+			t.Errorf("event = %q, want data: reload", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no reload event after Update")
+	}
+	if page := get(t, p.URL()); !strings.Contains(page, "<p>two</p>") {
+		t.Errorf("page not updated:\n%s", page)
+	}
+}
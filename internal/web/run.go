@@ -0,0 +1,154 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/phravins/devcli/internal/procs"
+	"github.com/phravins/devcli/internal/runner"
+	"github.com/phravins/devcli/pkg/utils"
+)
+
+const (
+	defaultRunTimeout = 10 * time.Second
+	maxRunTimeout     = 120 * time.Second
+)
+
+// RunRequest is the JSON body accepted by POST /run
+type RunRequest struct {
+	Language string   `json:"language"` // A runner name or alias; python by default
+	Code     string   `json:"code"`
+	Stdin    string   `json:"stdin"`
+	Args     []string `json:"args"`
+	Timeout  float64  `json:"timeout"` // Seconds, 0 = default
+}
+
+// RunResponse is the JSON body returned by POST /run
+type RunResponse struct {
+	Output     string `json:"output"`
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// runTimeout clamps the requested timeout to a sane range. Without one,
+// exec.default_timeout applies if set, else defaultRunTimeout.
+func runTimeout(seconds float64) time.Duration {
+	if seconds > maxRunTimeout.Seconds() {
+		return maxRunTimeout // Before converting, as huge values overflow a Duration
+	}
+	d := time.Duration(seconds * float64(time.Second))
+	if seconds <= 0 {
+		d = procs.Timeout()
+		if d <= 0 {
+			return defaultRunTimeout
+		}
+	}
+	if d > maxRunTimeout {
+		return maxRunTimeout
+	}
+	return d
+}
+
+// executeRun runs the request in a temp directory, enforcing the timeout by
+// killing the process, and reports output, exit code and duration.
+// Compiled languages are built first; the timeout covers both.
+func executeRun(req RunRequest) RunResponse {
+	lang := req.Language
+	if strings.TrimSpace(lang) == "" {
+		lang = "python"
+	}
+	r, ok := runner.Lookup(lang)
+	if !ok {
+		return RunResponse{ExitCode: -1, Error: fmt.Sprintf("unsupported language: %s", req.Language)}
+	}
+	tools, err := r.Resolve(utils.FindExecutable)
+	if err != nil {
+		return RunResponse{ExitCode: -1, Error: err.Error()}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "devcli-web-*")
+	if err != nil {
+		return RunResponse{ExitCode: -1, Error: err.Error()}
+	}
+	defer os.RemoveAll(tmpDir)
+
+	b, err := r.NewBuild(req.Code, tmpDir, tools)
+	if err != nil {
+		return RunResponse{ExitCode: -1, Error: err.Error()}
+	}
+
+	timeout := runTimeout(req.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	var cmd *exec.Cmd
+	// step runs one command of the build, registered so it can be
+	// cancelled with Ctrl+C
+	step := func(argv []string, stdin string) error {
+		cmd = exec.CommandContext(ctx, argv[0], argv[1:]...)
+		cmd.Dir = tmpDir
+		cmd.Env = os.Environ()
+		cmd.Stdin = strings.NewReader(stdin)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		activeMu.Lock()
+		activeCmd = cmd
+		activeMu.Unlock()
+
+		err := procs.Run(cmd, "web: "+r.Name)
+
+		activeMu.Lock()
+		activeCmd = nil
+		activeMu.Unlock()
+		return err
+	}
+
+	start := time.Now()
+	if r.Setup != nil {
+		err = step(r.Setup(b), "")
+		if err != nil {
+			err = fmt.Errorf("setup failed: %w", err)
+		}
+	}
+	if err == nil {
+		err = b.WriteSource(req.Code)
+	}
+	if err == nil && r.Compile != nil {
+		if err = step(r.Compile(b), ""); err != nil {
+			err = fmt.Errorf("compilation failed: %w", err)
+		}
+	}
+	if err == nil {
+		err = step(append(r.Run(b), req.Args...), req.Stdin)
+	}
+	duration := time.Since(start)
+
+	resp := RunResponse{
+		Output:     procs.DecodeOutput(out.Bytes(), r.Name),
+		DurationMs: duration.Milliseconds(),
+	}
+	if cmd != nil && cmd.ProcessState != nil {
+		resp.ExitCode = cmd.ProcessState.ExitCode()
+	} else if err != nil {
+		resp.ExitCode = -1
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		resp.ExitCode = -1
+		resp.Error = fmt.Sprintf("timed out after %s", timeout)
+	case err != nil:
+		resp.Error = err.Error()
+	}
+
+	return resp
+}
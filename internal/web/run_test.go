@@ -0,0 +1,62 @@
+package web
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func requirePython(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("python"); err == nil {
+		return
+	}
+	if _, err := exec.LookPath("python3"); err == nil {
+		return
+	}
+	t.Skip("python not available")
+}
+
+func TestExecuteRun_UnsupportedLanguage(t *testing.T) {
+	resp := executeRun(RunRequest{Language: "cobol", Code: "DISPLAY 'HI'."})
+	if resp.Error == "" {
+		t.Fatal("Expected error for unsupported language")
+	}
+	if resp.ExitCode != -1 {
+		t.Errorf("Expected exit code -1, got %d", resp.ExitCode)
+	}
+}
+
+func TestExecuteRun_StdinArgsAndExitCode(t *testing.T) {
+	requirePython(t)
+
+	code := "import sys\nprint(input(), sys.argv[1])\nsys.exit(3)\n"
+	resp := executeRun(RunRequest{Language: "python", Code: code, Stdin: "hello\n", Args: []string{"world"}})
+
+	if !strings.Contains(resp.Output, "hello world") {
+		t.Errorf("Expected output to contain 'hello world', got %q", resp.Output)
+	}
+	if resp.ExitCode != 3 {
+		t.Errorf("Expected exit code 3, got %d", resp.ExitCode)
+	}
+}
+
+func TestExecuteRun_Timeout(t *testing.T) {
+	requirePython(t)
+
+	resp := executeRun(RunRequest{Language: "python", Code: "import time\ntime.sleep(10)\n", Timeout: 0.5})
+	if !strings.Contains(resp.Error, "timed out") {
+		t.Errorf("Expected timeout error, got %q", resp.Error)
+	}
+	if resp.DurationMs >= 5000 {
+		t.Errorf("Process was not killed on timeout (ran %dms)", resp.DurationMs)
+	}
+}
+
+func TestRunTimeout_HugeValueClamped(t *testing.T) {
+	for _, seconds := range []float64{1e12, 1e300} {
+		if d := runTimeout(seconds); d != maxRunTimeout {
+			t.Errorf("runTimeout(%g) = %v, want %v", seconds, d, maxRunTimeout)
+		}
+	}
+}
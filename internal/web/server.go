@@ -11,6 +11,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/phravins/devcli/internal/config"
+	"github.com/phravins/devcli/internal/procs"
 	"github.com/phravins/devcli/pkg/utils"
 )
 
@@ -371,16 +373,18 @@ if __name__ == "__main__":
             try {
                 const response = await fetch('/run', {
                     method: 'POST',
-                    body: code
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ language: 'python', code: code })
                 });
                 const result = await response.json();
                 
+                const footer = "\n[exited " + result.exitCode + " in " + (result.durationMs / 1000).toFixed(2) + "s]";
                 if (result.error) {
                     log.style.color = 'var(--error)';
-                    log.textContent = result.output + "\nError: " + result.error;
+                    log.textContent = result.output + "\nError: " + result.error + footer;
                 } else {
                     log.style.color = 'var(--success)';
-                    log.textContent = result.output;
+                    log.textContent = (result.output || "[No output]") + footer;
                 }
             } catch (e) {
                 log.style.color = 'var(--error)';
@@ -519,8 +523,8 @@ func StartServer(port string) error {
 		activeMu.Lock()
 		defer activeMu.Unlock()
 		if activeCmd != nil && activeCmd.Process != nil {
-			// Terminate the currently running process
-			activeCmd.Process.Kill()
+			// Terminate the currently running process and its children
+			procs.Kill(activeCmd)
 			// The process runner will clean up and return an error
 		}
 		w.WriteHeader(http.StatusOK)
@@ -531,6 +535,10 @@ func StartServer(port string) error {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		if !localRequest(r, port) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
 
 		var payload struct {
 			Filename string `json:"filename"`
@@ -567,11 +575,22 @@ func StartServer(port string) error {
 		w.WriteHeader(http.StatusOK)
 	})
 
+	// /run executes code and returns {output, exitCode, durationMs, error}.
+	// The body is a RunRequest. Requiring JSON makes cross-site pages go
+	// through a CORS preflight, which this server never answers.
 	mux.HandleFunc("/run", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		if !localRequest(r, port) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+			return
+		}
 
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -579,16 +598,14 @@ func StartServer(port string) error {
 			return
 		}
 
-		// Execute the Python code and capture output
-		output, err := runPython(string(body))
-
-		response := map[string]string{
-			"output": output,
-		}
-		if err != nil {
-			response["error"] = err.Error()
+		var req RunRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
 		}
 
+		response := executeRun(req)
+
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
 	})
@@ -598,6 +615,10 @@ func StartServer(port string) error {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		if !localRequest(r, port) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
 
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -637,50 +658,16 @@ func StartServer(port string) error {
 	return err
 }
 
-// runPython executes Python code and returns the output
-func runPython(code string) (string, error) {
-	// Create a temporary Python file to hold the code
-	tmpfile, err := os.CreateTemp("", "devcli-*.py")
-	if err != nil {
-		return "", err
+// localRequest rejects requests that reached the server under another
+// host name (DNS rebinding) or were sent by a page from another origin
+func localRequest(r *http.Request, port string) bool {
+	switch r.Host {
+	case "127.0.0.1:" + port, "localhost:" + port:
+	default:
+		return false
 	}
-	defer os.Remove(tmpfile.Name())
-
-	if _, err := tmpfile.Write([]byte(code)); err != nil {
-		return "", err
-	}
-	if err := tmpfile.Close(); err != nil {
-		return "", err
-	}
-
-	// Determine which Python command to use
-	// Try "python" first (common on Windows), fallback to "python3" (common on Linux/Mac)
-	cmdName := "python"
-	if _, err := exec.LookPath("python"); err != nil {
-		cmdName = "python3"
-	}
-
-	cmd := exec.Command(cmdName, "-u", tmpfile.Name()) // -u = unbuffered output
-	cmd.Env = os.Environ()                             // Pass environment variables to the Python process
-
-	// Register this command so it can be cancelled with Ctrl+C
-	activeMu.Lock()
-	activeCmd = cmd
-	activeMu.Unlock()
-
-	output, err := cmd.CombinedOutput()
-
-	activeMu.Lock()
-	activeCmd = nil
-	activeMu.Unlock()
-
-	// Provide helpful feedback if the code produced no output
-	outStr := string(output)
-	if outStr == "" && err == nil {
-		outStr = fmt.Sprintf("[No output]\n(Ran: %s -u %s)", cmdName, tmpfile.Name())
-	}
-
-	return outStr, err
+	origin := r.Header.Get("Origin")
+	return origin == "" || origin == "http://"+r.Host
 }
 
 // runShell executes shell commands in the web terminal
@@ -712,7 +699,7 @@ func runShell(command string) (string, error) {
 	}
 
 	var cmd *exec.Cmd
-	cmd = utils.GetShellCommand(command)
+	cmd = utils.GetShellCommand(config.Shell(), command)
 
 	cmd.Dir = currentDir
 	cmd.Env = os.Environ() // Pass environment variables to the shell
@@ -722,11 +709,11 @@ func runShell(command string) (string, error) {
 	activeCmd = cmd
 	activeMu.Unlock()
 
-	output, err := cmd.CombinedOutput()
+	output, err := procs.CombinedOutput(cmd, "web: shell")
 
 	activeMu.Lock()
 	activeCmd = nil
 	activeMu.Unlock()
 
-	return string(output), err
+	return procs.DecodeOutput(output, "shell"), err
 }
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalRequest(t *testing.T) {
+	tests := []struct {
+		host, origin string
+		want         bool
+	}{
+		{"127.0.0.1:8080", "", true},
+		{"localhost:8080", "http://localhost:8080", true},
+		{"evil.example:8080", "", false},                 // DNS rebinding
+		{"127.0.0.1:8080", "http://evil.example", false}, // Cross-site page
+		{"127.0.0.1:9090", "", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/run", nil)
+		r.Host = tt.host
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := localRequest(r, "8080"); got != tt.want {
+			t.Errorf("localRequest(host %q, origin %q) = %v, want %v", tt.host, tt.origin, got, tt.want)
+		}
+	}
+}
@@ -3,13 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
-	"strings"
 
 	"github.com/phravins/devcli/internal/ai"
+	"github.com/phravins/devcli/internal/config"
 	"github.com/phravins/devcli/internal/fileops"
+	"github.com/phravins/devcli/internal/procs"
 	"github.com/phravins/devcli/internal/project"
 	"github.com/phravins/devcli/internal/tui"
 	"github.com/phravins/devcli/internal/updater"
@@ -28,6 +26,29 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.PersistentFlags().String("timeout", "", `Time limit for one-shot commands (runs, tasks, installs), e.g. "90s" or "5m"; 0 disables. Overrides exec.default_timeout`)
+	rootCmd.PersistentFlags().String("config", "", "Config file to read and write instead of the default (or set $"+config.EnvPath+")")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if path, _ := cmd.Flags().GetString("config"); path != "" {
+			if err := config.SetPath(path); err != nil {
+				return err
+			}
+		}
+		if !cmd.Flags().Changed("timeout") {
+			return nil
+		}
+		value, _ := cmd.Flags().GetString("timeout")
+		d, err := procs.ParseTimeout(value)
+		if err != nil {
+			return err
+		}
+		procs.SetTimeout(d)
+		return nil
+	}
+	rootCmd.Run = func(cmd *cobra.Command, args []string) {
+		tui.RunRoot() // Reached with root-level flags only, e.g. "devcli --timeout 5m"
+	}
+
 	// Add all subcommands
 	// Add all subcommands
 	fileops.FileCmd.Run = func(cmd *cobra.Command, args []string) {
@@ -36,6 +57,7 @@ func init() {
 	rootCmd.AddCommand(fileops.FileCmd)
 	rootCmd.AddCommand(ai.AICmd)
 	rootCmd.AddCommand(tui.EditorCmd)
+	rootCmd.AddCommand(devCmd)
 	ai.AICmd.AddCommand(tui.ChatCmd)
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "start [name] [stack]",
@@ -74,157 +96,9 @@ func init() {
 			}
 		},
 	})
-	rootCmd.AddCommand(&cobra.Command{
-		Use:   "install",
-		Short: "Install DevCLI globally to your system",
-		Long:  `Copies the DevCLI binary to your home directory and adds it to your system PATH.`,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Starting DevCLI installation...")
-
-			exePath, err := os.Executable()
-			if err != nil {
-				fmt.Printf("Error finding executable: %v\n", err)
-				return
-			}
-
-			home, err := os.UserHomeDir()
-			if err != nil {
-				fmt.Printf("Error finding home directory: %v\n", err)
-				return
-			}
-
-			// Destination Directory
-			var binDir string
-			var destPath string
-			if runtime.GOOS == "windows" {
-				binDir = filepath.Join(home, ".devcli", "bin")
-				destPath = filepath.Join(binDir, "devcli.exe")
-			} else {
-				// Unix: standard is often ~/.local/bin or just ~/go/bin, but let's stick to .devcli/bin for consistency
-				// or use /usr/local/bin if root? Let's use user-local to avoid permission issues.
-				binDir = filepath.Join(home, ".devcli", "bin")
-				destPath = filepath.Join(binDir, "devcli")
-			}
-
-			if err := os.MkdirAll(binDir, 0755); err != nil {
-				fmt.Printf("Error creating bin directory: %v\n", err)
-				return
-			}
-
-			// Read binary
-			data, err := os.ReadFile(exePath)
-			if err != nil {
-				fmt.Printf("Error reading current binary: %v\n", err)
-				return
-			}
-
-			// Write to destination
-			if err := os.WriteFile(destPath, data, 0755); err != nil {
-				fmt.Printf("Error copying binary: %v\n", err)
-				return
-			}
-
-			fmt.Printf("Binary deployed to: %s\n", destPath)
-
-			// Update PATH
-			if runtime.GOOS == "windows" {
-				script := fmt.Sprintf(`
-				$binPath = "%s"
-				$currentPath = [System.Environment]::GetEnvironmentVariable("Path", "User")
-				if ($currentPath -notlike "*$binPath*") {
-					[System.Environment]::SetEnvironmentVariable("Path", $currentPath + ";" + $binPath, "User")
-					Write-Output "ADDED"
-				} else {
-					Write-Output "EXISTS"
-				}
-			`, binDir)
-
-				out, err := exec.Command("powershell", "-Command", script).CombinedOutput()
-				if err != nil {
-					fmt.Printf("Warning: Automated PATH update failed: %v\n", err)
-					fmt.Printf("Please add this folder to your PATH manually: %s\n", binDir)
-				} else {
-					res := strings.TrimSpace(string(out))
-					if res == "ADDED" {
-						fmt.Println("Successfully added DevCLI to your User PATH!")
-						fmt.Println("Installation complete. PLEASE RESTART YOUR TERMINAL to use 'devcli' from anywhere.")
-					} else {
-						fmt.Println("DevCLI already exists in your PATH.")
-						fmt.Println("Installation complete.")
-					}
-				}
-			} else {
-				// Unix/Linux/Mac
-				// Make the binary executable
-				if err := os.Chmod(destPath, 0755); err != nil {
-					fmt.Printf("Warning: Failed to make binary executable: %v\n", err)
-				}
-
-				// Check current Shell
-				shell := os.Getenv("SHELL")
-				rcFile := ""
-
-				// Detect Shell Configuration File
-				if strings.Contains(shell, "zsh") {
-					rcFile = filepath.Join(home, ".zshrc")
-				} else if strings.Contains(shell, "bash") {
-					rcFile = filepath.Join(home, ".bashrc")
-				} else if strings.Contains(shell, "fish") {
-					// Fish shell support
-					configDir, _ := os.UserConfigDir() // usually ~/.config
-					rcFile = filepath.Join(configDir, "fish", "config.fish")
-				}
-
-				// Check if already in PATH (rough check)
-				pathEnv := os.Getenv("PATH")
-				if !strings.Contains(pathEnv, binDir) {
-					if rcFile != "" {
-						fmt.Printf("Detecting shell: %s. Attempting to update %s...\n", shell, rcFile)
-
-						// Prepare export line based on shell
-						var exportLine string
-						if strings.Contains(shell, "fish") {
-							exportLine = fmt.Sprintf("\n# DevCLI\nset -gx PATH $PATH %s\n", binDir)
-						} else {
-							exportLine = fmt.Sprintf("\n# DevCLI\nexport PATH=\"$PATH:%s\"\n", binDir)
-						}
-
-						// Check if file already has it
-						content, err := os.ReadFile(rcFile)
-						// If file doesn't exist, Create it
-						if os.IsNotExist(err) {
-							// For fish, ensure directory exists
-							if strings.Contains(shell, "fish") {
-								os.MkdirAll(filepath.Dir(rcFile), 0755)
-							}
-						}
-
-						if err == nil && strings.Contains(string(content), binDir) {
-							fmt.Println("PATH already seems to be configured in RC file.")
-						} else {
-							f, err := os.OpenFile(rcFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
-							if err != nil {
-								fmt.Printf("Error opening rc file: %v\n", err)
-							} else {
-								defer f.Close()
-								if _, err = f.WriteString(exportLine); err != nil {
-									fmt.Printf("Error writing to rc file: %v\n", err)
-								} else {
-									fmt.Println("Successfully added install path to shell configuration.")
-									fmt.Printf("Run 'source %s' or restart terminal to apply changes.\n", rcFile)
-								}
-							}
-						}
-					} else {
-						fmt.Printf("Could not detect shell configuration file (.bashrc/.zshrc/config.fish).\n")
-						fmt.Printf("Please manually add the following to your PATH:\n%s\n", binDir)
-					}
-				} else {
-					fmt.Println("DevCLI is already in your PATH.")
-				}
-			}
-		},
-	})
+	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(snippetsCmd)
+	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "update",
 		Short: "Update DevCLI to the latest version",
@@ -263,13 +137,52 @@ func init() {
 			fmt.Println("🔄 Please restart DevCLI to use the new version.")
 		},
 	})
+	killCmd := &cobra.Command{
+		Use:   "kill",
+		Short: "Stop every process started by DevCLI",
+		Long:  `Terminates dev servers, tasks and compiled programs that DevCLI started, including ones left running after a crash, so their ports are freed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			listOnly, _ := cmd.Flags().GetBool("list")
+			if listOnly {
+				running := procs.List()
+				if len(running) == 0 {
+					fmt.Println("No DevCLI-started processes are running.")
+					return
+				}
+				for _, e := range running {
+					fmt.Printf("%-8d %-24s started %s\n", e.PID, e.Name, e.Started.Format("2006-01-02 15:04:05"))
+				}
+				return
+			}
+
+			killed, err := procs.KillRecorded()
+			for _, e := range killed {
+				fmt.Printf("Killed %d (%s)\n", e.PID, e.Name)
+			}
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(killed) == 0 {
+				fmt.Println("No DevCLI-started processes are running.")
+			}
+		},
+	}
+	killCmd.Flags().BoolP("list", "l", false, "Only list the running processes")
+	rootCmd.AddCommand(killCmd)
 
 }
 func main() {
+	// Kill spawned servers and programs on a normal return or a panic in
+	// this goroutine. os.Exit and panics in other goroutines skip it; the
+	// processes stay in processes.json for "devcli kill" to clean up.
+	defer procs.KillAll()
+
 	// If args were passed (CLI mode), just run once
 	if len(os.Args) > 1 {
 		if err := rootCmd.Execute(); err != nil {
 			fmt.Println(err)
+			procs.KillAll()
 			os.Exit(1)
 		}
 		return
@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EditorConfig holds the .editorconfig properties the editor honours for
+// one file. Fields no matching section set are empty (0 for IndentSize).
+type EditorConfig struct {
+	IndentStyle            string // "space" or "tab"
+	IndentSize             int
+	InsertFinalNewline     string // "true" or "false"
+	TrimTrailingWhitespace string // "true" or "false"
+}
+
+// Empty reports whether no property applies
+func (c EditorConfig) Empty() bool {
+	return c == EditorConfig{}
+}
+
+// Indent returns one level of indentation, or fallback when neither
+// indent_style nor indent_size is set. A size without a style means spaces.
+func (c EditorConfig) Indent(fallback string) string {
+	switch {
+	case c.IndentStyle == "tab":
+		return "\t"
+	case c.IndentStyle == "space" || c.IndentSize > 0:
+		size := c.IndentSize
+		if size <= 0 {
+			size = 4
+		}
+		return strings.Repeat(" ", size)
+	}
+	return fallback
+}
+
+// editorConfigSection is one [glob] block of an .editorconfig file
+type editorConfigSection struct {
+	match *regexp.Regexp
+	props map[string]string
+}
+
+// FindEditorConfig resolves the .editorconfig properties for file. Files
+// are read from the file's folder up to the first one with root = true;
+// nearer files and later sections win, as the spec asks.
+func FindEditorConfig(file string) EditorConfig {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return EditorConfig{}
+	}
+
+	// Collected nearest first, applied farthest first
+	type configFile struct {
+		dir      string
+		sections []editorConfigSection
+	}
+	var files []configFile
+	for dir := filepath.Dir(abs); ; {
+		sections, root, ok := readEditorConfig(filepath.Join(dir, ".editorconfig"))
+		if ok {
+			files = append(files, configFile{dir, sections})
+		}
+		parent := filepath.Dir(dir)
+		if root || parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	props := map[string]string{}
+	for i := len(files) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(files[i].dir, abs)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, s := range files[i].sections {
+			if !s.match.MatchString(rel) {
+				continue
+			}
+			for k, v := range s.props {
+				props[k] = v
+			}
+		}
+	}
+
+	var c EditorConfig
+	if v := props["indent_style"]; v == "space" || v == "tab" {
+		c.IndentStyle = v
+	}
+	size := props["indent_size"]
+	if size == "tab" {
+		size = props["tab_width"]
+	}
+	if n, err := strconv.Atoi(size); err == nil && n > 0 {
+		c.IndentSize = n
+	}
+	if v := props["insert_final_newline"]; v == "true" || v == "false" {
+		c.InsertFinalNewline = v
+	}
+	if v := props["trim_trailing_whitespace"]; v == "true" || v == "false" {
+		c.TrimTrailingWhitespace = v
+	}
+	return c
+}
+
+// readEditorConfig parses an .editorconfig file. ok is false when it does
+// not exist; root reports a root = true in its preamble.
+func readEditorConfig(path string) (sections []editorConfigSection, root, ok bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, false
+	}
+	defer f.Close()
+
+	var cur *editorConfigSection
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			cur = nil
+			if re, err := editorConfigGlob(line[1 : len(line)-1]); err == nil {
+				sections = append(sections, editorConfigSection{match: re, props: map[string]string{}})
+				cur = &sections[len(sections)-1]
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch {
+		case cur != nil:
+			if value == "unset" {
+				value = ""
+			}
+			cur.props[key] = value
+		case len(sections) == 0 && key == "root":
+			root = value == "true"
+		}
+	}
+	return sections, root, true
+}
+
+var numericRange = regexp.MustCompile(`^[+-]?\d+\.\.[+-]?\d+$`)
+
+// editorConfigGlob turns a section name into a regexp matched against the
+// slash-separated path relative to the .editorconfig. A glob without a
+// slash matches the file name in any folder.
+func editorConfigGlob(glob string) (*regexp.Regexp, error) {
+	if strings.Contains(glob, "/") {
+		glob = strings.TrimPrefix(glob, "/")
+	} else {
+		glob = "**/" + glob
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	depth := 0 // Open { groups
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?") // **/ also matches no folder
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			end := strings.IndexByte(glob[i+1:], '}')
+			if end >= 0 {
+				// {1..10} matches a number; anything else is an alternation
+				if numericRange.MatchString(glob[i+1 : i+1+end]) {
+					b.WriteString(`[+-]?\d+`)
+					i += end + 1
+					continue
+				}
+			}
+			depth++
+			b.WriteString("(?:")
+		case '}':
+			if depth == 0 {
+				b.WriteString(`\}`)
+				continue
+			}
+			depth--
+			b.WriteString(")")
+		case ',':
+			if depth == 0 {
+				b.WriteString(",")
+				continue
+			}
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("unclosed { in %q", glob)
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindEditorConfig(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".editorconfig", `root = true
+
+[*]
+indent_style = space
+indent_size = 2
+trim_trailing_whitespace = true
+
+[*.{go,mod}]
+indent_style = tab
+
+[Makefile]
+indent_style = tab
+
+[docs/**.md]
+trim_trailing_whitespace = false
+`)
+	write("sub/.editorconfig", `[*.py]
+indent_size = 4
+insert_final_newline = true
+
+[*.txt]
+trim_trailing_whitespace = unset
+`)
+
+	tests := []struct {
+		file string
+		want EditorConfig
+	}{
+		{"main.go", EditorConfig{IndentStyle: "tab", IndentSize: 2, TrimTrailingWhitespace: "true"}},
+		{"pkg/a/b.go", EditorConfig{IndentStyle: "tab", IndentSize: 2, TrimTrailingWhitespace: "true"}},
+		{"Makefile", EditorConfig{IndentStyle: "tab", IndentSize: 2, TrimTrailingWhitespace: "true"}},
+		{"docs/guide/intro.md", EditorConfig{IndentStyle: "space", IndentSize: 2, TrimTrailingWhitespace: "false"}},
+		{"sub/app.py", EditorConfig{IndentStyle: "space", IndentSize: 4, InsertFinalNewline: "true", TrimTrailingWhitespace: "true"}},
+		{"sub/notes.txt", EditorConfig{IndentStyle: "space", IndentSize: 2}},
+	}
+	for _, tt := range tests {
+		if got := FindEditorConfig(filepath.Join(root, tt.file)); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.file, got, tt.want)
+		}
+	}
+
+	// root = true stops the search; without it the parent would apply
+	write("proj/.editorconfig", "root = true\n[*.js]\nindent_size = 3\n")
+	if got := FindEditorConfig(filepath.Join(root, "proj", "x.js")); got != (EditorConfig{IndentSize: 3}) {
+		t.Errorf("root = true: got %+v", got)
+	}
+	if got := FindEditorConfig(filepath.Join(root, "proj", "x.go")); !got.Empty() {
+		t.Errorf("no matching section: got %+v", got)
+	}
+}
+
+func TestEditorConfigIndent(t *testing.T) {
+	for _, tt := range []struct {
+		c    EditorConfig
+		want string
+	}{
+		{EditorConfig{}, "    "},
+		{EditorConfig{IndentStyle: "tab", IndentSize: 8}, "\t"},
+		{EditorConfig{IndentStyle: "space"}, "    "},
+		{EditorConfig{IndentSize: 2}, "  "},
+	} {
+		if got := tt.c.Indent("    "); got != tt.want {
+			t.Errorf("%+v.Indent = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+}
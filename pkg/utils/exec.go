@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 // OpenBrowser opens the specified URL in the default browser in a cross-platform way.
@@ -39,7 +41,13 @@ func OpenFile(path string) error {
 	return cmd.Start()
 }
 
-func GetShellCommand(command string) *exec.Cmd {
+// GetShellCommand wraps command in shell (e.g. pwsh, bash, zsh, fish),
+// falling back to the platform default when shell is empty
+func GetShellCommand(shell, command string) *exec.Cmd {
+	if shell = strings.TrimSpace(shell); shell != "" {
+		return exec.Command(shell, shellArgs(shell, command)...)
+	}
+
 	if runtime.GOOS == "windows" {
 		// Try PowerShell first, then Cmd
 		if _, err := exec.LookPath("powershell"); err == nil {
@@ -50,7 +58,7 @@ func GetShellCommand(command string) *exec.Cmd {
 
 	// Unix-like (Linux, macOS)
 	// Use $SHELL if set, otherwise fallback to sh
-	shell := os.Getenv("SHELL")
+	shell = os.Getenv("SHELL")
 	if shell != "" {
 		return exec.Command(shell, "-c", command)
 	}
@@ -60,3 +68,38 @@ func GetShellCommand(command string) *exec.Cmd {
 	}
 	return exec.Command("sh", "-c", command)
 }
+
+// shellArgs returns the arguments that make shell run a single command.
+// shell may be a bare name or a full path, with or without .exe.
+func shellArgs(shell, command string) []string {
+	name := strings.ToLower(filepath.Base(shell))
+	switch strings.TrimSuffix(name, ".exe") {
+	case "cmd":
+		return []string{"/C", command}
+	case "powershell", "pwsh":
+		return []string{"-Command", command}
+	default: // sh, bash, zsh, fish, ...
+		return []string{"-c", command}
+	}
+}
+
+// InteractiveShell returns the shell to open for the user: shell if set,
+// or the same platform default GetShellCommand uses
+func InteractiveShell(shell string) string {
+	if shell = strings.TrimSpace(shell); shell != "" {
+		return shell
+	}
+	if runtime.GOOS == "windows" {
+		if _, err := exec.LookPath("powershell"); err == nil {
+			return "powershell"
+		}
+		return "cmd"
+	}
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	if _, err := exec.LookPath("bash"); err == nil {
+		return "bash"
+	}
+	return "sh"
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestShellArgs(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{"bash", []string{"-c", "echo hi"}},
+		{"/usr/bin/zsh", []string{"-c", "echo hi"}},
+		{"fish", []string{"-c", "echo hi"}},
+		{"pwsh", []string{"-Command", "echo hi"}},
+		{"powershell.exe", []string{"-Command", "echo hi"}},
+		{"CMD.EXE", []string{"/C", "echo hi"}},
+	}
+	for _, tt := range tests {
+		if got := shellArgs(tt.shell, "echo hi"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("shellArgs(%q) = %q, want %q", tt.shell, got, tt.want)
+		}
+	}
+}
+
+func TestGetShellCommand_UsesConfiguredShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the fake shell")
+	}
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	// A fake shell that reports how it was invoked
+	fake := filepath.Join(t.TempDir(), "myshell")
+	script := "#!" + sh + "\necho \"myshell $*\"\n"
+	if err := os.WriteFile(fake, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := GetShellCommand(fake, "echo hi").Output()
+	if err != nil {
+		t.Fatalf("running configured shell: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "myshell -c echo hi" {
+		t.Errorf("output = %q, want the fake shell to run with -c", got)
+	}
+}
+
+func TestInteractiveShell(t *testing.T) {
+	if got := InteractiveShell(" zsh "); got != "zsh" {
+		t.Errorf("InteractiveShell() = %q, want the configured zsh", got)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	t.Setenv("SHELL", "/bin/myshell")
+	if got := InteractiveShell(""); got != "/bin/myshell" {
+		t.Errorf("InteractiveShell() = %q, want $SHELL", got)
+	}
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+)
+
+// gitignoreRule is one pattern from a .gitignore file
+type gitignoreRule struct {
+	base     string // Folder of the .gitignore, slash-separated and relative to the walk root ("" for the root)
+	pattern  string
+	negate   bool // !pattern re-includes a path
+	dirOnly  bool // pattern/ matches folders only
+	anchored bool // Patterns containing a slash match from base, others match any name
+}
+
+// gitignore collects the rules of the .gitignore files met during a walk.
+// Rules only apply below the folder they were read from, so rules of
+// sibling folders can be kept without leaking into each other.
+type gitignore struct {
+	rules []gitignoreRule
+}
+
+// load reads the .gitignore in dir, if any. rel is dir relative to the walk
+// root in slash form. Folders are loaded in walk order, so rules of folders
+// the walk has left are dropped here; they cannot match anything again.
+func (g *gitignore) load(dir, rel string) {
+	kept := g.rules[:0]
+	for _, r := range g.rules {
+		if r.base == "" || r.base == rel || strings.HasPrefix(rel, r.base+"/") {
+			kept = append(kept, r)
+		}
+	}
+	g.rules = kept
+
+	f, err := os.Open(dir + string(os.PathSeparator) + ".gitignore")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := gitignoreRule{base: rel}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`) // \# and \! escape a leading character
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+		if rule.pattern != "" {
+			g.rules = append(g.rules, rule)
+		}
+	}
+}
+
+// ignored reports whether rel (slash form, relative to the walk root) is
+// ignored. As in git, the last matching rule wins.
+func (g *gitignore) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, r := range g.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if r.base != "" {
+			if !strings.HasPrefix(rel, r.base+"/") {
+				continue
+			}
+			sub = rel[len(r.base)+1:]
+		}
+		if !r.anchored {
+			sub = path.Base(sub)
+		}
+		if matchGlob(r.pattern, sub) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// matchGlob matches a slash-separated path against a gitignore glob, where
+// ** stands for any number of folders
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GoImportPath returns the Go import path of the package containing path
+// (a .go file or a directory), using the module line of the nearest
+// enclosing go.mod
+func GoImportPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	dir := abs
+	if info, err := os.Stat(abs); err != nil {
+		return "", err
+	} else if !info.IsDir() {
+		dir = filepath.Dir(abs)
+	}
+
+	for root := dir; ; {
+		gomod := filepath.Join(root, "go.mod")
+		if FileExists(gomod) {
+			module, err := readModulePath(gomod)
+			if err != nil {
+				return "", err
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil || rel == "." {
+				return module, err
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", fmt.Errorf("%s is not inside a Go module (no go.mod found)", abs)
+		}
+		root = parent
+	}
+}
+
+// readModulePath extracts the module path from a go.mod file
+func readModulePath(gomod string) (string, error) {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if rest, ok := strings.CutPrefix(line, "module"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			if module := strings.Trim(strings.TrimSpace(rest), `"`+"`"); module != "" {
+				return module, nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s has no module line", gomod)
+}
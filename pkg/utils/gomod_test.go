@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoImportPath(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) string {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	write("app/go.mod", "// Example\nmodule example.com/app // trailing\n\ngo 1.23\n")
+	mainFile := write("app/main.go", "package main\n")
+	handler := write("app/internal/api/handler.go", "package api\n")
+	write("app/tools/go.mod", "module \"example.com/app/tools\"\n")
+	tool := write("app/tools/gen/gen.go", "package gen\n")
+	loose := write("scratch/x.go", "package x\n")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{mainFile, "example.com/app"},
+		{handler, "example.com/app/internal/api"},
+		{filepath.Dir(handler), "example.com/app/internal/api"},
+		{tool, "example.com/app/tools/gen"}, // Nearest go.mod wins
+	}
+	for _, tt := range tests {
+		got, err := GoImportPath(tt.path)
+		if err != nil {
+			t.Errorf("GoImportPath(%s): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GoImportPath(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := GoImportPath(loose); err == nil || !strings.Contains(err.Error(), "not inside a Go module") {
+		t.Errorf("file outside a module: err = %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GrepMatch is a line of a file that matched a Grep query
+type GrepMatch struct {
+	Path string // Relative to the searched folder
+	Line int    // 1-based
+	Col  int    // 1-based, in characters
+	Text string // The matched line
+}
+
+// GrepOptions tune Grep. Zero values pick the defaults.
+type GrepOptions struct {
+	Exclude     []string // File and folder names never searched
+	Workers     int      // Files searched at once; defaults to the CPU count, at most 8
+	MaxFileSize int64    // Larger files are skipped; defaults to 4 MB
+}
+
+const defaultGrepMaxFileSize = 4 << 20
+
+// CompileGrepQuery turns a query into a pattern: a regular expression when
+// regex is set, a literal string otherwise. The match ignores case unless
+// the query contains an upper-case letter.
+func CompileGrepQuery(query string, regex bool) (*regexp.Regexp, error) {
+	expr := query
+	if !regex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if !strings.ContainsFunc(query, unicode.IsUpper) {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// Grep searches the files below root for re and sends every matching line
+// to out, closing it when done. Folders and files named in opts.Exclude or
+// ignored by a .gitignore are skipped, as are binary and oversized files.
+// Files are read by a pool of opts.Workers goroutines, so matches arrive
+// in no particular order. Cancelling ctx stops the search early.
+func Grep(ctx context.Context, root string, re *regexp.Regexp, opts GrepOptions, out chan<- GrepMatch) {
+	defer close(out)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = min(runtime.NumCPU(), 8)
+	}
+	maxSize := opts.MaxFileSize
+	if maxSize <= 0 {
+		maxSize = defaultGrepMaxFileSize
+	}
+	exclude := map[string]bool{}
+	for _, name := range opts.Exclude {
+		exclude[name] = true
+	}
+
+	files := make(chan string, workers*4)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rel := range files {
+				if !grepFile(ctx, filepath.Join(root, filepath.FromSlash(rel)), rel, re, out) {
+					return
+				}
+			}
+		}()
+	}
+
+	var ignore gitignore
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if path != root && (exclude[d.Name()] || ignore.ignored(rel, d.IsDir())) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path == root {
+				rel = ""
+			}
+			ignore.load(path, rel)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxSize {
+			return nil
+		}
+		select {
+		case files <- rel:
+		case <-ctx.Done():
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	close(files)
+	wg.Wait()
+}
+
+// grepFile sends the matching lines of one file. It reports false once ctx
+// is cancelled.
+func grepFile(ctx context.Context, path, rel string, re *regexp.Regexp, out chan<- GrepMatch) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	// Files with a NUL byte near the start are binary, as git decides
+	if head, _ := reader.Peek(8000); bytes.IndexByte(head, 0) >= 0 {
+		return true
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		loc := re.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		match := GrepMatch{
+			Path: rel,
+			Line: line,
+			Col:  utf8.RuneCountInString(text[:loc[0]]) + 1,
+			Text: strings.TrimRight(text, "\r"),
+		}
+		select {
+		case out <- match:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true // Also after a line over 1 MB ends the scan
+}
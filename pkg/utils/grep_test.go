@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestGrep(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":          "*.log\nbuild/\n!keep.log\n",
+		"main.go":             "package main\n\nfunc main() { TODO() }\n",
+		"pkg/util.go":         "// todo: tidy\nfunc héllo() { TODO() }\n",
+		"pkg/.gitignore":      "/gen.go\n",
+		"pkg/gen.go":          "TODO generated\n",
+		"debug.log":           "TODO ignored\n",
+		"keep.log":            "TODO kept\n",
+		"build/out.txt":       "TODO ignored\n",
+		"node_modules/x.js":   "TODO excluded\n",
+		"image.bin":           "TODO\x00binary",
+		"docs/pkg/gen.go":     "TODO not below pkg/\n",
+		"docs/deep/a/note.md": "nothing here\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	grep := func(query string, regex bool) []GrepMatch {
+		re, err := CompileGrepQuery(query, regex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make(chan GrepMatch)
+		go Grep(context.Background(), root, re, GrepOptions{Exclude: []string{"node_modules"}, Workers: 2}, out)
+		var matches []GrepMatch
+		for m := range out {
+			matches = append(matches, m)
+		}
+		sort.Slice(matches, func(i, j int) bool {
+			if matches[i].Path != matches[j].Path {
+				return matches[i].Path < matches[j].Path
+			}
+			return matches[i].Line < matches[j].Line
+		})
+		return matches
+	}
+
+	got := grep("TODO", false)
+	want := []GrepMatch{
+		{Path: "docs/pkg/gen.go", Line: 1, Col: 1, Text: "TODO not below pkg/"},
+		{Path: "keep.log", Line: 1, Col: 1, Text: "TODO kept"},
+		{Path: "main.go", Line: 3, Col: 15, Text: "func main() { TODO() }"},
+		{Path: "pkg/util.go", Line: 2, Col: 16, Text: "func héllo() { TODO() }"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Lower-case queries ignore case
+	if got := grep("todo", false); len(got) != 5 {
+		t.Errorf("todo: got %d matches, want 5: %+v", len(got), got)
+	}
+	if got := grep(`func \w+\(\)`, true); len(got) != 1 || got[0].Path != "main.go" {
+		t.Errorf("regex: got %+v, want only main.go", got)
+	}
+	if _, err := CompileGrepQuery("(", true); err == nil {
+		t.Error("invalid regex compiled")
+	}
+	// Literal queries are not regular expressions; columns count characters
+	if got := grep("llo() {", false); len(got) != 1 || got[0].Col != 8 {
+		t.Errorf("literal: got %+v, want pkg/util.go column 8", got)
+	}
+}
+
+func TestGrep_Cancel(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("x\nx\nx\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan GrepMatch)
+	re, _ := CompileGrepQuery("x", false)
+	go Grep(ctx, root, re, GrepOptions{}, out)
+
+	<-out
+	cancel()
+	for range out {
+		// Drains the matches already on their way; Grep must then close out
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -67,12 +68,23 @@ func FindExecutable(cmdName string, fallbackGlobs []string) string {
 // DeepSearchExecutable performs a more intensive search in specific root directories.
 // It looks for the cmdName in subdirectories of roots, but limits depth for performance.
 func DeepSearchExecutable(cmdName string, roots []string) string {
+	return DeepSearchExecutableContext(context.Background(), cmdName, roots)
+}
+
+// DeepSearchExecutableContext is DeepSearchExecutable that gives up as soon as ctx is done.
+func DeepSearchExecutableContext(ctx context.Context, cmdName string, roots []string) string {
 	// Common patterns for compilers to narrow down the search
 	// e.g. for "gcc" we might look for folders containing "mingw", "codeblocks", etc.
 	for _, root := range roots {
 		// We walk only 3 levels deep to avoid scanning the entire disk
 		found := ""
+		if ctx.Err() != nil {
+			return ""
+		}
 		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if ctx.Err() != nil {
+				return filepath.SkipAll
+			}
 			if err != nil {
 				return filepath.SkipDir
 			}
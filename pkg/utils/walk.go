@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WalkStats describes a WalkLimited run
+type WalkStats struct {
+	Roots     []string // Roots that were walked; missing ones and those inside earlier roots are left out
+	Visited   int
+	Skipped   int      // Folders that could not be read, e.g. for lack of permission
+	Examples  []string // The first few skipped folders
+	Truncated bool     // The walk stopped at the limit with paths left
+	Cancelled bool     // The walk was stopped through its context
+	Excluded  int      // Paths left out by WalkOptions; an excluded folder counts once
+	Duration  time.Duration
+}
+
+// WalkOptions leaves paths out of a walk
+type WalkOptions struct {
+	Exclude   []string // File and folder names never walked, e.g. node_modules
+	Gitignore bool     // Also skip what the .gitignore files met on the way ignore
+}
+
+// maxSkipExamples caps WalkStats.Examples
+const maxSkipExamples = 10
+
+// WalkLimited walks roots in order and calls visit with every path found,
+// stopping once limit paths were visited (limit <= 0 means no limit).
+// A root inside an earlier root is skipped there, so listing specific
+// folders before broad ones gets them indexed first. Unreadable folders are
+// skipped and counted in the returned stats.
+func WalkLimited(roots []string, limit int, visit func(path string)) WalkStats {
+	return WalkLimitedContext(context.Background(), roots, limit, WalkOptions{}, visit)
+}
+
+// WalkLimitedContext is WalkLimited that leaves out what opts excludes and
+// stops early, with Cancelled set, once ctx is done
+func WalkLimitedContext(ctx context.Context, roots []string, limit int, opts WalkOptions, visit func(path string)) (stats WalkStats) {
+	start := time.Now()
+	defer func() { stats.Duration = time.Since(start) }()
+
+	exclude := map[string]bool{}
+	for _, name := range opts.Exclude {
+		exclude[name] = true
+	}
+
+	var walked []string
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		if ctx.Err() != nil {
+			stats.Cancelled = true
+			return stats
+		}
+		if underAny(root, walked) {
+			continue
+		}
+		if _, err := os.Stat(root); err != nil {
+			continue
+		}
+		stats.Roots = append(stats.Roots, root)
+		var ignore gitignore // Rules are relative to the root they were read under
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if ctx.Err() != nil {
+				stats.Cancelled = true
+				return filepath.SkipAll
+			}
+			if err != nil {
+				if d != nil && d.IsDir() {
+					stats.Skipped++
+					if len(stats.Examples) < maxSkipExamples {
+						stats.Examples = append(stats.Examples, path)
+					}
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() && path != root && underAny(path, walked) {
+				return filepath.SkipDir
+			}
+			if path != root && (exclude[d.Name()] || (opts.Gitignore && ignore.ignored(walkRel(root, path), d.IsDir()))) {
+				stats.Excluded++
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if opts.Gitignore && d.IsDir() {
+				ignore.load(path, walkRel(root, path))
+			}
+			if limit > 0 && stats.Visited >= limit {
+				stats.Truncated = true
+				return filepath.SkipAll
+			}
+			visit(path)
+			stats.Visited++
+			return nil
+		})
+		if stats.Truncated || stats.Cancelled {
+			return stats
+		}
+		walked = append(walked, root)
+	}
+	return stats
+}
+
+// walkRel is path relative to root in the slash form gitignore expects
+func walkRel(root, path string) string {
+	if path == root {
+		return ""
+	}
+	rel, _ := filepath.Rel(root, path)
+	return filepath.ToSlash(rel)
+}
+
+// underAny reports whether path is one of dirs or inside one of them
+func underAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalkLimited(t *testing.T) {
+	root := t.TempDir()
+	projects := filepath.Join(root, "Projects")
+	for _, dir := range []string{filepath.Join(projects, "app"), filepath.Join(root, "other")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{"Projects/app/main.go", "Projects/app/go.mod", "other/a.txt", "other/b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(root, f), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roots := []string{projects, filepath.Join(root, "missing"), root}
+
+	var all []string
+	stats := WalkLimited(roots, 0, func(p string) { all = append(all, p) })
+	if stats.Truncated {
+		t.Error("unlimited walk reported truncation")
+	}
+	if len(stats.Roots) != 2 || stats.Roots[0] != projects || stats.Roots[1] != root {
+		t.Errorf("walked roots %q, want Projects then the root", stats.Roots)
+	}
+	if stats.Visited != len(all) || stats.Skipped != 0 {
+		t.Errorf("stats %+v, want %d visited and none skipped", stats, len(all))
+	}
+	// root, Projects and its 3 entries once each, other/ with 2 files, c.txt
+	if len(all) != 9 {
+		t.Errorf("visited %d paths, want 9: %q", len(all), all)
+	}
+	seen := map[string]bool{}
+	for _, p := range all {
+		if seen[p] {
+			t.Errorf("%s visited twice", p)
+		}
+		seen[p] = true
+	}
+
+	var capped []string
+	if !WalkLimited(roots, 4, func(p string) { capped = append(capped, p) }).Truncated {
+		t.Error("capped walk did not report truncation")
+	}
+	if len(capped) != 4 {
+		t.Fatalf("visited %d paths, want the cap of 4", len(capped))
+	}
+	// The earlier, more specific root is indexed first
+	for _, p := range capped {
+		if p != projects && filepath.Dir(p) != projects && filepath.Dir(filepath.Dir(p)) != projects {
+			t.Errorf("%s visited before Projects was finished", p)
+		}
+	}
+
+	var exact []string
+	if WalkLimited(roots, len(all), func(p string) { exact = append(exact, p) }).Truncated {
+		t.Error("walk that fits the cap exactly reported truncation")
+	}
+}
+
+func TestWalkLimitedContextCancel(t *testing.T) {
+	root := t.TempDir()
+	for _, f := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(root, f), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var visited []string
+	stats := WalkLimitedContext(ctx, []string{root}, 0, WalkOptions{}, func(p string) {
+		visited = append(visited, p)
+		if len(visited) == 2 {
+			cancel()
+		}
+	})
+	if !stats.Cancelled || stats.Truncated {
+		t.Errorf("stats %+v, want cancelled and not truncated", stats)
+	}
+	if len(visited) != 2 {
+		t.Errorf("visited %d paths after cancelling at 2: %q", len(visited), visited)
+	}
+
+	if stats := WalkLimitedContext(ctx, []string{root}, 0, WalkOptions{}, func(string) { t.Error("visited after cancel") }); !stats.Cancelled || len(stats.Roots) != 0 {
+		t.Errorf("walk with a done context: %+v", stats)
+	}
+}
+
+func TestWalkLimitedContextOptions(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"app/main.go":             "",
+		"app/node_modules/x/a.js": "",
+		"app/.gitignore":          "*.log\nbin/\n",
+		"app/debug.log":           "",
+		"app/bin/app":             "",
+		"app/src/bin/keep.go":     "",
+		"other/debug.log":         "",
+		"other/build/out.txt":     "",
+		"other/sub/.gitignore":    "keep.txt\n",
+		"other/sub/keep.txt":      "",
+		"other/zz/keep.txt":       "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := WalkOptions{Exclude: []string{"node_modules", "build"}, Gitignore: true}
+	seen := map[string]bool{}
+	stats := WalkLimitedContext(context.Background(), []string{root}, 0, opts, func(p string) {
+		rel, _ := filepath.Rel(root, p)
+		seen[filepath.ToSlash(rel)] = true
+	})
+
+	for _, want := range []string{"app/main.go", "app/.gitignore", "app/src", "other/debug.log", "other/zz/keep.txt"} {
+		if !seen[want] {
+			t.Errorf("%s was not visited", want)
+		}
+	}
+	// Excluded names, app's .gitignore (bin/ has no slash inside, so it
+	// matches src/bin too) and sub's rules, which must not leak into zz/
+	for _, skip := range []string{"app/node_modules", "app/debug.log", "app/bin", "app/src/bin", "other/build", "other/sub/keep.txt"} {
+		if seen[skip] {
+			t.Errorf("%s was visited", skip)
+		}
+	}
+	if stats.Excluded == 0 {
+		t.Error("no paths counted as excluded")
+	}
+}
@@ -0,0 +1,43 @@
+package utils
+
+import "strings"
+
+// CleanWhitespace strips spaces and tabs at the end of every line (trim)
+// and ends non-empty content with exactly one newline (finalNewline).
+// cursor is a byte offset into content; the returned one points at the same
+// place in the cleaned text, or the end of its line if that was trimmed away.
+func CleanWhitespace(content string, cursor int, trim, finalNewline bool) (string, int) {
+	cursor = max(0, min(cursor, len(content)))
+	if trim {
+		var b strings.Builder
+		b.Grow(len(content))
+		newCursor, start := cursor, 0
+		for start <= len(content) {
+			end := strings.IndexByte(content[start:], '\n')
+			if end < 0 {
+				end = len(content)
+			} else {
+				end += start
+			}
+			line := strings.TrimRight(content[start:end], " \t")
+			if cursor >= start && cursor <= end {
+				newCursor = b.Len() + min(cursor-start, len(line))
+			}
+			b.WriteString(line)
+			if end < len(content) {
+				b.WriteByte('\n')
+			}
+			start = end + 1
+		}
+		content, cursor = b.String(), newCursor
+	}
+	if finalNewline && content != "" {
+		body := strings.TrimRight(content, "\n")
+		if body == "" {
+			return "", 0 // Nothing but blank lines
+		}
+		content = body + "\n"
+		cursor = min(cursor, len(content))
+	}
+	return content, cursor
+}
@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestCleanWhitespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		cursor        int
+		trim, newline bool
+		want          string
+		wantCursor    int
+	}{
+		{"off", "a  \nb", 3, false, false, "a  \nb", 3},
+		{"trim", "a  \n\tb\t\nc ", 0, true, false, "a\n\tb\nc", 0},
+		{"cursor after trimmed line", "ab  \ncd", 6, true, false, "ab\ncd", 4},
+		{"cursor in trimmed spaces", "ab  \ncd", 3, true, false, "ab\ncd", 2},
+		{"cursor at end", "ab \n", 4, true, false, "ab\n", 3},
+		{"final newline added", "a", 1, false, true, "a\n", 1},
+		{"extra newlines collapsed", "a\n\n\n", 4, false, true, "a\n", 2},
+		{"both", "a \n  \n", 6, true, true, "a\n", 2},
+		{"blank buffer", "\n\n", 1, false, true, "", 0},
+		{"empty buffer", "", 0, true, true, "", 0},
+		{"multibyte", "héllo  \n", 7, true, true, "héllo\n", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor := CleanWhitespace(tt.content, tt.cursor, tt.trim, tt.newline)
+			if got != tt.want || cursor != tt.wantCursor {
+				t.Errorf("CleanWhitespace(%q, %d) = %q, %d; want %q, %d", tt.content, tt.cursor, got, cursor, tt.want, tt.wantCursor)
+			}
+		})
+	}
+}
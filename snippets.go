@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/phravins/devcli/internal/boilerplate"
+	"github.com/phravins/devcli/internal/snippets"
+	"github.com/spf13/cobra"
+)
+
+var snippetsCmd = &cobra.Command{
+	Use:   "snippets",
+	Short: "List, print and save code snippets",
+	Long: `Scriptable access to the snippets shown in the TUI: the built-in boilerplate snippets and your Snippet Library.
+
+  devcli snippets list [--lang go]
+  devcli snippets show "CRUD API" go > crud.go
+  devcli snippets add "Retry helper" --lang go --file retry.go
+  cat retry.go | devcli snippets add "Retry helper" --lang go`,
+}
+
+var snippetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the available snippets and their languages",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang, _ := cmd.Flags().GetString("lang")
+		library, err := loadLibrary()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		defer w.Flush()
+
+		fmt.Fprintln(w, "Boilerplate snippets:")
+		for _, key := range boilerplate.SnippetKeys() {
+			s := boilerplate.Snippets[key]
+			if _, _, ok := s.Code(lang); lang != "" && !ok {
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", key, strings.Join(s.Languages(), ", "))
+		}
+
+		fmt.Fprintln(w, "\nSnippet Library:")
+		for _, s := range library {
+			if lang != "" && !strings.EqualFold(s.Language, lang) {
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Title, s.Language, s.ID)
+		}
+	},
+}
+
+var snippetsShowCmd = &cobra.Command{
+	Use:   "show <name> [language]",
+	Short: "Print a snippet's code to stdout",
+	Long:  `Prints a snippet so it can be redirected to a file. name is a boilerplate snippet (key or title) or a Snippet Library title or ID, matched ignoring case. Boilerplate snippets written in several languages need the language.`,
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := ""
+		if len(args) > 1 {
+			lang = args[1]
+		}
+		code, err := snippetCode(args[0], lang)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(code)
+		if !strings.HasSuffix(code, "\n") {
+			fmt.Println()
+		}
+	},
+}
+
+var snippetsAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Save a snippet to the Snippet Library",
+	Long:  `Saves code from --file, or from stdin when it is piped, as a new Snippet Library entry. It shows up in the TUI's Snippet Library.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang, _ := cmd.Flags().GetString("lang")
+		file, _ := cmd.Flags().GetString("file")
+		desc, _ := cmd.Flags().GetString("desc")
+		category, _ := cmd.Flags().GetString("category")
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+
+		code, err := readSnippetCode(file)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		storage, err := snippets.NewStorage()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		snip := snippets.Snippet{
+			Title:       strings.TrimSpace(args[0]),
+			Description: desc,
+			Language:    strings.ToLower(lang),
+			Category:    category,
+			Code:        code,
+			Tags:        tags,
+		}
+		if err := storage.Add(snip); err != nil {
+			fmt.Printf("Error saving snippet: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Saved %q (%s) to the Snippet Library\n", snip.Title, snip.Language)
+	},
+}
+
+func init() {
+	snippetsListCmd.Flags().String("lang", "", "Only list snippets in this language")
+	snippetsAddCmd.Flags().String("lang", "", "Language of the snippet, e.g. go or python (required)")
+	snippetsAddCmd.Flags().String("file", "", "Read the code from this file instead of stdin")
+	snippetsAddCmd.Flags().String("desc", "", "Short description")
+	snippetsAddCmd.Flags().String("category", "", "Category, e.g. api or database")
+	snippetsAddCmd.Flags().StringSlice("tags", nil, "Comma-separated tags used by search")
+	snippetsAddCmd.MarkFlagRequired("lang")
+	snippetsCmd.AddCommand(snippetsListCmd, snippetsShowCmd, snippetsAddCmd)
+}
+
+func loadLibrary() ([]snippets.Snippet, error) {
+	storage, err := snippets.NewStorage()
+	if err != nil {
+		return nil, err
+	}
+	return storage.Library()
+}
+
+// snippetCode finds name among the boilerplate snippets, then in the
+// Snippet Library
+func snippetCode(name, lang string) (string, error) {
+	if key, s, ok := boilerplate.FindSnippet(name); ok {
+		langs := s.Languages()
+		if lang == "" && len(langs) == 1 {
+			lang = langs[0]
+		}
+		if code, _, ok := s.Code(lang); ok {
+			return code, nil
+		}
+		if lang == "" {
+			return "", fmt.Errorf("%s is available in %s; pass one as the second argument", key, strings.Join(langs, ", "))
+		}
+		return "", fmt.Errorf("%s has no %s version (available: %s)", key, lang, strings.Join(langs, ", "))
+	}
+
+	storage, err := snippets.NewStorage()
+	if err != nil {
+		return "", err
+	}
+	snip, err := storage.Find(name)
+	if err != nil {
+		return "", fmt.Errorf("no snippet named %q (see devcli snippets list)", name)
+	}
+	if lang != "" && !strings.EqualFold(snip.Language, lang) {
+		return "", fmt.Errorf("%s is written in %s, not %s", snip.Title, snip.Language, lang)
+	}
+	return snip.Code, nil
+}
+
+// readSnippetCode reads file, or stdin when no file is given and stdin is
+// not a terminal
+func readSnippetCode(file string) (string, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		data, err = os.ReadFile(file)
+	} else {
+		if st, statErr := os.Stdin.Stat(); statErr == nil && st.Mode()&os.ModeCharDevice != 0 {
+			return "", fmt.Errorf("pass --file or pipe the code on stdin")
+		}
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", fmt.Errorf("the snippet is empty")
+	}
+	return string(data), nil
+}